@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	iomodes "github.com/angelsolaorbaiceta/inkfem/io/modes"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/spf13/cobra"
+)
+
+var (
+	buckleIncludeOwnWeight bool
+	buckleDispMaxError     float64
+	buckleUseVerbose       bool
+	buckleSolver           string
+	buckleDofNumbering     string
+	buckleLoadCase         string
+	buckleModesCount       int
+
+	buckleCommand = &cobra.Command{
+		Use:   "buckle <inkfem|inkfempre file path>",
+		Short: "Computes the critical load factors and buckling modes of the structure",
+		Long: `Computes the elastic critical load factors and buckling modes of the structure given in an .inkfem or preprocessed .inkfempre file, and saves them in an .inkfembuck file.
+
+The structure is first solved for the loads in the chosen load case or combination to obtain the axial forces in its bars.
+These are used to assemble the geometric stiffness matrix, and the lowest load factors that make the structure unstable are computed.
+The loads multiplied by a critical load factor make the structure buckle following the shape of the corresponding mode.
+
+Bars that aren't sliced, like axial members, are modeled with a single finite element, which overestimates their own buckling load.`,
+		Args: cobra.ExactArgs(1),
+		Run:  buckleStructure,
+	}
+)
+
+func init() {
+	buckleCommand.
+		Flags().
+		BoolVarP(&buckleIncludeOwnWeight, "weight", "w", false, "include the weight of each bar as a distributed load")
+
+	buckleCommand.
+		Flags().
+		Float64VarP(&buckleDispMaxError, "error", "e", 1e-5, "maximum allowed displacement error")
+
+	buckleCommand.
+		Flags().
+		BoolVarP(&buckleUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	buckleCommand.
+		Flags().
+		StringVar(&buckleSolver, "solver", string(process.DirectSolver), "the system of equations solver. Use one of: direct, pcg")
+
+	buckleCommand.
+		Flags().
+		StringVarP(&buckleDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	buckleCommand.
+		Flags().
+		StringVarP(&buckleLoadCase, "case", "c", load.DefaultCase, "the load case or combination whose loads are applied")
+
+	buckleCommand.
+		Flags().
+		IntVarP(&buckleModesCount, "modes", "m", 3, "the number of buckling modes to compute")
+
+	rootCmd.AddCommand(buckleCommand)
+}
+
+func buckleStructure(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(buckleUseVerbose)
+
+	solver, err := process.ParseSolverType(buckleSolver)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	dofNumbering, err := preprocess.ParseDofNumbering(buckleDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	log.StartProcess()
+
+	var (
+		inputFilePath = args[0]
+		outPath       = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		preStructure  *preprocess.Structure
+	)
+
+	if inkio.IsDefinitionFile(inputFilePath) {
+		var (
+			structure = readStructureFromFile(inputFilePath)
+			options   = &preprocess.PreprocessOptions{
+				IncludeOwnWeight: buckleIncludeOwnWeight,
+				DofNumbering:     dofNumbering,
+			}
+		)
+
+		preStructure = preprocessStructure(structure, options)
+	} else if inkio.IsPreprocessedFile(inputFilePath) {
+		preStructure = readPreprocessedStructureFromFile(inputFilePath)
+	} else {
+		panic(
+			fmt.Sprintf(
+				"Unsupported file type: %s. Expected %s or %s\n",
+				inputFilePath, inkio.DefinitionFileExt, inkio.PreFileExt,
+			),
+		)
+	}
+
+	var (
+		bucklingOptions = process.BucklingOptions{
+			SolveOptions: process.SolveOptions{
+				OutputPath:            outPath,
+				MaxDisplacementsError: buckleDispMaxError,
+				Solver:                solver,
+			},
+			LoadCase:   buckleLoadCase,
+			ModesCount: buckleModesCount,
+		}
+		solution = process.SolveBuckling(preStructure, bucklingOptions)
+		file     = inkio.CreateFile(outPath + inkio.BucklingFileExt)
+	)
+	defer file.Close()
+
+	iomodes.WriteBuckling(solution, file)
+
+	log.Result()
+}
@@ -6,9 +6,11 @@ import (
 	"github.com/angelsolaorbaiceta/inkfem/io"
 	iodef "github.com/angelsolaorbaiceta/inkfem/io/def"
 	iopre "github.com/angelsolaorbaiceta/inkfem/io/pre"
+	iorecord "github.com/angelsolaorbaiceta/inkfem/io/record"
 	"github.com/angelsolaorbaiceta/inkfem/log"
 	"github.com/angelsolaorbaiceta/inkfem/preprocess"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 )
 
 // readStructureFromFile reads the structure definition from the given .inkfem file.
@@ -62,3 +64,24 @@ func preprocessStructure(
 
 	return preprocessedStructure
 }
+
+// readTimeFunctionFromFile reads a time function, like a ground acceleration record, from the
+// given plain text file. Returns nil if no file path is given.
+func readTimeFunctionFromFile(filePath string) *load.TimeFunction {
+	if filePath == "" {
+		return nil
+	}
+
+	file := io.OpenFile(filePath)
+	defer file.Close()
+
+	return iorecord.Read(file)
+}
+
+// readResponseSpectrumFromFile reads a design response spectrum from the given plain text file.
+func readResponseSpectrumFromFile(filePath string) *load.ResponseSpectrum {
+	file := io.OpenFile(filePath)
+	defer file.Close()
+
+	return iorecord.ReadSpectrum(file)
+}
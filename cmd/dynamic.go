@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	gomath "math"
+	"os"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	iohistory "github.com/angelsolaorbaiceta/inkfem/io/history"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dynamicUseVerbose   bool
+	dynamicDofNumbering string
+	dynamicLoadCase     string
+	dynamicLoadFunction string
+	dynamicGroundX      string
+	dynamicGroundY      string
+	dynamicTimeStep     float64
+	dynamicDuration     float64
+	dynamicAlpha        float64
+	dynamicBeta         float64
+	dynamicGamma        float64
+	dynamicLumped       bool
+	dynamicGravity      float64
+	dynamicDampingRatio float64
+	dynamicDampingFreqs []float64
+	dynamicNodes        []string
+	dynamicBars         []string
+
+	dynamicCommand = &cobra.Command{
+		Use:   "dynamic <inkfem|inkfempre file path>",
+		Short: "Computes the linear time-history response of the structure",
+		Long: `Computes the linear dynamic response of the structure given in an .inkfem or preprocessed .inkfempre file over time, and saves the time series of the chosen nodes and bars in an .inkfemhist file.
+
+The structure is excited by the loads of the chosen load case multiplied by the load function (--load-fn), by the ground accelerations in X and Y (--ground-x and --ground-y), or by both.
+These time functions are read from plain text files with a "<time> <value>" pair in each line, and are linearly interpolated between the points.
+
+The equations of motion are integrated using the HHT-α method, which is the Newmark-β average acceleration method with the default parameters.
+The mass matrix is assembled as in the modal command, and the Rayleigh damping matrix yields the given damping ratio at the two damping frequencies, or those of the first two vibration modes if none are given.
+
+The displacements, relative to the ground, of the chosen nodes, the reactions of those which are constrained, and the axial force, shear force and bending moment at the ends of the chosen bars are saved at each time step.`,
+		Args: cobra.ExactArgs(1),
+		Run:  solveDynamicStructure,
+	}
+)
+
+func init() {
+	dynamicCommand.
+		Flags().
+		BoolVarP(&dynamicUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	dynamicCommand.
+		Flags().
+		StringVarP(&dynamicDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	dynamicCommand.
+		Flags().
+		StringVarP(&dynamicLoadCase, "case", "c", load.DefaultCase, "the load case whose loads are multiplied by the load function")
+
+	dynamicCommand.
+		Flags().
+		StringVar(&dynamicLoadFunction, "load-fn", "", "the file with the load function, the factor of the load case's loads over time")
+
+	dynamicCommand.
+		Flags().
+		StringVar(&dynamicGroundX, "ground-x", "", "the file with the ground acceleration record in the X direction")
+
+	dynamicCommand.
+		Flags().
+		StringVar(&dynamicGroundY, "ground-y", "", "the file with the ground acceleration record in the Y direction")
+
+	dynamicCommand.
+		Flags().
+		Float64Var(&dynamicTimeStep, "dt", 0.01, "the integration time step")
+
+	dynamicCommand.
+		Flags().
+		Float64Var(&dynamicDuration, "duration", 0, "the analysis duration; the longest time function's if not given")
+
+	dynamicCommand.
+		Flags().
+		Float64Var(&dynamicAlpha, "alpha", 0, "the HHT-α parameter, between -1/3 and 0")
+
+	dynamicCommand.
+		Flags().
+		Float64Var(&dynamicBeta, "beta", 0, "the Newmark β parameter; derived from α if not given")
+
+	dynamicCommand.
+		Flags().
+		Float64Var(&dynamicGamma, "gamma", 0, "the Newmark γ parameter; derived from α if not given")
+
+	dynamicCommand.
+		Flags().
+		BoolVar(&dynamicLumped, "lumped", false, "use lumped mass matrices instead of consistent ones")
+
+	dynamicCommand.
+		Flags().
+		Float64Var(&dynamicGravity, "gravity", 1.0, "the gravity acceleration dividing the density to obtain the mass")
+
+	dynamicCommand.
+		Flags().
+		Float64Var(&dynamicDampingRatio, "damping", 0, "the Rayleigh damping ratio")
+
+	dynamicCommand.
+		Flags().
+		Float64SliceVar(&dynamicDampingFreqs, "damping-freqs", []float64{}, "the two frequencies, in hertz, with the given damping ratio, separated by commas")
+
+	dynamicCommand.
+		Flags().
+		StringSliceVar(&dynamicNodes, "nodes", []string{}, "the nodes whose displacements (and reactions) are saved, separated by commas")
+
+	dynamicCommand.
+		Flags().
+		StringSliceVar(&dynamicBars, "bars", []string{}, "the bars whose end forces are saved, separated by commas")
+
+	rootCmd.AddCommand(dynamicCommand)
+}
+
+func solveDynamicStructure(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(dynamicUseVerbose)
+
+	dofNumbering, err := preprocess.ParseDofNumbering(dynamicDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if dynamicLoadFunction == "" && dynamicGroundX == "" && dynamicGroundY == "" {
+		fmt.Println("Use a load function (--load-fn) or a ground acceleration record (--ground-x, --ground-y)")
+		os.Exit(1)
+	}
+
+	var dampingFreqs [2]float64
+	switch len(dynamicDampingFreqs) {
+	case 0:
+	case 2:
+		dampingFreqs = [2]float64{2.0 * gomath.Pi * dynamicDampingFreqs[0], 2.0 * gomath.Pi * dynamicDampingFreqs[1]}
+	default:
+		fmt.Println("The damping frequencies must be two values separated by commas")
+		os.Exit(1)
+	}
+
+	log.StartProcess()
+
+	var (
+		inputFilePath = args[0]
+		outPath       = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		preStructure  *preprocess.Structure
+	)
+
+	if inkio.IsDefinitionFile(inputFilePath) {
+		var (
+			structure = readStructureFromFile(inputFilePath)
+			options   = &preprocess.PreprocessOptions{DofNumbering: dofNumbering}
+		)
+
+		preStructure = preprocessStructure(structure, options)
+	} else if inkio.IsPreprocessedFile(inputFilePath) {
+		preStructure = readPreprocessedStructureFromFile(inputFilePath)
+	} else {
+		panic(
+			fmt.Sprintf(
+				"Unsupported file type: %s. Expected %s or %s\n",
+				inputFilePath, inkio.DefinitionFileExt, inkio.PreFileExt,
+			),
+		)
+	}
+
+	nodes := make([]contracts.StrID, len(dynamicNodes))
+	for i, id := range dynamicNodes {
+		nodes[i] = contracts.StrID(id)
+	}
+
+	bars := make([]contracts.StrID, len(dynamicBars))
+	for i, id := range dynamicBars {
+		bars[i] = contracts.StrID(id)
+	}
+
+	var (
+		timeHistoryOptions = process.TimeHistoryOptions{
+			LoadCase:            dynamicLoadCase,
+			LoadFunction:        readTimeFunctionFromFile(dynamicLoadFunction),
+			GroundAccelerationX: readTimeFunctionFromFile(dynamicGroundX),
+			GroundAccelerationY: readTimeFunctionFromFile(dynamicGroundY),
+			TimeStep:            dynamicTimeStep,
+			Duration:            dynamicDuration,
+			Alpha:               dynamicAlpha,
+			Beta:                dynamicBeta,
+			Gamma:               dynamicGamma,
+			Lumped:              dynamicLumped,
+			Gravity:             dynamicGravity,
+			DampingRatio:        dynamicDampingRatio,
+			DampingFrequencies:  dampingFreqs,
+			Nodes:               nodes,
+			Bars:                bars,
+		}
+		solution = process.SolveTimeHistory(preStructure, timeHistoryOptions)
+		file     = inkio.CreateFile(outPath + inkio.TimeHistoryFileExt)
+	)
+	defer file.Close()
+
+	iohistory.Write(solution, file)
+
+	log.Result()
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	gomath "math"
+	"os"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	ioinfluence "github.com/angelsolaorbaiceta/inkfem/io/influence"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/plot"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/spf13/cobra"
+)
+
+var (
+	influenceUseVerbose   bool
+	influenceDofNumbering string
+	influencePath         []string
+	influenceQuantities   []string
+	influencePlot         bool
+	influencePlotScale    float64
+	influenceLineScale    float64
+	influenceUseDarkTheme bool
+
+	influenceCommand = &cobra.Command{
+		Use:   "influence <inkfem file path>",
+		Short: "Computes the influence lines of reactions and bar forces for a moving unit load",
+		Long: `Computes the influence lines of the structure given in an .inkfem file: the values of reactions, bar end forces or bending moments as a unit load, acting downwards, travels along a path of bars.
+The influence lines are saved as comma separated values in an .influence.csv file, with a row for each position of the unit load.
+
+The path (--path) is the sequence of bars the load travels along, each from its start to its end node.
+The load is placed in the nodes of the sliced bars.
+Each quantity (-q) is given as one of:
+
+  - reaction:<node>:<fx|fy|mz>, the reaction in a node, in global coordinates
+  - end:<bar>:<start|end>:<fx|fy|mz>, the force at an end of a bar, in the bar's local coordinates
+  - moment:<bar>:<t>, the bending moment of a bar at the position t
+
+The stiffness matrix is factorized once, and each influence line is obtained with a single solve using the Müller-Breslau principle.
+The tension-only and compression-only limits of the bars are ignored.
+When the --plot flag is used, each influence line is plotted over the structure to its own SVG file.`,
+		Args: cobra.ExactArgs(1),
+		Run:  influenceStructure,
+	}
+)
+
+func init() {
+	influenceCommand.
+		Flags().
+		BoolVarP(&influenceUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	influenceCommand.
+		Flags().
+		StringVarP(&influenceDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	influenceCommand.
+		Flags().
+		StringSliceVar(&influencePath, "path", []string{}, "the bars the unit load travels along, in order, separated by commas")
+	influenceCommand.MarkFlagRequired("path")
+
+	influenceCommand.
+		Flags().
+		StringArrayVarP(&influenceQuantities, "quantity", "q", []string{}, "a quantity whose influence line is computed. Can be repeated")
+	influenceCommand.MarkFlagRequired("quantity")
+
+	influenceCommand.
+		Flags().
+		BoolVar(&influencePlot, "plot", false, "plot each of the influence lines to an SVG file")
+
+	influenceCommand.
+		Flags().
+		Float64VarP(&influencePlotScale, "scale", "s", 0.25, "Plot scale")
+
+	influenceCommand.
+		Flags().
+		Float64Var(&influenceLineScale, "line-scale", 0.2, "Size of the largest influence line ordinate, relative to the longest bar")
+
+	influenceCommand.
+		Flags().
+		BoolVarP(&influenceUseDarkTheme, "dark", "d", false, "use a dark theme for the plots")
+
+	rootCmd.AddCommand(influenceCommand)
+}
+
+func influenceStructure(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(influenceUseVerbose)
+
+	dofNumbering, err := preprocess.ParseDofNumbering(influenceDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	quantities := make([]process.InfluenceQuantity, len(influenceQuantities))
+	for i, spec := range influenceQuantities {
+		if quantities[i], err = process.ParseInfluenceQuantity(spec); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	path := make([]contracts.StrID, len(influencePath))
+	for i, id := range influencePath {
+		path[i] = contracts.StrID(id)
+	}
+
+	inputFilePath := args[0]
+	if !inkio.IsDefinitionFile(inputFilePath) {
+		panic(
+			fmt.Sprintf("Unsupported file type: %s. Expected %s\n", inputFilePath, inkio.DefinitionFileExt),
+		)
+	}
+
+	log.StartProcess()
+
+	var (
+		outPath      = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		structure    = readStructureFromFile(inputFilePath)
+		preStructure = preprocessStructure(structure, &preprocess.PreprocessOptions{
+			DofNumbering: dofNumbering,
+		})
+		solution = process.SolveInfluenceLines(preStructure, process.InfluenceOptions{
+			Path:       path,
+			Quantities: quantities,
+		})
+		file = inkio.CreateFile(outPath + inkio.InfluenceFileExt)
+	)
+	defer file.Close()
+
+	ioinfluence.Write(solution, file)
+
+	if influencePlot {
+		plotInfluenceLines(outPath, structure, solution)
+	}
+
+	log.Result()
+}
+
+// plotInfluenceLines plots each of the influence lines over the structure to an SVG file, named
+// after the line's index.
+func plotInfluenceLines(outPath string, st *structure.Structure, solution *process.InfluenceSolution) {
+	var (
+		options = &plot.StructurePlotOps{
+			Scale:          influencePlotScale,
+			MinMargin:      150,
+			ModeShapeScale: influenceLineScale,
+		}
+		config = plot.DefaultPlotConfig()
+	)
+
+	if influenceUseDarkTheme {
+		config = plot.DarkPlotConfig()
+	}
+
+	for i, line := range solution.Lines {
+		var (
+			maxValue = 0.0
+			plotLine = &plot.InfluenceLine{
+				Ordinates: make(map[contracts.StrID][]plot.InfluenceOrdinate, len(solution.Path)),
+			}
+		)
+
+		for _, value := range line.Values {
+			maxValue = gomath.Max(maxValue, gomath.Abs(value))
+		}
+
+		plotLine.Title = fmt.Sprintf("Influence line of %s: max |value| = %g", line.Quantity, maxValue)
+
+		for j, point := range solution.Points {
+			value := 0.0
+			if maxValue > 0 {
+				value = line.Values[j] / maxValue
+			}
+
+			plotLine.Ordinates[point.ElementID] = append(
+				plotLine.Ordinates[point.ElementID],
+				plot.InfluenceOrdinate{T: point.T, Value: value},
+			)
+		}
+
+		linePlotFile := inkio.CreateFile(fmt.Sprintf("%s.influence_%d.svg", outPath, i+1))
+		plot.InfluenceLineToSVG(st, plotLine, options, config, linePlotFile)
+		linePlotFile.Close()
+	}
+}
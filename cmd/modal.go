@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	iomodes "github.com/angelsolaorbaiceta/inkfem/io/modes"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/spf13/cobra"
+)
+
+var (
+	modalUseVerbose   bool
+	modalDofNumbering string
+	modalModesCount   int
+	modalLumped       bool
+	modalGravity      float64
+
+	modalCommand = &cobra.Command{
+		Use:   "modal <inkfem|inkfempre file path>",
+		Short: "Computes the natural frequencies and vibration modes of the structure",
+		Long: `Computes the lowest natural frequencies and vibration modes of the structure given in an .inkfem or preprocessed .inkfempre file, and saves them in an .inkfemmodal file.
+
+The mass of the bars is derived from the density of their material, and is distributed using a consistent mass matrix, or a lumped one with the --lumped flag.
+The nodal masses in the definition's |masses| section are added to the bars' mass.
+If the materials' density is a weight per unit volume, use the --gravity flag to convert it into a mass.
+
+For each mode, the frequency, period, participation factors and the ratio of the total mass it mobilizes in the X and Y directions are written, followed by its shape.
+
+Bars that aren't sliced, like axial members, are modeled with a single finite element, thus their own local vibration modes aren't accurately captured.`,
+		Args: cobra.ExactArgs(1),
+		Run:  modalStructure,
+	}
+)
+
+func init() {
+	modalCommand.
+		Flags().
+		BoolVarP(&modalUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	modalCommand.
+		Flags().
+		StringVarP(&modalDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	modalCommand.
+		Flags().
+		IntVarP(&modalModesCount, "modes", "m", 3, "the number of vibration modes to compute")
+
+	modalCommand.
+		Flags().
+		BoolVar(&modalLumped, "lumped", false, "use lumped mass matrices instead of consistent ones")
+
+	modalCommand.
+		Flags().
+		Float64Var(&modalGravity, "gravity", 1.0, "the gravity acceleration dividing the density to obtain the mass")
+
+	rootCmd.AddCommand(modalCommand)
+}
+
+func modalStructure(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(modalUseVerbose)
+
+	dofNumbering, err := preprocess.ParseDofNumbering(modalDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if modalGravity <= 0 {
+		fmt.Println("The gravity acceleration must be positive")
+		os.Exit(1)
+	}
+
+	log.StartProcess()
+
+	var (
+		inputFilePath = args[0]
+		outPath       = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		preStructure  *preprocess.Structure
+	)
+
+	if inkio.IsDefinitionFile(inputFilePath) {
+		var (
+			structure = readStructureFromFile(inputFilePath)
+			options   = &preprocess.PreprocessOptions{DofNumbering: dofNumbering}
+		)
+
+		preStructure = preprocessStructure(structure, options)
+	} else if inkio.IsPreprocessedFile(inputFilePath) {
+		preStructure = readPreprocessedStructureFromFile(inputFilePath)
+	} else {
+		panic(
+			fmt.Sprintf(
+				"Unsupported file type: %s. Expected %s or %s\n",
+				inputFilePath, inkio.DefinitionFileExt, inkio.PreFileExt,
+			),
+		)
+	}
+
+	var (
+		modalOptions = process.ModalOptions{
+			ModesCount: modalModesCount,
+			Lumped:     modalLumped,
+			Gravity:    modalGravity,
+		}
+		solution = process.SolveModal(preStructure, modalOptions)
+		file     = inkio.CreateFile(outPath + inkio.ModalFileExt)
+	)
+	defer file.Close()
+
+	iomodes.WriteModal(solution, file)
+
+	log.Result()
+}
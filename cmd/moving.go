@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	iomoving "github.com/angelsolaorbaiceta/inkfem/io/moving"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/spf13/cobra"
+)
+
+var (
+	movingUseVerbose   bool
+	movingDofNumbering string
+	movingDispMaxError float64
+	movingPath         []string
+	movingAxleLoads    []float64
+	movingSpacings     []float64
+	movingStep         float64
+
+	movingCommand = &cobra.Command{
+		Use:   "moving <inkfem file path>",
+		Short: "Computes the envelopes of the structure under a moving load train",
+		Long: `Moves a load train, like a vehicle or a crane, along a path of bars of the structure given in an .inkfem file, and saves the envelopes of the reactions and the bars' stresses in an .inkfemmove file.
+
+The train is a set of axle loads (--loads), acting downwards, with the spacings between each consecutive pair of axles (--spacings).
+It enters the path (--path), a sequence of bars traversed from their start to their end node, with its first axle at the path's start and advances in steps (--step) until its last axle leaves it.
+
+The structure with the train in each position is solved as its own load case, named train_<n>, reusing the same factorized stiffness matrix.
+For each bar section, the maximum and minimum axial stress, shear force and bending moment are saved together with the position which governs them, in the same format as the envelopes of the .inkfemsol files.
+The same goes for each component of the reactions, which include the axles acting directly over the supports.
+
+The structure's own loads aren't included, and the tension-only and compression-only limits of the bars are ignored.`,
+		Args: cobra.ExactArgs(1),
+		Run:  moveLoadTrain,
+	}
+)
+
+func init() {
+	movingCommand.
+		Flags().
+		BoolVarP(&movingUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	movingCommand.
+		Flags().
+		StringVarP(&movingDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	movingCommand.
+		Flags().
+		Float64VarP(&movingDispMaxError, "error", "e", 1e-5, "maximum allowed displacement error")
+
+	movingCommand.
+		Flags().
+		StringSliceVar(&movingPath, "path", []string{}, "the bars the train moves along, in order, separated by commas")
+	movingCommand.MarkFlagRequired("path")
+
+	movingCommand.
+		Flags().
+		Float64SliceVar(&movingAxleLoads, "loads", []float64{}, "the loads of the train's axles, from the first one, separated by commas")
+	movingCommand.MarkFlagRequired("loads")
+
+	movingCommand.
+		Flags().
+		Float64SliceVar(&movingSpacings, "spacings", []float64{}, "the distances between each pair of consecutive axles, separated by commas")
+
+	movingCommand.
+		Flags().
+		Float64Var(&movingStep, "step", 0, "the distance the train advances from one position to the next")
+	movingCommand.MarkFlagRequired("step")
+
+	rootCmd.AddCommand(movingCommand)
+}
+
+func moveLoadTrain(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(movingUseVerbose)
+
+	dofNumbering, err := preprocess.ParseDofNumbering(movingDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if len(movingSpacings) != len(movingAxleLoads)-1 {
+		fmt.Printf("A train with %d axles needs %d spacings\n", len(movingAxleLoads), len(movingAxleLoads)-1)
+		os.Exit(1)
+	}
+
+	if movingStep <= 0 {
+		fmt.Println("The step must be positive")
+		os.Exit(1)
+	}
+
+	inputFilePath := args[0]
+	if !inkio.IsDefinitionFile(inputFilePath) {
+		panic(
+			fmt.Sprintf("Unsupported file type: %s. Expected %s\n", inputFilePath, inkio.DefinitionFileExt),
+		)
+	}
+
+	log.StartProcess()
+
+	path := make([]contracts.StrID, len(movingPath))
+	for i, id := range movingPath {
+		path[i] = contracts.StrID(id)
+	}
+
+	var (
+		outPath   = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		structure = readStructureFromFile(inputFilePath)
+		train     = load.MakeLoadTrain(movingAxleLoads, movingSpacings)
+		positions = structure.AddMovingLoadCases(path, train, movingStep)
+	)
+
+	var (
+		preStructure = preprocessStructure(structure, &preprocess.PreprocessOptions{
+			DofNumbering: dofNumbering,
+		})
+		solveOptions = process.SolveOptions{
+			OutputPath:            outPath,
+			MaxDisplacementsError: movingDispMaxError,
+			Solver:                process.DirectSolver,
+		}
+		solution = process.SolveMovingLoad(preStructure, path, train, positions, solveOptions)
+		file     = inkio.CreateFile(outPath + inkio.MovingLoadFileExt)
+	)
+	defer file.Close()
+
+	iomoving.Write(solution, file)
+
+	log.Result()
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	ioloadpath "github.com/angelsolaorbaiceta/inkfem/io/loadpath"
+	iosol "github.com/angelsolaorbaiceta/inkfem/io/sol"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/spf13/cobra"
+)
+
+var (
+	nonlinearIncludeOwnWeight bool
+	nonlinearDispMaxError     float64
+	nonlinearUseVerbose       bool
+	nonlinearSolver           string
+	nonlinearDofNumbering     string
+	nonlinearLoadCase         string
+	nonlinearLoadSteps        int
+	nonlinearMaxError         float64
+	nonlinearMaxIter          int
+	nonlinearPathNodes        []string
+
+	nonlinearCommand = &cobra.Command{
+		Use:   "nonlinear <inkfem|inkfempre file path>",
+		Short: "Solves the structure accounting for large displacements",
+		Long: `Solves the structure given in an .inkfem or preprocessed .inkfempre file accounting for large displacements and rotations, and saves the final deformed state solution in an .inkfemsol file.
+
+Each sliced finite element is modeled with the corotational beam formulation.
+The loads of the chosen load case are applied in equal increments, and the equilibrium in each of them is found by Newton–Raphson iterations, until the residual forces are below the maximum error, relative to the applied loads.
+
+The displacements of the chosen nodes at the end of each increment, the load–displacement path, are saved in an .inkfempath file.`,
+		Args: cobra.ExactArgs(1),
+		Run:  solveNonlinearStructure,
+	}
+)
+
+func init() {
+	nonlinearCommand.
+		Flags().
+		BoolVarP(&nonlinearIncludeOwnWeight, "weight", "w", false, "include the weight of each bar as a distributed load")
+
+	nonlinearCommand.
+		Flags().
+		Float64VarP(&nonlinearDispMaxError, "error", "e", 1e-5, "maximum allowed displacement error")
+
+	nonlinearCommand.
+		Flags().
+		BoolVarP(&nonlinearUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	nonlinearCommand.
+		Flags().
+		StringVar(&nonlinearSolver, "solver", string(process.DirectSolver), "the system of equations solver. Use one of: direct, pcg")
+
+	nonlinearCommand.
+		Flags().
+		StringVarP(&nonlinearDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	nonlinearCommand.
+		Flags().
+		StringVarP(&nonlinearLoadCase, "case", "c", load.DefaultCase, "the load case whose loads are applied")
+
+	nonlinearCommand.
+		Flags().
+		IntVar(&nonlinearLoadSteps, "steps", process.DefaultNonlinearLoadSteps, "the number of load increments")
+
+	nonlinearCommand.
+		Flags().
+		Float64Var(&nonlinearMaxError, "residual", process.DefaultNonlinearMaxError, "maximum residual force, relative to the applied loads")
+
+	nonlinearCommand.
+		Flags().
+		IntVar(&nonlinearMaxIter, "max-iter", process.DefaultNonlinearMaxIter, "maximum number of Newton–Raphson iterations in each increment")
+
+	nonlinearCommand.
+		Flags().
+		StringSliceVar(&nonlinearPathNodes, "nodes", []string{}, "the nodes whose load–displacement path is saved, separated by commas")
+
+	rootCmd.AddCommand(nonlinearCommand)
+}
+
+func solveNonlinearStructure(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(nonlinearUseVerbose)
+
+	solver, err := process.ParseSolverType(nonlinearSolver)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	dofNumbering, err := preprocess.ParseDofNumbering(nonlinearDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	log.StartProcess()
+
+	var (
+		inputFilePath = args[0]
+		outPath       = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		preStructure  *preprocess.Structure
+	)
+
+	if inkio.IsDefinitionFile(inputFilePath) {
+		var (
+			structure = readStructureFromFile(inputFilePath)
+			options   = &preprocess.PreprocessOptions{
+				IncludeOwnWeight: nonlinearIncludeOwnWeight,
+				DofNumbering:     dofNumbering,
+			}
+		)
+
+		preStructure = preprocessStructure(structure, options)
+	} else if inkio.IsPreprocessedFile(inputFilePath) {
+		preStructure = readPreprocessedStructureFromFile(inputFilePath)
+	} else {
+		panic(
+			fmt.Sprintf(
+				"Unsupported file type: %s. Expected %s or %s\n",
+				inputFilePath, inkio.DefinitionFileExt, inkio.PreFileExt,
+			),
+		)
+	}
+
+	pathNodes := make([]contracts.StrID, len(nonlinearPathNodes))
+	for i, id := range nonlinearPathNodes {
+		pathNodes[i] = contracts.StrID(id)
+	}
+
+	var (
+		nonlinearOptions = process.NonlinearOptions{
+			SolveOptions: process.SolveOptions{
+				OutputPath:            outPath,
+				MaxDisplacementsError: nonlinearDispMaxError,
+				Solver:                solver,
+			},
+			LoadCase:  nonlinearLoadCase,
+			LoadSteps: nonlinearLoadSteps,
+			MaxError:  nonlinearMaxError,
+			MaxIter:   nonlinearMaxIter,
+			PathNodes: pathNodes,
+		}
+		solution = process.SolveNonlinear(preStructure, nonlinearOptions)
+		solFile  = inkio.CreateFile(outPath + inkio.SolFileExt)
+		pathFile = inkio.CreateFile(outPath + inkio.LoadPathFileExt)
+	)
+	defer solFile.Close()
+	defer pathFile.Close()
+
+	iosol.Write([]*process.Solution{solution.Solution}, nil, solFile)
+	ioloadpath.Write(solution, pathFile)
+
+	log.Result()
+}
@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	iomodes "github.com/angelsolaorbaiceta/inkfem/io/modes"
 	"github.com/angelsolaorbaiceta/inkfem/plot"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +18,9 @@ var (
 	plotPreprocessedFile bool
 	plotUseDarkTheme     bool
 	plotDistLoadScale    float64
+	plotBucklingModes    bool
+	plotVibrationModes   bool
+	plotModeShapeScale   float64
 
 	plotCommand = &cobra.Command{
 		Use:   "plot <inkfem file path>",
@@ -20,6 +29,9 @@ var (
 	
 The original structure definition (.inkfem file) is plotted to an SVG file with the same name, but with the .svg extension.
 This plot includes the bars, node supports, and loads.
+
+When the -b flag is used, the buckling modes in the .inkfembuck file (see the buckle command) are plotted, each to its own SVG file.
+Similarly, the --modal flag plots the vibration modes in the .inkfemmodal file (see the modal command).
 		`,
 		Args: cobra.ExactArgs(1),
 		Run:  plotStructure,
@@ -42,6 +54,18 @@ func init() {
 		Flags().
 		BoolVarP(&plotUseDarkTheme, "dark", "d", false, "use a dark theme for the plot")
 
+	plotCommand.
+		Flags().
+		BoolVarP(&plotBucklingModes, "buckling", "b", false, "plot the buckling modes (if the .inkfembuck file can be found)")
+
+	plotCommand.
+		Flags().
+		BoolVar(&plotVibrationModes, "modal", false, "plot the vibration modes (if the .inkfemmodal file can be found)")
+
+	plotCommand.
+		Flags().
+		Float64Var(&plotModeShapeScale, "mode-scale", 0.2, "Size of the largest mode shape displacement, relative to the longest bar")
+
 	rootCmd.AddCommand(plotCommand)
 }
 
@@ -53,8 +77,9 @@ func plotStructure(cmd *cobra.Command, args []string) {
 		strPlotOptions        = &plot.StructurePlotOps{
 			Scale: plotScale,
 			// TODO: read these two values from the command line
-			DistLoadScale: plotDistLoadScale,
-			MinMargin:     150,
+			DistLoadScale:  plotDistLoadScale,
+			MinMargin:      150,
+			ModeShapeScale: plotModeShapeScale,
 		}
 		plotConfig *plot.PlotConfig
 	)
@@ -72,4 +97,63 @@ func plotStructure(cmd *cobra.Command, args []string) {
 	defer strPlotFile.Close()
 
 	plot.StructureToSVG(structure, strPlotOptions, plotConfig, strPlotFile)
+
+	if plotBucklingModes {
+		plotModeShapes(inputFilePath, inkio.BucklingFileExt, structure, strPlotOptions, plotConfig)
+	}
+
+	if plotVibrationModes {
+		plotModeShapes(inputFilePath, inkio.ModalFileExt, structure, strPlotOptions, plotConfig)
+	}
+}
+
+// plotModeShapes plots each of the modes of the structure, read from the modes file with the
+// given extension next to the definition file, to an SVG file.
+func plotModeShapes(
+	inputFilePath, modesFileExt string,
+	st *structure.Structure,
+	options *plot.StructurePlotOps,
+	config *plot.PlotConfig,
+) {
+	var (
+		modesFilePath = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt) + modesFileExt
+		modesFile     = inkio.OpenFile(modesFilePath)
+		modes         = iomodes.Read(modesFile)
+	)
+	modesFile.Close()
+
+	for _, mode := range modes.Modes {
+		shape := &plot.ModeShape{
+			Title:         modeShapeTitle(modes, mode),
+			Displacements: make(map[contracts.StrID][]plot.ModeShapePoint, len(mode.Shape)),
+		}
+
+		for elementID, displacements := range mode.Shape {
+			points := make([]plot.ModeShapePoint, len(displacements))
+			for i, displ := range displacements {
+				points[i] = plot.ModeShapePoint{T: displ.T, Dx: displ.Dx, Dy: displ.Dy}
+			}
+
+			shape.Displacements[elementID] = points
+		}
+
+		modePlotFile := inkio.CreateFile(
+			fmt.Sprintf("%s.%s_%d.svg", inputFilePath, modes.Analysis, mode.Number),
+		)
+		plot.ModeShapeToSVG(st, shape, options, config, modePlotFile)
+		modePlotFile.Close()
+	}
+}
+
+func modeShapeTitle(modes *iomodes.ModesFile, mode *iomodes.Mode) string {
+	if modes.Analysis == iomodes.ModalAnalysis {
+		return fmt.Sprintf(
+			"Vibration mode %d: f = %g Hz, T = %g s",
+			mode.Number, mode.Value, mode.Properties[iomodes.PeriodProp],
+		)
+	}
+
+	return fmt.Sprintf(
+		"Buckling mode %d (%s): critical load factor = %g", mode.Number, modes.LoadCase, mode.Value,
+	)
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
 	"strings"
 
 	inkio "github.com/angelsolaorbaiceta/inkfem/io"
@@ -13,9 +15,15 @@ import (
 var (
 	preIncludeOwnWeight bool
 	preUseVerbose       bool
+	preDofNumbering     string
+	preLoadedSlices     int
+	preUnloadedSlices   int
+	preMaxSliceLength   float64
+	preAdaptive         bool
+	preAdaptiveTol      float64
 
 	preCommand = &cobra.Command{
-		Use:   "pre [-w] [-v] <.inkfem file path>",
+		Use:   "pre [-w] [-v] [-n numbering] [--adaptive] <.inkfem file path>",
 		Short: "Preprocess structure",
 		Long: `Preprocess the structure definition (.inkfem file), slicing it and distributing the loads into the nodes, and saves it as a .inkfempre file.
 
@@ -24,12 +32,21 @@ How many elements each bar is sliced into depends on its end supports and applie
 There are three different cases:
 
 1. Axial bars: These are not sliced at all.
-2. Bars without loads: These are sliced into a maximum of 6 elements.
-3. Bars with loads: These are sliced into a maximum of 10 elements.
+2. Bars without loads: These are sliced into 6 elements, or the number given with --unloaded-slices.
+3. Bars with loads: These are sliced into 10 elements, or the number given with --loaded-slices.
 
 Intermediate points where a concentrated load is applied also generate intermediate nodes for the load to be included.
+When --max-slice-length is given, bars are sliced into as many elements as needed for none to be longer.
+
+When the --adaptive flag is used, the sliced structure is solved and the slices where the bending moment
+changes its gradient the most are split in two, until the largest error of the bending moment's linear
+interpolation in every slice is below the --adaptive-tolerance, relative to the largest bending moment.
 
 When the -w flag is used, the weight of each bar is included as a distributed load.
+
+The degrees of freedom are numbered following the bars sorted by their position. Using the "rcm" numbering,
+they are renumbered with the Reverse Cuthill-McKee algorithm, which reduces the bandwidth and profile of the
+system of equations matrix and speeds up its resolution in large structures.
 		`,
 		Args: cobra.ExactArgs(1),
 		Run:  preStructure,
@@ -45,11 +62,47 @@ func init() {
 		Flags().
 		BoolVarP(&preUseVerbose, "verbose", "v", false, "use verbose output")
 
+	preCommand.
+		Flags().
+		StringVarP(&preDofNumbering, "numbering", "n", string(preprocess.GeometricNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	preCommand.
+		Flags().
+		IntVar(&preLoadedSlices, "loaded-slices", 10, "the number of slices of the bars with loads")
+
+	preCommand.
+		Flags().
+		IntVar(&preUnloadedSlices, "unloaded-slices", 6, "the number of slices of the bars without loads")
+
+	preCommand.
+		Flags().
+		Float64Var(&preMaxSliceLength, "max-slice-length", 0.0, "the maximum length of the slices; zero means no limit")
+
+	preCommand.
+		Flags().
+		BoolVar(&preAdaptive, "adaptive", false, "refine the slices where the bending moment gradient is high")
+
+	preCommand.
+		Flags().
+		Float64Var(&preAdaptiveTol, "adaptive-tolerance", preprocess.DefaultAdaptiveTolerance, "the bending moment error allowed in the adaptive slicing, relative to the largest one")
+
 	rootCmd.AddCommand(preCommand)
 }
 
 func preStructure(cmd *cobra.Command, args []string) {
 	log.SetVerbosity(preUseVerbose)
+
+	dofNumbering, err := preprocess.ParseDofNumbering(preDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := validateSlicingFlags(preLoadedSlices, preUnloadedSlices, preMaxSliceLength, preAdaptiveTol); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	log.StartProcess()
 
 	var (
@@ -57,7 +110,13 @@ func preStructure(cmd *cobra.Command, args []string) {
 		outPath       = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
 		structure     = readStructureFromFile(inputFilePath)
 		options       = &preprocess.PreprocessOptions{
-			IncludeOwnWeight: preIncludeOwnWeight,
+			IncludeOwnWeight:  preIncludeOwnWeight,
+			DofNumbering:      dofNumbering,
+			LoadedSlices:      preLoadedSlices,
+			UnloadedSlices:    preUnloadedSlices,
+			MaxSliceLength:    preMaxSliceLength,
+			Adaptive:          preAdaptive,
+			AdaptiveTolerance: preAdaptiveTol,
 		}
 		preStructure = preprocessStructure(structure, options)
 	)
@@ -68,3 +127,21 @@ func preStructure(cmd *cobra.Command, args []string) {
 
 	log.Result()
 }
+
+// validateSlicingFlags checks that the slicing options given as flags are valid: the number of
+// slices must be positive, and the maximum slice length and adaptive tolerance non-negative.
+func validateSlicingFlags(loadedSlices, unloadedSlices int, maxSliceLength, adaptiveTolerance float64) error {
+	if loadedSlices < 1 || unloadedSlices < 1 {
+		return fmt.Errorf("the number of slices must be positive, got %d and %d", loadedSlices, unloadedSlices)
+	}
+
+	if maxSliceLength < 0 {
+		return fmt.Errorf("the maximum slice length can't be negative, got %f", maxSliceLength)
+	}
+
+	if adaptiveTolerance <= 0 {
+		return fmt.Errorf("the adaptive tolerance must be positive, got %f", adaptiveTolerance)
+	}
+
+	return nil
+}
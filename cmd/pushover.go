@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	iopushover "github.com/angelsolaorbaiceta/inkfem/io/pushover"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pushoverIncludeOwnWeight bool
+	pushoverUseVerbose       bool
+	pushoverDofNumbering     string
+	pushoverLoadCase         string
+	pushoverShapeFactor      float64
+	pushoverPathNodes        []string
+
+	pushoverCommand = &cobra.Command{
+		Use:   "pushover <inkfem|inkfempre file path>",
+		Short: "Computes the plastic collapse load of the structure",
+		Long: `Computes the load factor which turns the structure given in an .inkfem or preprocessed .inkfempre file into a mechanism by the formation of plastic hinges, and saves the hinges sequence in an .inkfempush file.
+
+The loads of the chosen load case are increased proportionally, from one event, the formation of a plastic hinge, to the next one.
+A hinge forms at the end of a slice when its bending moment reaches the plastic moment: the shape factor (--shape) times the yield moment, the product of the material's yield strength and the section's strong axis modulus.
+Each hinge releases the rotation of the slice end, as a pinned link does, until the structure becomes a mechanism.
+
+For each hinge, the bar and position where it forms, the load factor and bending moment are saved, followed by the displacements of the chosen nodes at that moment.
+The hinges are assumed not to unload, and the interaction between the bending moment and the axial force isn't considered.`,
+		Args: cobra.ExactArgs(1),
+		Run:  pushoverStructure,
+	}
+)
+
+func init() {
+	pushoverCommand.
+		Flags().
+		BoolVarP(&pushoverIncludeOwnWeight, "weight", "w", false, "include the weight of each bar as a distributed load")
+
+	pushoverCommand.
+		Flags().
+		BoolVarP(&pushoverUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	pushoverCommand.
+		Flags().
+		StringVarP(&pushoverDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	pushoverCommand.
+		Flags().
+		StringVarP(&pushoverLoadCase, "case", "c", load.DefaultCase, "the load case whose loads are increased")
+
+	pushoverCommand.
+		Flags().
+		Float64Var(&pushoverShapeFactor, "shape", process.DefaultPushoverShapeFactor, "the ratio between the plastic and elastic section moduli")
+
+	pushoverCommand.
+		Flags().
+		StringSliceVar(&pushoverPathNodes, "nodes", []string{}, "the nodes whose displacements are saved as each hinge forms, separated by commas")
+
+	rootCmd.AddCommand(pushoverCommand)
+}
+
+func pushoverStructure(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(pushoverUseVerbose)
+
+	dofNumbering, err := preprocess.ParseDofNumbering(pushoverDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if pushoverShapeFactor < 1 {
+		fmt.Println("The shape factor can't be smaller than one")
+		os.Exit(1)
+	}
+
+	log.StartProcess()
+
+	var (
+		inputFilePath = args[0]
+		outPath       = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		preStructure  *preprocess.Structure
+	)
+
+	if inkio.IsDefinitionFile(inputFilePath) {
+		var (
+			structure = readStructureFromFile(inputFilePath)
+			options   = &preprocess.PreprocessOptions{
+				IncludeOwnWeight: pushoverIncludeOwnWeight,
+				DofNumbering:     dofNumbering,
+			}
+		)
+
+		preStructure = preprocessStructure(structure, options)
+	} else if inkio.IsPreprocessedFile(inputFilePath) {
+		preStructure = readPreprocessedStructureFromFile(inputFilePath)
+	} else {
+		panic(
+			fmt.Sprintf(
+				"Unsupported file type: %s. Expected %s or %s\n",
+				inputFilePath, inkio.DefinitionFileExt, inkio.PreFileExt,
+			),
+		)
+	}
+
+	pathNodes := make([]contracts.StrID, len(pushoverPathNodes))
+	for i, id := range pushoverPathNodes {
+		pathNodes[i] = contracts.StrID(id)
+	}
+
+	var (
+		pushoverOptions = process.PushoverOptions{
+			LoadCase:    pushoverLoadCase,
+			ShapeFactor: pushoverShapeFactor,
+			PathNodes:   pathNodes,
+		}
+		solution = process.SolvePushover(preStructure, pushoverOptions)
+		file     = inkio.CreateFile(outPath + inkio.PushoverFileExt)
+	)
+	defer file.Close()
+
+	iopushover.Write(solution, file)
+
+	log.Result()
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	inkio "github.com/angelsolaorbaiceta/inkfem/io"
@@ -19,13 +20,31 @@ var (
 	solveUseVerbose       bool
 	solvePreprocessToFile bool
 	solveSafeChecks       bool
+	solveSolver           string
+	solvePreconditioner   string
+	solveICFillIn         float64
+	solveSSOROmega        float64
+	solveDofNumbering     string
+	solveSecondOrder      bool
+	solveSecondOrderError float64
+	solveSecondOrderIter  int
+	solveLoadedSlices     int
+	solveUnloadedSlices   int
+	solveMaxSliceLength   float64
+	solveAdaptive         bool
+	solveAdaptiveTol      float64
+	solveEquilibriumTol   float64
 
 	solveCommand = &cobra.Command{
 		Use:   "solve <inkfem|inkfempre file path>",
 		Short: "Solves the structure",
-		Long:  "Solves the structure given in an .inkfem or preprocessed .inkfempre file and saves the result in an .inkfemsol file.",
-		Args:  cobra.ExactArgs(1),
-		Run:   solveStructure,
+		Long: `Solves the structure given in an .inkfem or preprocessed .inkfempre file and saves the result in an .inkfemsol file.
+
+The equilibrium of the solution of each load case is verified: the sum of the reactions against the sum of the applied loads,
+the residual of the system of equations and the equilibrium of each node. If any relative imbalance is larger than the
+--equilibrium-tolerance, the solution file is still written, but the command exits with a non-zero status.`,
+		Args: cobra.ExactArgs(1),
+		Run:  solveStructure,
 	}
 )
 
@@ -50,11 +69,96 @@ func init() {
 		Flags().
 		BoolVarP(&solveSafeChecks, "safe", "s", false, "perform safety checks")
 
+	solveCommand.
+		Flags().
+		StringVar(&solveSolver, "solver", string(process.DirectSolver), "the system of equations solver. Use one of: direct, pcg")
+
+	solveCommand.
+		Flags().
+		StringVar(&solvePreconditioner, "precond", string(process.JacobiPreconditioner), "the PCG solver preconditioner. Use one of: jacobi, ic, ssor")
+
+	solveCommand.
+		Flags().
+		Float64Var(&solveICFillIn, "ic-fill", 0.0, "the incomplete Cholesky fill-in threshold; zero means IC(0)")
+
+	solveCommand.
+		Flags().
+		Float64Var(&solveSSOROmega, "ssor-omega", process.DefaultSSORRelaxation, "the SSOR preconditioner relaxation factor, in the (0, 2) range")
+
+	solveCommand.
+		Flags().
+		StringVarP(&solveDofNumbering, "numbering", "n", string(preprocess.GeometricNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	solveCommand.
+		Flags().
+		BoolVar(&solveSecondOrder, "second-order", false, "include the P-Delta (second-order) effects")
+
+	solveCommand.
+		Flags().
+		Float64Var(&solveSecondOrderError, "second-order-error", process.DefaultSecondOrderMaxError, "maximum relative change of the displacements between second-order iterations")
+
+	solveCommand.
+		Flags().
+		IntVar(&solveSecondOrderIter, "second-order-iter", process.DefaultSecondOrderMaxIter, "maximum number of second-order iterations")
+
+	solveCommand.
+		Flags().
+		IntVar(&solveLoadedSlices, "loaded-slices", 10, "the number of slices of the bars with loads")
+
+	solveCommand.
+		Flags().
+		IntVar(&solveUnloadedSlices, "unloaded-slices", 6, "the number of slices of the bars without loads")
+
+	solveCommand.
+		Flags().
+		Float64Var(&solveMaxSliceLength, "max-slice-length", 0.0, "the maximum length of the slices; zero means no limit")
+
+	solveCommand.
+		Flags().
+		BoolVar(&solveAdaptive, "adaptive", false, "refine the slices where the bending moment gradient is high")
+
+	solveCommand.
+		Flags().
+		Float64Var(&solveAdaptiveTol, "adaptive-tolerance", preprocess.DefaultAdaptiveTolerance, "the bending moment error allowed in the adaptive slicing, relative to the largest one")
+
+	solveCommand.
+		Flags().
+		Float64Var(&solveEquilibriumTol, "equilibrium-tolerance", process.DefaultEquilibriumTolerance, "maximum relative imbalance of the solutions and residual of the system of equations")
+
 	rootCmd.AddCommand(solveCommand)
 }
 
 func solveStructure(cmd *cobra.Command, args []string) {
 	log.SetVerbosity(solveUseVerbose)
+
+	solver, err := process.ParseSolverType(solveSolver)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	preconditioner, err := process.ParsePreconditionerType(solvePreconditioner)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	dofNumbering, err := preprocess.ParseDofNumbering(solveDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if solveEquilibriumTol <= 0 {
+		fmt.Printf("the equilibrium tolerance must be positive, got %f\n", solveEquilibriumTol)
+		os.Exit(1)
+	}
+
+	if err := validateSlicingFlags(solveLoadedSlices, solveUnloadedSlices, solveMaxSliceLength, solveAdaptiveTol); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	log.StartProcess()
 
 	var (
@@ -67,7 +171,13 @@ func solveStructure(cmd *cobra.Command, args []string) {
 		var (
 			structure = readStructureFromFile(inputFilePath)
 			options   = &preprocess.PreprocessOptions{
-				IncludeOwnWeight: solveIncludeOwnWeight,
+				IncludeOwnWeight:  solveIncludeOwnWeight,
+				DofNumbering:      dofNumbering,
+				LoadedSlices:      solveLoadedSlices,
+				UnloadedSlices:    solveUnloadedSlices,
+				MaxSliceLength:    solveMaxSliceLength,
+				Adaptive:          solveAdaptive,
+				AdaptiveTolerance: solveAdaptiveTol,
 			}
 		)
 
@@ -95,15 +205,46 @@ func solveStructure(cmd *cobra.Command, args []string) {
 		OutputPath:            outPath,
 		SafeChecks:            solveSafeChecks,
 		MaxDisplacementsError: solveDispMaxError,
+		Solver:                solver,
+		Preconditioner:        preconditioner,
+		ICFillInThreshold:     solveICFillIn,
+		SSORRelaxation:        solveSSOROmega,
+		SecondOrder:           solveSecondOrder,
+		SecondOrderMaxError:   solveSecondOrderError,
+		SecondOrderMaxIter:    solveSecondOrderIter,
+		EquilibriumTolerance:  solveEquilibriumTol,
 	}
 
 	var (
-		solution = process.Solve(preStructure, solveOptions)
-		solFile  = inkio.CreateFile(outPath + inkio.SolFileExt)
+		solutions    = process.Solve(preStructure, solveOptions)
+		combinations = process.CombineSolutions(solutions, preStructure.LoadCombinations())
+		envelopes    = process.MakeEnvelopes(combinations)
+		solFile      = inkio.CreateFile(outPath + inkio.SolFileExt)
 	)
-	defer solFile.Close()
 
-	iosol.Write(solution, solFile)
+	iosol.Write(append(solutions, combinations...), envelopes, solFile)
+	solFile.Close()
 
 	log.Result()
+
+	if unbalanced := unbalancedLoadCases(solutions); len(unbalanced) > 0 {
+		fmt.Printf(
+			"The equilibrium tolerance is exceeded in the load cases: %s\n",
+			strings.Join(unbalanced, ", "),
+		)
+		os.Exit(1)
+	}
+}
+
+// unbalancedLoadCases returns the load cases whose solutions exceed the equilibrium tolerance.
+func unbalancedLoadCases(solutions []*process.Solution) []string {
+	var loadCases []string
+
+	for _, solution := range solutions {
+		if solution.Equilibrium != nil && !solution.Equilibrium.IsWithinTolerance() {
+			loadCases = append(loadCases, solution.LoadCase)
+		}
+	}
+
+	return loadCases
 }
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	iospectrum "github.com/angelsolaorbaiceta/inkfem/io/spectrum"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/spf13/cobra"
+)
+
+var (
+	spectrumUseVerbose   bool
+	spectrumDofNumbering string
+	spectrumFile         string
+	spectrumDirection    string
+	spectrumModesCount   int
+	spectrumLumped       bool
+	spectrumGravity      float64
+	spectrumScale        float64
+	spectrumDampingRatio float64
+
+	spectrumCommand = &cobra.Command{
+		Use:   "spectrum <inkfem|inkfempre file path>",
+		Short: "Computes the seismic response of the structure to a design response spectrum",
+		Long: `Computes the peak seismic response of the structure given in an .inkfem or preprocessed .inkfempre file to the design response spectrum, and saves it in an .inkfemspec file.
+
+The spectrum is read from a plain text file (--spectrum) with a "<period> <spectral acceleration>" pair in each line, and is linearly interpolated between the points.
+The spectral accelerations are multiplied by the --scale factor, which converts them into the units of the structure; for example, the gravity acceleration if they're given as fractions of it.
+
+The vibration modes are computed as in the modal command.
+The peak response of each mode is combined using both the SRSS and CQC rules, the latter with the given damping ratio.
+For each rule, the envelopes of the reactions and the bars' axial stress, shear force and bending moment are saved.
+
+Check that the cumulative mass ratio of the modes, written in the results file, is close to one; otherwise, compute more modes.`,
+		Args: cobra.ExactArgs(1),
+		Run:  spectrumStructure,
+	}
+)
+
+func init() {
+	spectrumCommand.
+		Flags().
+		BoolVarP(&spectrumUseVerbose, "verbose", "v", false, "use verbose output; logs the progress of the resolution")
+
+	spectrumCommand.
+		Flags().
+		StringVarP(&spectrumDofNumbering, "numbering", "n", string(preprocess.RCMNumbering), "the degrees of freedom numbering. Use one of: geometric, rcm")
+
+	spectrumCommand.
+		Flags().
+		StringVar(&spectrumFile, "spectrum", "", "the file with the design spectrum, the spectral acceleration for each period")
+
+	spectrumCommand.
+		Flags().
+		StringVarP(&spectrumDirection, "direction", "d", string(process.SpectrumX), "the direction of the ground motion. Use one of: x, y")
+
+	spectrumCommand.
+		Flags().
+		IntVarP(&spectrumModesCount, "modes", "m", 10, "the number of vibration modes to combine")
+
+	spectrumCommand.
+		Flags().
+		BoolVar(&spectrumLumped, "lumped", false, "use lumped mass matrices instead of consistent ones")
+
+	spectrumCommand.
+		Flags().
+		Float64Var(&spectrumGravity, "gravity", 1.0, "the gravity acceleration dividing the density to obtain the mass")
+
+	spectrumCommand.
+		Flags().
+		Float64Var(&spectrumScale, "scale", 1.0, "the factor multiplying the spectral accelerations")
+
+	spectrumCommand.
+		Flags().
+		Float64Var(&spectrumDampingRatio, "damping", process.DefaultSpectrumDampingRatio, "the damping ratio of the modes used by the CQC rule")
+
+	spectrumCommand.MarkFlagRequired("spectrum")
+
+	rootCmd.AddCommand(spectrumCommand)
+}
+
+func spectrumStructure(cmd *cobra.Command, args []string) {
+	log.SetVerbosity(spectrumUseVerbose)
+
+	dofNumbering, err := preprocess.ParseDofNumbering(spectrumDofNumbering)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	direction, err := process.ParseSpectrumDirection(spectrumDirection)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if spectrumGravity <= 0 {
+		fmt.Println("The gravity acceleration must be positive")
+		os.Exit(1)
+	}
+
+	log.StartProcess()
+
+	var (
+		inputFilePath = args[0]
+		outPath       = strings.TrimSuffix(inputFilePath, inkio.DefinitionFileExt)
+		preStructure  *preprocess.Structure
+	)
+
+	if inkio.IsDefinitionFile(inputFilePath) {
+		var (
+			structure = readStructureFromFile(inputFilePath)
+			options   = &preprocess.PreprocessOptions{DofNumbering: dofNumbering}
+		)
+
+		preStructure = preprocessStructure(structure, options)
+	} else if inkio.IsPreprocessedFile(inputFilePath) {
+		preStructure = readPreprocessedStructureFromFile(inputFilePath)
+	} else {
+		panic(
+			fmt.Sprintf(
+				"Unsupported file type: %s. Expected %s or %s\n",
+				inputFilePath, inkio.DefinitionFileExt, inkio.PreFileExt,
+			),
+		)
+	}
+
+	var (
+		spectrumOptions = process.SpectrumOptions{
+			ModalOptions: process.ModalOptions{
+				ModesCount: spectrumModesCount,
+				Lumped:     spectrumLumped,
+				Gravity:    spectrumGravity,
+			},
+			Spectrum:          readResponseSpectrumFromFile(spectrumFile),
+			Direction:         direction,
+			AccelerationScale: spectrumScale,
+			DampingRatio:      spectrumDampingRatio,
+		}
+		solution = process.SolveSpectrum(preStructure, spectrumOptions)
+		file     = inkio.CreateFile(outPath + inkio.SpectrumFileExt)
+	)
+	defer file.Close()
+
+	iospectrum.Write(solution, file)
+
+	log.Result()
+}
@@ -15,10 +15,11 @@ const (
 	endLinkGroupName   = "end_link"
 	materialGroupName  = "material"
 	sectionGroupName   = "section"
+	behaviorGroupName  = "behavior"
 	numNodesGroupName  = "n_nodes"
 )
 
-// <id> -> <s_node> {[dx dy rz]} <e_node> {[dx dy rz]} <material> <section> [>> <n_pre_nodes>]
+// <id> -> <s_node> {[dx dy rz]} <e_node> {[dx dy rz]} <material> <section> [tension|compression] [>> <n_pre_nodes>]
 var elementDefinitionRegex = regexp.MustCompile(
 	"^" + inkio.IdGrpExpr + inkio.ArrowExpr +
 		inkio.IdGroupExpr(startNodeGroupName) + inkio.OptionalSpaceExpr +
@@ -26,7 +27,9 @@ var elementDefinitionRegex = regexp.MustCompile(
 		inkio.IdGroupExpr(endNodeGroupName) + inkio.OptionalSpaceExpr +
 		inkio.ConstraintGroupExpr(endLinkGroupName) + inkio.SpaceExpr +
 		inkio.NameGroupExpr(materialGroupName) + inkio.SpaceExpr +
-		inkio.NameGroupExpr(sectionGroupName) + inkio.OptionalSpaceExpr +
+		inkio.NameGroupExpr(sectionGroupName) +
+		`(?:` + inkio.SpaceExpr + `(?P<` + behaviorGroupName + `>tension|compression))?` +
+		inkio.OptionalSpaceExpr +
 		`(?:>>` + inkio.OptionalSpaceExpr +
 		`(?P<` + numNodesGroupName + `>\d+))?` +
 		"$",
@@ -48,6 +51,7 @@ type DeserializedBarDTO struct {
 	EndLink      *structure.Constraint
 	MaterialName string
 	SectionName  string
+	Behavior     structure.AxialBehavior
 }
 
 func (bar *DeserializedBarDTO) Equals(other *DeserializedBarDTO) bool {
@@ -56,6 +60,7 @@ func (bar *DeserializedBarDTO) Equals(other *DeserializedBarDTO) bool {
 		bar.EndNodeId == other.EndNodeId &&
 		bar.MaterialName == other.MaterialName &&
 		bar.SectionName == other.SectionName &&
+		bar.Behavior == other.Behavior &&
 		bar.StartLink.Equals(other.StartLink) &&
 		bar.EndLink.Equals(other.EndLink)
 }
@@ -71,11 +76,12 @@ func DeserializeBar(line string) (*DeserializedBarDTO, int) {
 		groups        = inkio.ExtractNamedGroups(elementDefinitionRegex, line)
 		id            = groups[inkio.IdGrpName]
 		startNodeId   = groups[startNodeGroupName]
-		startLink     = constraintFromString(groups[startLinkGroupName])
+		startLink     = linkFromString(groups[startLinkGroupName])
 		endNodeId     = groups[endNodeGroupName]
-		endLink       = constraintFromString(groups[endLinkGroupName])
+		endLink       = linkFromString(groups[endLinkGroupName])
 		materialName  = groups[materialGroupName]
 		sectionName   = groups[sectionGroupName]
+		behavior      = structure.AxialBehavior(groups[behaviorGroupName])
 		numberOfNodes = 2
 	)
 
@@ -87,6 +93,7 @@ func DeserializeBar(line string) (*DeserializedBarDTO, int) {
 		EndLink:      endLink,
 		MaterialName: materialName,
 		SectionName:  sectionName,
+		Behavior:     behavior,
 	}
 
 	if nNodesString, isPreprocessed := groups[numNodesGroupName]; isPreprocessed {
@@ -129,8 +136,10 @@ func BarFromDeserialization(
 		WithEndNode(endNode, bar.EndLink).
 		WithMaterial(material).
 		WithSection(section).
+		WithAxialBehavior(bar.Behavior).
 		AddConcentratedLoads(data.ConcentratedLoads[bar.Id]).
-		AddDistributedLoads(data.DistributedLoads[bar.Id])
+		AddDistributedLoads(data.DistributedLoads[bar.Id]).
+		AddThermalLoads(data.ThermalLoads[bar.Id])
 
 	return builder.Build()
 }
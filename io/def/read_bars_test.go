@@ -113,3 +113,40 @@ func TestDeserializeBars(t *testing.T) {
 		assert.Equal(t, wantBarTwo.ConcentratedLoads, barTwo.ConcentratedLoads)
 	})
 }
+
+func TestDeserializeBarWithSemiRigidLinks(t *testing.T) {
+	var (
+		barDTO, _ = DeserializeBar("1 -> 1{ dx dy krz=5e6 } 2{ dx kdy=1000 rz } 'mat' 'sec'")
+		wantStart = structure.MakeConstraint(true, true, false).WithSprings(0, 0, 5e6)
+		wantEnd   = structure.MakeConstraint(true, false, true).WithSprings(0, 1000, 0)
+	)
+
+	assert.True(t, barDTO.StartLink.Equals(wantStart))
+	assert.True(t, barDTO.EndLink.Equals(wantEnd))
+	assert.Equal(t, "{ dx dy krz=5e+06 }", barDTO.StartLink.String())
+}
+
+func TestDeserializeBarWithAxialBehavior(t *testing.T) {
+	t.Run("tension-only bar", func(t *testing.T) {
+		barDTO, _ := DeserializeBar("1 -> 1{ dx dy } 2{ dx dy } 'mat' 'sec' tension")
+		assert.Equal(t, structure.TensionOnly, barDTO.Behavior)
+		assert.Equal(t, "sec", barDTO.SectionName)
+	})
+
+	t.Run("compression-only preprocessed bar", func(t *testing.T) {
+		barDTO, nNodes := DeserializeBar("1 -> 1{ dx dy } 2{ dx dy } 'mat' 'sec' compression >> 2")
+		assert.Equal(t, structure.CompressionOnly, barDTO.Behavior)
+		assert.Equal(t, 2, nNodes)
+	})
+
+	t.Run("bars take tension and compression by default", func(t *testing.T) {
+		barDTO, _ := DeserializeBar("1 -> 1{ dx dy } 2{ dx dy } 'mat' 'sec' >> 2")
+		assert.Equal(t, structure.TensionAndCompression, barDTO.Behavior)
+	})
+
+	t.Run("unknown behavior", func(t *testing.T) {
+		assert.Panics(t, func() {
+			DeserializeBar("1 -> 1{ dx dy } 2{ dx dy } 'mat' 'sec' cable")
+		})
+	})
+}
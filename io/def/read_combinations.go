@@ -0,0 +1,54 @@
+package def
+
+import (
+	"fmt"
+	"regexp"
+
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+var (
+	// <factor> <loadCase>
+	combinationTermExpr = inkio.FloatGroupExpr("factor") + inkio.SpaceExpr +
+		inkio.IdGroupExpr("case")
+
+	// <name> -> <factor> <loadCase> + <factor> <loadCase> + ...
+	combinationDefinitionRegex = regexp.MustCompile(
+		"^" + inkio.IdGroupExpr("name") + inkio.ArrowExpr +
+			combinationTermExpr +
+			`(?:\s*\+\s*` + combinationTermExpr + `)*` +
+			inkio.OptionalSpaceExpr + "$",
+	)
+
+	combinationTermRegex = regexp.MustCompile(combinationTermExpr)
+)
+
+// DeserializeCombination parses a load combination definition, as in "ULS1 -> 1.35 dead + 1.5 live".
+func DeserializeCombination(definition string) *load.Combination {
+	if !combinationDefinitionRegex.MatchString(definition) {
+		panic(fmt.Sprintf("Found load combination with wrong format: '%s'", definition))
+	}
+
+	var (
+		name         = combinationDefinitionRegex.FindStringSubmatch(definition)[1]
+		termMatches  = combinationTermRegex.FindAllStringSubmatch(definition[len(name):], -1)
+		terms        = make([]load.CombinationTerm, len(termMatches))
+		definedCases = make(map[string]bool)
+	)
+
+	for i, termGroups := range termMatches {
+		loadCase := termGroups[2]
+		if definedCases[loadCase] {
+			panic(fmt.Sprintf("Load combination '%s' includes load case '%s' twice", name, loadCase))
+		}
+		definedCases[loadCase] = true
+
+		terms[i] = load.CombinationTerm{
+			Factor:   inkio.EnsureParseFloat(termGroups[1], "load combination factor"),
+			LoadCase: loadCase,
+		}
+	}
+
+	return load.MakeCombination(name, terms...)
+}
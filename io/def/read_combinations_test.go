@@ -0,0 +1,51 @@
+package def
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeCombination(t *testing.T) {
+	t.Run("deserializes the combination", func(t *testing.T) {
+		var (
+			got  = DeserializeCombination("ULS1 -> 1.35 dead + 1.5 live")
+			want = load.MakeCombination(
+				"ULS1",
+				load.CombinationTerm{Factor: 1.35, LoadCase: "dead"},
+				load.CombinationTerm{Factor: 1.5, LoadCase: "live"},
+			)
+		)
+
+		assert.True(t, want.Equals(got))
+	})
+
+	t.Run("deserializes negative and scientific notation factors", func(t *testing.T) {
+		var (
+			got  = DeserializeCombination("ULS-2->1e0 default+-0.5 wind_x")
+			want = load.MakeCombination(
+				"ULS-2",
+				load.CombinationTerm{Factor: 1.0, LoadCase: "default"},
+				load.CombinationTerm{Factor: -0.5, LoadCase: "wind_x"},
+			)
+		)
+
+		assert.True(t, want.Equals(got))
+	})
+
+	t.Run("the combination serializes to its definition terms", func(t *testing.T) {
+		got := DeserializeCombination("ULS1 -> 1.35 dead + 1.5 live")
+
+		assert.Equal(t, "1.35 dead + 1.5 live", got.String())
+	})
+
+	t.Run("panics if the format is wrong", func(t *testing.T) {
+		assert.Panics(t, func() { DeserializeCombination("ULS1 -> 1.35 dead +") })
+		assert.Panics(t, func() { DeserializeCombination("ULS1 -> dead + live") })
+	})
+
+	t.Run("panics if a load case is included twice", func(t *testing.T) {
+		assert.Panics(t, func() { DeserializeCombination("ULS1 -> 1.35 dead + 1.5 dead") })
+	})
+}
@@ -1,8 +1,10 @@
 package def
 
 import (
-	"strings"
+	"fmt"
+	"regexp"
 
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
 )
 
@@ -12,12 +14,62 @@ const (
 	rotZ  = "rz"
 )
 
+var (
+	// [k]<dof>[=<value>]
+	constraintDofRegex = regexp.MustCompile(`(k)?(dx|dy|rz)(?:=` + inkio.FloatGroupExpr("value") + `)?`)
+
+	// angle=<degrees>
+	constraintAngleRegex = regexp.MustCompile(`angle=` + inkio.FloatGroupExpr("angle"))
+)
+
+// constraintFromString parses a constraint, as in "{dx dy=-0.5 krz=1000}", where the
+// constrained degrees of freedom may include a prescribed value, and the free ones may have a
+// spring, whose stiffness follows the name of the degree of freedom prefixed by "k".
+// An "angle=<degrees>" entry inclines the constraint.
 func constraintFromString(str string) *structure.Constraint {
 	var (
-		dxConst = strings.Contains(str, dispX)
-		dyConst = strings.Contains(str, dispY)
-		rzConst = strings.Contains(str, rotZ)
+		isConstrained = make(map[string]bool)
+		values        = make(map[string]float64)
+		springs       = make(map[string]float64)
 	)
 
-	return structure.MakeConstraint(dxConst, dyConst, rzConst)
+	for _, groups := range constraintDofRegex.FindAllStringSubmatch(str, -1) {
+		isSpring, dof, value := groups[1] != "", groups[2], groups[3]
+
+		if isSpring {
+			springs[dof] = inkio.EnsureParseFloat(value, fmt.Sprintf("constraint %s spring stiffness", dof))
+			continue
+		}
+
+		isConstrained[dof] = true
+		if value != "" {
+			values[dof] = inkio.EnsureParseFloat(value, fmt.Sprintf("constraint %s value", dof))
+		}
+	}
+
+	angle := 0.0
+	if groups := constraintAngleRegex.FindStringSubmatch(str); groups != nil {
+		angle = inkio.EnsureParseFloat(groups[1], "constraint angle")
+	}
+
+	return structure.MakePrescribedConstraint(
+		isConstrained[dispX], isConstrained[dispY], isConstrained[rotZ],
+		values[dispX], values[dispY], values[rotZ],
+	).WithSprings(
+		springs[dispX], springs[dispY], springs[rotZ],
+	).WithAngle(angle)
+}
+
+// linkFromString parses the constraint of a bar end, which can't have prescribed values or be
+// inclined. The springs of a link make it semi-rigid.
+func linkFromString(str string) *structure.Constraint {
+	link := constraintFromString(str)
+	if link.HasPrescribedValues() {
+		panic(fmt.Sprintf("Bar links can't have prescribed values: '%s'", str))
+	}
+	if link.IsInclined() {
+		panic(fmt.Sprintf("Bar links can't be inclined: '%s'", str))
+	}
+
+	return link
 }
@@ -11,22 +11,24 @@ import (
 )
 
 var (
-	// <term> <reference-type> <elementId> <tStart> <valueStart> <tEnd> <valueEnd>
+	// <term> <reference-type> <elementId> <tStart> <valueStart> <tEnd> <valueEnd> [@<loadCase>]
 	distLoadDefinitionRegex = regexp.MustCompile(
 		"^" + inkio.LoadTermExpr + inkio.DistributedLoadRefExpr +
 			inkio.LoadElementID +
 			inkio.FloatGroupExpr("t_start") + inkio.SpaceExpr +
 			inkio.FloatGroupExpr("val_start") + inkio.SpaceExpr +
 			inkio.FloatGroupExpr("t_end") + inkio.SpaceExpr +
-			inkio.FloatGroupExpr("val_end") + inkio.OptionalSpaceExpr + "$",
+			inkio.FloatGroupExpr("val_end") + inkio.OptionalLoadCaseExpr +
+			inkio.OptionalSpaceExpr + "$",
 	)
 
-	// <term> <reference> <elementId> <t> <value>
+	// <term> <reference> <elementId> <t> <value> [@<loadCase>]
 	concLoadDefinitionRegex = regexp.MustCompile(
 		"^" + inkio.LoadTermExpr + inkio.ConcentratedLoadRefExpr +
 			inkio.LoadElementID +
 			inkio.FloatGroupExpr("t") + inkio.SpaceExpr +
-			inkio.FloatGroupExpr("val") + inkio.OptionalSpaceExpr + "$",
+			inkio.FloatGroupExpr("val") + inkio.OptionalLoadCaseExpr +
+			inkio.OptionalSpaceExpr + "$",
 	)
 )
 
@@ -61,6 +63,7 @@ func deserializeDistributedLoad(line string) (contracts.StrID, *load.Distributed
 	valStart := inkio.EnsureParseFloat(groups[5], "distributed load start value")
 	tEnd := inkio.EnsureParseFloat(groups[6], "distributed load end T")
 	valEnd := inkio.EnsureParseFloat(groups[7], "distributed load end value")
+	loadCase := groups[8]
 
 	return elementID,
 		load.MakeDistributed(
@@ -70,7 +73,7 @@ func deserializeDistributedLoad(line string) (contracts.StrID, *load.Distributed
 			valStart,
 			nums.MakeTParam(tEnd),
 			valEnd,
-		)
+		).InCase(loadCaseOrDefault(loadCase))
 }
 
 func deserializeConcentratedLoad(line string) (contracts.StrID, *load.ConcentratedLoad) {
@@ -83,6 +86,18 @@ func deserializeConcentratedLoad(line string) (contracts.StrID, *load.Concentrat
 	elementID := groups[3]
 	t := inkio.EnsureParseFloat(groups[4], "concentrated load T")
 	val := inkio.EnsureParseFloat(groups[5], "concentrated load value")
+	loadCase := groups[6]
 
-	return elementID, load.MakeConcentrated(term, isInLocalCoords, nums.MakeTParam(t), val)
+	return elementID,
+		load.MakeConcentrated(term, isInLocalCoords, nums.MakeTParam(t), val).
+			InCase(loadCaseOrDefault(loadCase))
+}
+
+// loadCaseOrDefault returns the given load case name, or the default case if it's empty.
+func loadCaseOrDefault(loadCase string) string {
+	if loadCase == "" {
+		return load.DefaultCase
+	}
+
+	return loadCase
 }
@@ -35,3 +35,31 @@ func TestDeserializeConcentratedLoad(t *testing.T) {
 		t.Errorf("Expected load %v, got %v", want, gotLoad)
 	}
 }
+
+func TestDeserializeLoadInLoadCase(t *testing.T) {
+	t.Run("distributed load", func(t *testing.T) {
+		_, gotLoad := deserializeDistributedLoad("fy gd 34 0.0 -20.0 1.0 -20.0 @live")
+		want := load.MakeDistributed(load.FY, false, nums.MinT, -20.0, nums.MaxT, -20.0).InCase("live")
+
+		if !gotLoad.Equals(want) {
+			t.Errorf("Expected load %v, got %v", want, gotLoad)
+		}
+	})
+
+	t.Run("concentrated load", func(t *testing.T) {
+		_, gotLoad := deserializeConcentratedLoad("fx lc 45 0.5 10.0 @wind")
+		want := load.MakeConcentrated(load.FX, true, nums.HalfT, 10.0).InCase("wind")
+
+		if !gotLoad.Equals(want) {
+			t.Errorf("Expected load %v, got %v", want, gotLoad)
+		}
+	})
+
+	t.Run("loads without a load case are in the default one", func(t *testing.T) {
+		_, gotLoad := deserializeConcentratedLoad("fx lc 45 0.5 10.0")
+
+		if !gotLoad.IsInDefaultCase() {
+			t.Errorf("Expected load in the default case, got %s", gotLoad.LoadCase)
+		}
+	})
+}
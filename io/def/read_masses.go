@@ -0,0 +1,43 @@
+package def
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+)
+
+// <node> -> <mass> [<rotationalInertia>]
+var nodalMassDefinitionRegex = regexp.MustCompile(
+	"^" + inkio.IdGrpExpr + inkio.ArrowExpr +
+		inkio.FloatGroupExpr("mass") +
+		`(?:` + inkio.SpaceExpr + inkio.FloatGroupExpr("inertia") + `)?` +
+		inkio.OptionalSpaceExpr + "$",
+)
+
+// DeserializeNodalMass parses a nodal mass definition, as in "n1 -> 250.0 10.0".
+func DeserializeNodalMass(definition string) *structure.NodalMass {
+	if !nodalMassDefinitionRegex.MatchString(definition) {
+		panic(fmt.Sprintf("Found nodal mass with wrong format: '%s'", definition))
+	}
+
+	var (
+		groups = inkio.ExtractNamedGroups(nodalMassDefinitionRegex, definition)
+		mass   = &structure.NodalMass{
+			NodeID: contracts.StrID(groups[inkio.IdGrpName]),
+			Mass:   inkio.EnsureParseFloat(groups["mass"], "nodal mass"),
+		}
+	)
+
+	if groups["inertia"] != "" {
+		mass.RotationalInertia = inkio.EnsureParseFloat(groups["inertia"], "nodal rotational inertia")
+	}
+
+	if mass.Mass < 0 || mass.RotationalInertia < 0 {
+		panic(fmt.Sprintf("Nodal mass can't be negative: '%s'", definition))
+	}
+
+	return mass
+}
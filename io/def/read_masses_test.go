@@ -0,0 +1,34 @@
+package def
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeNodalMass(t *testing.T) {
+	t.Run("deserializes the mass", func(t *testing.T) {
+		mass := DeserializeNodalMass("n1 -> 250.5")
+
+		assert.Equal(t, contracts.StrID("n1"), mass.NodeID)
+		assert.Equal(t, 250.5, mass.Mass)
+		assert.Equal(t, 0.0, mass.RotationalInertia)
+	})
+
+	t.Run("deserializes the mass with rotational inertia", func(t *testing.T) {
+		mass := DeserializeNodalMass("n_2->1e2 12.0")
+
+		assert.Equal(t, contracts.StrID("n_2"), mass.NodeID)
+		assert.Equal(t, 100.0, mass.Mass)
+		assert.Equal(t, 12.0, mass.RotationalInertia)
+	})
+
+	t.Run("panics with a negative mass", func(t *testing.T) {
+		assert.Panics(t, func() { DeserializeNodalMass("n1 -> -250.0") })
+	})
+
+	t.Run("panics with wrong format", func(t *testing.T) {
+		assert.Panics(t, func() { DeserializeNodalMass("n1 -> heavy") })
+	})
+}
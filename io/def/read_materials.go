@@ -8,7 +8,7 @@ import (
 	"github.com/angelsolaorbaiceta/inkfem/structure"
 )
 
-// '<name>' -> <density> <young> <shear> <poisson> <yield> <ultimate>
+// '<name>' -> <density> <young> <shear> <poisson> <yield> <ultimate> [<thermalExpansion>]
 var materialDefinitionRegex = regexp.MustCompile(
 	"^" + inkio.NameGrpExpr + inkio.ArrowExpr +
 		inkio.FloatGroupExpr("density") + inkio.SpaceExpr +
@@ -16,7 +16,9 @@ var materialDefinitionRegex = regexp.MustCompile(
 		inkio.FloatGroupExpr("shear") + inkio.SpaceExpr +
 		inkio.FloatGroupExpr("poisson") + inkio.SpaceExpr +
 		inkio.FloatGroupExpr("yield") + inkio.SpaceExpr +
-		inkio.FloatGroupExpr("ultimate") + inkio.OptionalSpaceExpr + "$")
+		inkio.FloatGroupExpr("ultimate") +
+		`(?:` + inkio.SpaceExpr + inkio.FloatGroupExpr("thermal") + `)?` +
+		inkio.OptionalSpaceExpr + "$")
 
 func DeserializeMaterial(definition string) *structure.Material {
 	if !materialDefinitionRegex.MatchString(definition) {
@@ -32,6 +34,10 @@ func DeserializeMaterial(definition string) *structure.Material {
 	possonRatio := inkio.EnsureParseFloat(groups[5], "material poisson ratio")
 	yieldStrength := inkio.EnsureParseFloat(groups[6], "material yield strength")
 	ultimateStrength := inkio.EnsureParseFloat(groups[7], "material ultimate strength")
+	thermalExpansion := 0.0
+	if groups[8] != "" {
+		thermalExpansion = inkio.EnsureParseFloat(groups[8], "material thermal expansion coefficient")
+	}
 
 	return &structure.Material{
 		Name:             name,
@@ -40,5 +46,7 @@ func DeserializeMaterial(definition string) *structure.Material {
 		ShearMod:         shearMod,
 		PoissonRatio:     possonRatio,
 		YieldStrength:    yieldStrength,
-		UltimateStrength: ultimateStrength}
+		UltimateStrength: ultimateStrength,
+		ThermalExpansion: thermalExpansion,
+	}
 }
@@ -28,6 +28,17 @@ func TestDeserializeMaterial(t *testing.T) {
 			want = structure.MakeMaterial("steel", 110.0, 0.022, 3000, 4.4, 5.5, 6.6)
 		)
 
+		if !got.Equals(want) {
+			t.Errorf("Wrong material. Want %v, got %v", want, got)
+		}
+	})
+	t.Run("deserializes the material with thermal expansion coefficient", func(t *testing.T) {
+		var (
+			got  = DeserializeMaterial("'steel' -> 1.1 2.2 3.3 4.4 5.5 6.6 1.2e-5")
+			want = structure.MakeMaterial("steel", 1.1, 2.2, 3.3, 4.4, 5.5, 6.6)
+		)
+		want.ThermalExpansion = 1.2e-5
+
 		if !got.Equals(want) {
 			t.Errorf("Wrong material. Want %v, got %v", want, got)
 		}
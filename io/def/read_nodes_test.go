@@ -25,6 +25,48 @@ func TestDeserializeNode(t *testing.T) {
 			want = structure.MakeNode("1", g2d.MakePoint(100.0, 0.02), &structure.FullConstraint)
 		)
 
+		if !got.Equals(want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+	t.Run("deserializes the node with prescribed displacements", func(t *testing.T) {
+		var (
+			got  = DeserializeNode("1 -> 10.1 20.2 { dx dy=-0.5 rz=1e-3 }")
+			want = structure.MakeNode(
+				"1",
+				g2d.MakePoint(10.1, 20.2),
+				structure.MakePrescribedConstraint(true, true, true, 0, -0.5, 0.001),
+			)
+		)
+
+		if !got.Equals(want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+	t.Run("deserializes the node with springs", func(t *testing.T) {
+		var (
+			got  = DeserializeNode("1 -> 10.1 20.2 { dx kdy=1e4 krz=500 }")
+			want = structure.MakeNode(
+				"1",
+				g2d.MakePoint(10.1, 20.2),
+				structure.MakeConstraint(true, false, false).WithSprings(0, 10000, 500),
+			)
+		)
+
+		if !got.Equals(want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+	t.Run("deserializes the node with an inclined constraint", func(t *testing.T) {
+		var (
+			got  = DeserializeNode("1 -> 10.1 20.2 { dy angle=30 }")
+			want = structure.MakeNode(
+				"1",
+				g2d.MakePoint(10.1, 20.2),
+				structure.DispYConstraint.WithAngle(30),
+			)
+		)
+
 		if !got.Equals(want) {
 			t.Errorf("Expected %v, got %v", want, got)
 		}
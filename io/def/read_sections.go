@@ -8,14 +8,17 @@ import (
 	"github.com/angelsolaorbaiceta/inkfem/structure"
 )
 
-// '<name>' -> <area> <iStrong> <iWeak> <sStrong> <sWeak>
+// '<name>' -> <area> <iStrong> <iWeak> <sStrong> <sWeak> [<depth> [<shearArea>]]
 var sectionDefinitionRegex = regexp.MustCompile(
 	"^" + inkio.NameGrpExpr + inkio.ArrowExpr +
 		inkio.FloatGroupExpr("area") + inkio.SpaceExpr +
 		inkio.FloatGroupExpr("istrong") + inkio.SpaceExpr +
 		inkio.FloatGroupExpr("iweak") + inkio.SpaceExpr +
 		inkio.FloatGroupExpr("sstrong") + inkio.SpaceExpr +
-		inkio.FloatGroupExpr("sweak") + inkio.OptionalSpaceExpr + "$")
+		inkio.FloatGroupExpr("sweak") +
+		`(?:` + inkio.SpaceExpr + inkio.FloatGroupExpr("depth") +
+		`(?:` + inkio.SpaceExpr + inkio.FloatGroupExpr("shear_area") + `)?)?` +
+		inkio.OptionalSpaceExpr + "$")
 
 func DeserializeSection(definition string) *structure.Section {
 	if !sectionDefinitionRegex.MatchString(definition) {
@@ -30,13 +33,23 @@ func DeserializeSection(definition string) *structure.Section {
 	iWeak := inkio.EnsureParseFloat(groups[4], "section iWeak")
 	sStrong := inkio.EnsureParseFloat(groups[5], "section sStrong")
 	sWeak := inkio.EnsureParseFloat(groups[6], "section sWeak")
+	depth := 0.0
+	if groups[7] != "" {
+		depth = inkio.EnsureParseFloat(groups[7], "section depth")
+	}
+	shearArea := 0.0
+	if groups[8] != "" {
+		shearArea = inkio.EnsureParseFloat(groups[8], "section shear area")
+	}
 
 	return &structure.Section{
-		Name:    name,
-		Area:    area,
-		IStrong: iStrong,
-		IWeak:   iWeak,
-		SStrong: sStrong,
-		SWeak:   sWeak,
+		Name:      name,
+		Area:      area,
+		IStrong:   iStrong,
+		IWeak:     iWeak,
+		SStrong:   sStrong,
+		SWeak:     sWeak,
+		Depth:     depth,
+		ShearArea: shearArea,
 	}
 }
@@ -32,4 +32,29 @@ func TestDeserializeSection(t *testing.T) {
 			t.Errorf("Expected section %v, got %v", want, got)
 		}
 	})
+
+	t.Run("deserializes the section with depth", func(t *testing.T) {
+		var (
+			got  = DeserializeSection("'IPE 100' -> 1.1 2.2 3.3 4.4 5.5 10.0")
+			want = structure.MakeSection("IPE 100", 1.1, 2.2, 3.3, 4.4, 5.5)
+		)
+		want.Depth = 10.0
+
+		if !got.Equals(want) {
+			t.Errorf("Expected section %v, got %v", want, got)
+		}
+	})
+
+	t.Run("deserializes the section with depth and shear area", func(t *testing.T) {
+		var (
+			got  = DeserializeSection("'IPE 100' -> 1.1 2.2 3.3 4.4 5.5 10.0 4.1")
+			want = structure.MakeSection("IPE 100", 1.1, 2.2, 3.3, 4.4, 5.5)
+		)
+		want.Depth = 10.0
+		want.ShearArea = 4.1
+
+		if !got.Equals(want) {
+			t.Errorf("Expected section %v, got %v", want, got)
+		}
+	})
 }
@@ -0,0 +1,44 @@
+package def
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+// <term> <elementId> <value> [@<loadCase>]
+var thermalLoadDefinitionRegex = regexp.MustCompile(
+	"^" + inkio.ThermalLoadTermExpr +
+		inkio.LoadElementID +
+		inkio.FloatGroupExpr("val") + inkio.OptionalLoadCaseExpr +
+		inkio.OptionalSpaceExpr + "$",
+)
+
+// IsThermalLoad returns true if the line defines a thermal load.
+func IsThermalLoad(line string) bool {
+	return thermalLoadDefinitionRegex.MatchString(line)
+}
+
+// DeserializeThermalLoad parses a thermal load, as in "dtg 4 25.0 @summer", returning the id
+// of the element where it's applied and the load.
+func DeserializeThermalLoad(line string) (contracts.StrID, *load.ThermalLoad) {
+	if !IsThermalLoad(line) {
+		panic(fmt.Sprintf("Found thermal load with wrong format: '%s'", line))
+	}
+
+	groups := thermalLoadDefinitionRegex.FindStringSubmatch(line)
+
+	term := load.ThermalTerm(groups[1])
+	load.EnsureValidThermalTerm(term)
+
+	var (
+		elementID = groups[2]
+		value     = inkio.EnsureParseFloat(groups[3], "thermal load value")
+		loadCase  = groups[4]
+	)
+
+	return elementID, load.MakeThermal(term, value).InCase(loadCaseOrDefault(loadCase))
+}
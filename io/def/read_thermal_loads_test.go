@@ -0,0 +1,40 @@
+package def
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+func TestDeserializeThermalLoad(t *testing.T) {
+	t.Run("uniform temperature change", func(t *testing.T) {
+		barID, got := DeserializeThermalLoad("dt 4 -15.5")
+
+		if barID != "4" {
+			t.Errorf("Expected bar id 4, got %s", barID)
+		}
+		if got.Term != load.UniformTemperature || got.Value != -15.5 || !got.IsInDefaultCase() {
+			t.Errorf("Expected uniform thermal load -15.5 in default case, got %v", got)
+		}
+	})
+
+	t.Run("temperature gradient in load case", func(t *testing.T) {
+		barID, got := DeserializeThermalLoad("dtg 4 25.0 @summer")
+
+		if barID != "4" {
+			t.Errorf("Expected bar id 4, got %s", barID)
+		}
+		if got.Term != load.TemperatureGradient || got.Value != 25.0 || got.LoadCase != "summer" {
+			t.Errorf("Expected thermal gradient 25.0 in case summer, got %v", got)
+		}
+	})
+
+	t.Run("is a thermal load", func(t *testing.T) {
+		if !IsThermalLoad("dtg 4 25.0") {
+			t.Error("Expected line to be a thermal load")
+		}
+		if IsThermalLoad("fy ld 4 0.0 -50.0 1.0 -75.0") {
+			t.Error("Expected distributed load line not to be a thermal load")
+		}
+	})
+}
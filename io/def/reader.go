@@ -7,6 +7,7 @@ import (
 	"github.com/angelsolaorbaiceta/inkfem/contracts"
 	inkio "github.com/angelsolaorbaiceta/inkfem/io"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 )
 
 // Reads the given .inkfem file and tries to parse a structure from the data defined.
@@ -27,6 +28,9 @@ func parseStructure(linesReader *inkio.LinesReader) *structure.Structure {
 		sections          = make(structure.SectionsByName)
 		concentratedLoads = make(structure.ConcLoadsById)
 		distributedLoads  = make(structure.DistLoadsById)
+		thermalLoads      = make(structure.ThermLoadsById)
+		combinations      = make([]*load.Combination, 0)
+		nodalMasses       = make([]*structure.NodalMass, 0)
 		deserializedBars  = make([]*DeserializedBarDTO, 0)
 		currentSection    string
 	)
@@ -61,6 +65,12 @@ func parseStructure(linesReader *inkio.LinesReader) *structure.Structure {
 
 			case inkio.LoadsHeader:
 				{
+					if IsThermalLoad(line) {
+						barId, thermalLoad := DeserializeThermalLoad(line)
+						thermalLoads[barId] = append(thermalLoads[barId], thermalLoad)
+						break
+					}
+
 					barId, distLoad, concLoad := DeserializeLoad(line)
 					if distLoad != nil {
 						distributedLoads[barId] = append(distributedLoads[barId], distLoad)
@@ -70,6 +80,16 @@ func parseStructure(linesReader *inkio.LinesReader) *structure.Structure {
 					}
 				}
 
+			case inkio.CombinationsHeader:
+				{
+					combinations = append(combinations, DeserializeCombination(line))
+				}
+
+			case inkio.MassesHeader:
+				{
+					nodalMasses = append(nodalMasses, DeserializeNodalMass(line))
+				}
+
 			case inkio.BarsHeader:
 				{
 					bar, _ := DeserializeBar(line)
@@ -88,6 +108,7 @@ func parseStructure(linesReader *inkio.LinesReader) *structure.Structure {
 		Sections:          sections,
 		ConcentratedLoads: concentratedLoads,
 		DistributedLoads:  distributedLoads,
+		ThermalLoads:      thermalLoads,
 	}
 	bars := BarsFromDeserialization(deserializedBars, data)
 
@@ -96,5 +117,11 @@ func parseStructure(linesReader *inkio.LinesReader) *structure.Structure {
 	// 	log.Fatal(err)
 	// }
 
-	return structure.Make(metadata, nodes, bars)
+	str := structure.Make(metadata, nodes, bars)
+	str.SetLoadCombinations(combinations)
+	str.EnsureLoadCombinationsCases(append(str.LoadCases(), load.DefaultCase))
+	str.SetNodalMasses(nodalMasses)
+	str.EnsureNodalMassesNodes(nodes)
+
+	return str
 }
@@ -6,7 +6,7 @@ const (
 	floatExpr      = `[+-]?\d+(?:\.\d+)?(?:[eE][+-]?\d+)?`
 	validNameExpr  = `[\w\-_ ]+`
 	validIDExpr    = `[\w\-_]+`
-	constraintExpr = `{[drxyz ]*}`
+	constraintExpr = `{(?:\s*(?:(?:dx|dy|rz)(?:=` + floatExpr + `)?|k(?:dx|dy|rz)=` + floatExpr + `|angle=` + floatExpr + `))*\s*}`
 )
 
 const (
@@ -18,9 +18,13 @@ const (
 	IdGrpExpr               = `(?P<` + IdGrpName + `>` + validIDExpr + `)`
 	ArrowExpr               = `\s*->\s*`
 	LoadTermExpr            = `(?P<term>[fm]{1}[xyz]{1})\s+`
+	ThermalLoadTermExpr     = `(?P<term>dtg?)\s+`
 	LoadElementID           = `(?P<element>` + validIDExpr + `)\s+`
 	DistributedLoadRefExpr  = `(?P<ref>[lg]{1})d\s+`
 	ConcentratedLoadRefExpr = `(?P<ref>[lg]{1})c\s+`
+	LoadCaseGrpName         = "case"
+	OptionalLoadCaseExpr    = `(?:\s+@(?P<` + LoadCaseGrpName + `>` + validIDExpr + `))?`
+	LoadCaseSuffixExpr      = `(?:@(?P<` + LoadCaseGrpName + `>` + validIDExpr + `))?`
 	DofGrpName              = "dof"
 	DofGrpExpr              = `(?P<` + DofGrpName + `>\[\d+ \d+ \d+\])`
 )
@@ -11,6 +11,30 @@ const PreFileExt = ".inkfempre"
 // SolFileExt is the extension of the solved structure files.
 const SolFileExt = ".inkfemsol"
 
+// BucklingFileExt is the extension of the structure's buckling modes files.
+const BucklingFileExt = ".inkfembuck"
+
+// ModalFileExt is the extension of the structure's natural vibration modes files.
+const ModalFileExt = ".inkfemmodal"
+
+// TimeHistoryFileExt is the extension of the time series files of the time-history analysis.
+const TimeHistoryFileExt = ".inkfemhist"
+
+// SpectrumFileExt is the extension of the response spectrum analysis results files.
+const SpectrumFileExt = ".inkfemspec"
+
+// PushoverFileExt is the extension of the plastic hinge analysis results files.
+const PushoverFileExt = ".inkfempush"
+
+// LoadPathFileExt is the extension of the load–displacement path files of the nonlinear analysis.
+const LoadPathFileExt = ".inkfempath"
+
+// InfluenceFileExt is the extension of the influence lines files, as comma separated values.
+const InfluenceFileExt = ".influence.csv"
+
+// MovingLoadFileExt is the extension of the moving load envelopes files.
+const MovingLoadFileExt = ".inkfemmove"
+
 // IsDefinitionFile returns true if the file extension in the path is .inkfem.
 func IsDefinitionFile(path string) bool {
 	return strings.HasSuffix(path, DefinitionFileExt)
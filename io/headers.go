@@ -10,6 +10,8 @@ var (
 	MaterialsHeader           = "materials"
 	SectionsHeader            = "sections"
 	LoadsHeader               = "loads"
+	CombinationsHeader        = "combinations"
+	MassesHeader              = "masses"
 	BarsHeader                = "bars"
 )
 
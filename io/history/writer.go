@@ -0,0 +1,26 @@
+package history
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"text/template"
+
+	"github.com/angelsolaorbaiceta/inkfem/process"
+)
+
+//go:embed history.template.txt
+var historyTemplateBytes []byte
+
+// Write writes the time series of a time-history solution to the passed in writer: the global
+// displacements {dx, dy, rz} of the chosen nodes, the global reactions {fx, fy, mz} of the
+// constrained ones and the end forces {N, V, M, N, V, M} of the chosen bars, at each time step.
+func Write(solution *process.TimeHistorySolution, writer io.Writer) {
+	var (
+		tmpl       = template.Must(template.New("history").Parse(string(historyTemplateBytes)))
+		buffWriter = bufio.NewWriter(writer)
+	)
+
+	tmpl.Execute(buffWriter, solution)
+	buffWriter.Flush()
+}
@@ -0,0 +1,72 @@
+package history
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTimeHistory(t *testing.T) {
+	var (
+		writer   bytes.Buffer
+		solution = &process.TimeHistorySolution{
+			Metadata:      structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+			LoadCase:      "impact",
+			TimeStep:      0.01,
+			Nodes:         []contracts.StrID{"n1", "n2"},
+			ReactionNodes: []contracts.StrID{"n1"},
+			Bars:          []contracts.StrID{"b1"},
+			Steps: []*process.TimeHistoryStep{
+				{
+					Time: 0.0,
+					Displacements: map[contracts.StrID][3]float64{
+						"n1": {0, 0, 0},
+						"n2": {0, 0, 0},
+					},
+					Reactions: map[contracts.StrID][3]float64{"n1": {0, 0, 0}},
+					Forces:    map[contracts.StrID][6]float64{"b1": {0, 0, 0, 0, 0, 0}},
+				},
+				{
+					Time: 0.01,
+					Displacements: map[contracts.StrID][3]float64{
+						"n1": {0, 0, 0},
+						"n2": {0.25, -0.5, 0.0075},
+					},
+					Reactions: map[contracts.StrID][3]float64{"n1": {-10, 20, 300}},
+					Forces:    map[contracts.StrID][6]float64{"b1": {1, 2, 3, 4, 5, 6}},
+				},
+			},
+		}
+		want = `inkfem v2.3
+analysis: time_history
+load_case: impact
+time_step: 0.01
+
+|displacements|
+n1 >> 2
+0.000000 : 0 0 0
+0.010000 : 0 0 0
+n2 >> 2
+0.000000 : 0 0 0
+0.010000 : 0.25 -0.5 0.0075
+
+|reactions|
+n1 >> 2
+0.000000 : 0 0 0
+0.010000 : -10 20 300
+
+|forces|
+b1 >> 2
+0.000000 : 0 0 0 0 0 0
+0.010000 : 1 2 3 4 5 6
+`
+	)
+
+	Write(solution, &writer)
+
+	assert.Equal(t, want, writer.String())
+}
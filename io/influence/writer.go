@@ -0,0 +1,47 @@
+package influence
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/angelsolaorbaiceta/inkfem/process"
+)
+
+// Write writes the influence lines to the passed in writer as comma separated values: a row for
+// each of the path's points, with the element and position T where the unit load is, its distance
+// along the path and, for each of the quantities, its value. The header row has the quantities'
+// specifications (see process.ParseInfluenceQuantity).
+func Write(solution *process.InfluenceSolution, writer io.Writer) {
+	var (
+		csvWriter = csv.NewWriter(writer)
+		header    = []string{"bar", "t", "position"}
+	)
+
+	for _, line := range solution.Lines {
+		header = append(header, line.Quantity.String())
+	}
+	csvWriter.Write(header)
+
+	for i, point := range solution.Points {
+		row := []string{string(point.ElementID), formatFloat(point.T.Value()), formatFloat(point.Position)}
+		for _, line := range solution.Lines {
+			row = append(row, formatFloat(line.Values[i]))
+		}
+
+		csvWriter.Write(row)
+	}
+
+	csvWriter.Flush()
+}
+
+// formatFloat formats the value with six decimals, without the sign of the values that round to
+// zero.
+func formatFloat(value float64) string {
+	if math.Abs(value) < 5e-7 {
+		value = 0
+	}
+
+	return strconv.FormatFloat(value, 'f', 6, 64)
+}
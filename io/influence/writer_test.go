@@ -0,0 +1,43 @@
+package influence
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteInfluenceLines(t *testing.T) {
+	var (
+		writer   bytes.Buffer
+		solution = &process.InfluenceSolution{
+			Path: []contracts.StrID{"b1", "b2"},
+			Points: []*process.InfluencePoint{
+				{ElementID: "b1", T: nums.MinT, Position: 0},
+				{ElementID: "b1", T: nums.MaxT, Position: 100},
+				{ElementID: "b2", T: nums.HalfT, Position: 150},
+			},
+			Lines: []*process.InfluenceLine{
+				{
+					Quantity: process.InfluenceQuantity{Kind: process.ReactionInfluence, NodeID: "n1", Component: 1},
+					Values:   []float64{1.0, 0.0, -0.1875},
+				},
+				{
+					Quantity: process.InfluenceQuantity{Kind: process.MomentInfluence, ElementID: "b1", T: nums.HalfT, Component: 2},
+					Values:   []float64{0.0, 0.0, -9.375},
+				},
+			},
+		}
+		want = "bar,t,position,reaction:n1:fy,moment:b1:0.5\n" +
+			"b1,0.000000,0.000000,1.000000,0.000000\n" +
+			"b1,1.000000,100.000000,0.000000,0.000000\n" +
+			"b2,0.500000,150.000000,-0.187500,-9.375000\n"
+	)
+
+	Write(solution, &writer)
+
+	assert.Equal(t, want, writer.String())
+}
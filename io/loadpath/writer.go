@@ -0,0 +1,27 @@
+package loadpath
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"text/template"
+
+	"github.com/angelsolaorbaiceta/inkfem/process"
+)
+
+//go:embed loadpath.template.txt
+var loadPathTemplateBytes []byte
+
+// Write writes the load–displacement path of a nonlinear solution to the passed in writer: for
+// each load increment, the load factor and the number of iterations, followed by the global
+// displacements {dx, dy, rz} of each of the path nodes.
+func Write(solution *process.NonlinearSolution, writer io.Writer) {
+	var (
+		funcs      = template.FuncMap{"inc": func(i int) int { return i + 1 }}
+		tmpl       = template.Must(template.New("loadpath").Funcs(funcs).Parse(string(loadPathTemplateBytes)))
+		buffWriter = bufio.NewWriter(writer)
+	)
+
+	tmpl.Execute(buffWriter, solution)
+	buffWriter.Flush()
+}
@@ -0,0 +1,58 @@
+package loadpath
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteLoadPath(t *testing.T) {
+	var (
+		writer   bytes.Buffer
+		solution = &process.NonlinearSolution{
+			Solution: &process.Solution{
+				Metadata: structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+				LoadCase: "default",
+			},
+			PathNodes: []contracts.StrID{"n1", "n2"},
+			Path: []*process.LoadPathPoint{
+				{
+					LoadFactor: 0.5,
+					Iterations: 3,
+					Displacements: map[contracts.StrID][3]float64{
+						"n1": {0.1, -0.2, 0.003},
+						"n2": {0, 0, 0},
+					},
+				},
+				{
+					LoadFactor: 1.0,
+					Iterations: 4,
+					Displacements: map[contracts.StrID][3]float64{
+						"n1": {0.25, -0.5, 0.0075},
+						"n2": {1, 2, 3},
+					},
+				},
+			},
+		}
+		want = `inkfem v2.3
+analysis: nonlinear
+load_case: default
+
+|path|
+step 1 -> 0.500000 (3 iterations)
+n1 -> 0.1 -0.2 0.003
+n2 -> 0 0 0
+step 2 -> 1.000000 (4 iterations)
+n1 -> 0.25 -0.5 0.0075
+n2 -> 1 2 3
+`
+	)
+
+	Write(solution, &writer)
+
+	assert.Equal(t, want, writer.String())
+}
@@ -0,0 +1,114 @@
+package modes
+
+import (
+	"bytes"
+	"testing"
+
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAndReadBucklingModes(t *testing.T) {
+	var (
+		preElement = inkio.MakeTestPreprocessedStructure().GetElementById("b1")
+		shape      = &process.ElementModeShape{Element: preElement}
+		writer     bytes.Buffer
+	)
+
+	for i, node := range preElement.Nodes() {
+		value := float64(i) / 2.0
+		shape.GlobalXDispl = append(shape.GlobalXDispl, process.PointSolutionValue{T: node.T, Value: value})
+		shape.GlobalYDispl = append(shape.GlobalYDispl, process.PointSolutionValue{T: node.T, Value: -value})
+		shape.GlobalZRot = append(shape.GlobalZRot, process.PointSolutionValue{T: node.T, Value: 1e-5 * value})
+	}
+
+	WriteBuckling(&process.BucklingSolution{
+		Metadata: structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+		LoadCase: "live",
+		Modes: []*process.BucklingMode{
+			{LoadFactor: 12.5, Elements: []*process.ElementModeShape{shape}},
+			{LoadFactor: 40.25, Elements: []*process.ElementModeShape{shape}},
+		},
+	}, &writer)
+
+	file := Read(&writer)
+
+	assert.Equal(t, 2, file.Metadata.MajorVersion)
+	assert.Equal(t, 3, file.Metadata.MinorVersion)
+	assert.Equal(t, BucklingAnalysis, file.Analysis)
+	assert.Equal(t, "live", file.LoadCase)
+	assert.Equal(t, 2, len(file.Modes))
+
+	t.Run("modes numbers and values", func(t *testing.T) {
+		assert.Equal(t, 1, file.Modes[0].Number)
+		assert.Equal(t, 12.5, file.Modes[0].Value)
+		assert.Equal(t, 2, file.Modes[1].Number)
+		assert.Equal(t, 40.25, file.Modes[1].Value)
+	})
+
+	t.Run("mode shape displacements", func(t *testing.T) {
+		displ := file.Modes[1].Shape["b1"]
+		assert.Equal(t, preElement.NodesCount(), len(displ))
+
+		for i, node := range preElement.Nodes() {
+			value := float64(i) / 2.0
+			assert.True(t, node.T.Equals(displ[i].T))
+			assert.True(t, nums.FloatsEqual(value, displ[i].Dx))
+			assert.True(t, nums.FloatsEqual(-value, displ[i].Dy))
+			assert.True(t, nums.FloatsEqual(1e-5*value, displ[i].Rz))
+		}
+	})
+}
+
+func TestWriteAndReadModalModes(t *testing.T) {
+	var (
+		preElement = inkio.MakeTestPreprocessedStructure().GetElementById("b1")
+		shape      = &process.ElementModeShape{Element: preElement}
+		writer     bytes.Buffer
+	)
+
+	for _, node := range preElement.Nodes() {
+		shape.GlobalXDispl = append(shape.GlobalXDispl, process.PointSolutionValue{T: node.T, Value: 1.0})
+		shape.GlobalYDispl = append(shape.GlobalYDispl, process.PointSolutionValue{T: node.T, Value: 0.0})
+		shape.GlobalZRot = append(shape.GlobalZRot, process.PointSolutionValue{T: node.T, Value: 0.0})
+	}
+
+	WriteModal(&process.ModalSolution{
+		Metadata: structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+		Modes: []*process.VibrationMode{
+			{
+				AngularFrequency:     20.0,
+				Frequency:            3.25,
+				Period:               0.5,
+				ParticipationFactorX: 1.25,
+				ParticipationFactorY: -2e-05,
+				MassRatioX:           0.8,
+				MassRatioY:           0.0,
+				Elements:             []*process.ElementModeShape{shape},
+			},
+		},
+	}, &writer)
+
+	file := Read(&writer)
+
+	assert.Equal(t, ModalAnalysis, file.Analysis)
+	assert.Equal(t, "", file.LoadCase)
+	assert.Equal(t, 1, len(file.Modes))
+	assert.Equal(t, 3.25, file.Modes[0].Value)
+	assert.Equal(t, preElement.NodesCount(), len(file.Modes[0].Shape["b1"]))
+
+	t.Run("mode properties", func(t *testing.T) {
+		props := file.Modes[0].Properties
+
+		assert.Equal(t, 6, len(props))
+		assert.Equal(t, 0.5, props[PeriodProp])
+		assert.Equal(t, 20.0, props[AngularFrequencyProp])
+		assert.Equal(t, 1.25, props[ParticipationFactorXProp])
+		assert.Equal(t, -2e-05, props[ParticipationFactorYProp])
+		assert.Equal(t, 0.8, props[MassRatioXProp])
+		assert.Equal(t, 0.0, props[MassRatioYProp])
+	})
+}
@@ -0,0 +1,161 @@
+package modes
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+const modesHeader = "modes"
+
+var (
+	analysisRegex = regexp.MustCompile(`^analysis:\s*(\w+)$`)
+	loadCaseRegex = regexp.MustCompile(`^load_case:\s*([\w\-_]+)$`)
+	modeRegex     = regexp.MustCompile(
+		`^mode\s+(?P<number>\d+)` + inkio.ArrowExpr + inkio.FloatGroupExpr("value") +
+			`(?:\s*\{(?P<props>[^}]*)\})?$`,
+	)
+	propertyRegex = regexp.MustCompile(`^(?P<name>\w+)=` + inkio.FloatGroupExpr("value") + "$")
+	elementRegex  = regexp.MustCompile(
+		"^" + inkio.IdGrpExpr + `\s*>>\s*(?P<count>\d+)$`,
+	)
+	nodeDisplRegex = regexp.MustCompile(
+		"^" + inkio.FloatGroupExpr("t") +
+			inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
+			inkio.FloatGroupExpr("dx") + inkio.SpaceExpr +
+			inkio.FloatGroupExpr("dy") + inkio.SpaceExpr +
+			inkio.FloatGroupExpr("rz") + "$",
+	)
+)
+
+// NodeDisplacement is the global displacement of a sliced element node in a mode shape.
+type NodeDisplacement struct {
+	T          nums.TParam
+	Dx, Dy, Rz float64
+}
+
+// A Mode is a mode read from a modes file: its number, its value (a critical load factor, for
+// instance), its additional properties (like the period of a vibration mode) and the
+// displacements of each of the elements' nodes in its shape.
+type Mode struct {
+	Number     int
+	Value      float64
+	Properties map[string]float64
+	Shape      map[contracts.StrID][]NodeDisplacement
+}
+
+// ModesFile is the content of a modes file: the analysis that yielded the modes, the load case
+// they refer to, if any, and the modes.
+type ModesFile struct {
+	Metadata structure.StrMetadata
+	Analysis string
+	LoadCase string
+	Modes    []*Mode
+}
+
+// Read parses the modes of a structure from a modes file.
+func Read(reader io.Reader) *ModesFile {
+	var (
+		linesReader = inkio.MakeLinesReader(reader)
+		file        = &ModesFile{Metadata: inkio.ParseMetadata(linesReader)}
+		mode        *Mode
+	)
+
+	file.Analysis = readHeaderValue(linesReader, analysisRegex)
+
+	if !linesReader.ReadNext() {
+		panic(fmt.Sprintf("Expected the '|%s|' header", modesHeader))
+	}
+	if loadCaseRegex.MatchString(linesReader.GetNextLine()) {
+		file.LoadCase = loadCaseRegex.FindStringSubmatch(linesReader.GetNextLine())[1]
+
+		if !linesReader.ReadNext() {
+			panic(fmt.Sprintf("Expected the '|%s|' header", modesHeader))
+		}
+	}
+
+	if !inkio.IsSectionHeaderLine(linesReader.GetNextLine()) ||
+		inkio.ParseSectionHeader(linesReader.GetNextLine()) != modesHeader {
+		panic(fmt.Sprintf("Expected the '|%s|' header", modesHeader))
+	}
+
+	for linesReader.ReadNext() {
+		line := linesReader.GetNextLine()
+
+		if modeRegex.MatchString(line) {
+			groups := inkio.ExtractNamedGroups(modeRegex, line)
+			number, _ := strconv.Atoi(groups["number"])
+
+			mode = &Mode{
+				Number:     number,
+				Value:      inkio.EnsureParseFloat(groups["value"], "mode value"),
+				Properties: deserializeProperties(groups["props"]),
+				Shape:      make(map[contracts.StrID][]NodeDisplacement),
+			}
+			file.Modes = append(file.Modes, mode)
+
+			continue
+		}
+
+		if mode == nil {
+			panic(fmt.Sprintf("Expected a mode definition, got '%s'", line))
+		}
+
+		var (
+			groups    = inkio.ExtractNamedGroups(elementRegex, line)
+			count, _  = strconv.Atoi(groups["count"])
+			nodeLines = linesReader.GetNextLines(count)
+			displ     = make([]NodeDisplacement, count)
+		)
+
+		for i, nodeLine := range nodeLines {
+			displ[i] = deserializeNodeDisplacement(nodeLine)
+		}
+
+		mode.Shape[contracts.StrID(groups[inkio.IdGrpName])] = displ
+	}
+
+	return file
+}
+
+func readHeaderValue(linesReader *inkio.LinesReader, regex *regexp.Regexp) string {
+	if !linesReader.ReadNext() || !regex.MatchString(linesReader.GetNextLine()) {
+		panic(fmt.Sprintf("Expected a line matching: %s", regex))
+	}
+
+	return regex.FindStringSubmatch(linesReader.GetNextLine())[1]
+}
+
+// deserializeProperties parses the space separated "name=value" properties of a mode.
+func deserializeProperties(propsString string) map[string]float64 {
+	props := make(map[string]float64)
+
+	for _, prop := range strings.Fields(propsString) {
+		if !propertyRegex.MatchString(prop) {
+			panic(fmt.Sprintf("Invalid mode property: '%s'", prop))
+		}
+
+		groups := inkio.ExtractNamedGroups(propertyRegex, prop)
+		props[groups["name"]] = inkio.EnsureParseFloat(groups["value"], "mode property "+groups["name"])
+	}
+
+	return props
+}
+
+func deserializeNodeDisplacement(line string) NodeDisplacement {
+	groups := inkio.ExtractNamedGroups(nodeDisplRegex, line)
+
+	return NodeDisplacement{
+		T:  nums.MakeTParam(inkio.EnsureParseFloat(groups["t"], "mode node T position")),
+		Dx: inkio.EnsureParseFloat(groups["dx"], "mode node x displacement"),
+		Dy: inkio.EnsureParseFloat(groups["dy"], "mode node y displacement"),
+		Rz: inkio.EnsureParseFloat(groups["rz"], "mode node rotation"),
+	}
+}
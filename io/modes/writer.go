@@ -0,0 +1,109 @@
+package modes
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"text/template"
+
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+)
+
+//go:embed modes.template.txt
+var modesTemplateBytes []byte
+
+const (
+	// BucklingAnalysis is the name of the linear buckling analysis in the modes files.
+	BucklingAnalysis = "buckling"
+
+	// ModalAnalysis is the name of the modal (natural vibration) analysis in the modes files.
+	ModalAnalysis = "modal"
+)
+
+// Names of the properties of the vibration modes in the modal analysis files.
+const (
+	PeriodProp               = "period"
+	AngularFrequencyProp     = "omega"
+	ParticipationFactorXProp = "gamma_x"
+	ParticipationFactorYProp = "gamma_y"
+	MassRatioXProp           = "mass_x"
+	MassRatioYProp           = "mass_y"
+)
+
+// modeData is a mode as written to the file: the mode's value (a critical load factor, for
+// instance), its additional properties, if any, and its shape in each of the elements.
+type modeData struct {
+	Value      float64
+	Properties []modeProperty
+	Elements   []*process.ElementModeShape
+}
+
+// modeProperty is a named value of a mode, written in the order it's given.
+type modeProperty struct {
+	Name  string
+	Value float64
+}
+
+// WriteBuckling writes the buckling modes of a structure to the passed in writer: the critical
+// load factor of each mode followed by the global displacements of the nodes of each element in
+// the mode's shape.
+func WriteBuckling(solution *process.BucklingSolution, writer io.Writer) {
+	modes := make([]modeData, len(solution.Modes))
+	for i, mode := range solution.Modes {
+		modes[i] = modeData{Value: mode.LoadFactor, Elements: mode.Elements}
+	}
+
+	write(solution.Metadata, BucklingAnalysis, solution.LoadCase, modes, writer)
+}
+
+// WriteModal writes the natural vibration modes of a structure to the passed in writer: the
+// frequency of each mode, in hertz, with its period, angular frequency, participation factors
+// and mass ratios, followed by the global displacements of the nodes of each element in the
+// mode's shape.
+func WriteModal(solution *process.ModalSolution, writer io.Writer) {
+	modes := make([]modeData, len(solution.Modes))
+	for i, mode := range solution.Modes {
+		modes[i] = modeData{
+			Value: mode.Frequency,
+			Properties: []modeProperty{
+				{PeriodProp, mode.Period},
+				{AngularFrequencyProp, mode.AngularFrequency},
+				{ParticipationFactorXProp, mode.ParticipationFactorX},
+				{ParticipationFactorYProp, mode.ParticipationFactorY},
+				{MassRatioXProp, mode.MassRatioX},
+				{MassRatioYProp, mode.MassRatioY},
+			},
+			Elements: mode.Elements,
+		}
+	}
+
+	write(solution.Metadata, ModalAnalysis, "", modes, writer)
+}
+
+func write(
+	metadata structure.StrMetadata,
+	analysis, loadCase string,
+	modes []modeData,
+	writer io.Writer,
+) {
+	var (
+		funcs      = template.FuncMap{"inc": func(i int) int { return i + 1 }}
+		tmpl       = template.Must(template.New("modes").Funcs(funcs).Parse(string(modesTemplateBytes)))
+		buffWriter = bufio.NewWriter(writer)
+		data       = struct {
+			Metadata structure.StrMetadata
+			Analysis string
+			LoadCase string
+			Modes    []modeData
+		}{
+			Metadata: metadata,
+			Analysis: analysis,
+			LoadCase: loadCase,
+			Modes:    modes,
+		}
+	)
+
+	tmpl.Execute(buffWriter, data)
+	buffWriter.Flush()
+}
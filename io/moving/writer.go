@@ -0,0 +1,42 @@
+package moving
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"text/template"
+
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+//go:embed moving.template.txt
+var movingTemplateBytes []byte
+
+// Write writes the result of moving a load train along a path to the passed in writer.
+//
+// The train's axles, with their offset behind the first one and load, and the train's positions,
+// with the load case of each of them, go first. Then, the envelope of each component {fx, fy, mz}
+// of the reactions and the envelopes of the elements' stresses, in the same format as those of
+// the solution files, where the governing load cases are the positions'.
+func Write(solution *process.MovingLoadSolution, writer io.Writer) {
+	var (
+		funcs = template.FuncMap{
+			"inc":        func(i int) int { return i + 1 },
+			"components": func() []string { return []string{"fx", "fy", "mz"} },
+			"axles": func(train *load.LoadTrain) []int {
+				indices := make([]int, train.AxlesCount())
+				for i := range indices {
+					indices[i] = i
+				}
+
+				return indices
+			},
+		}
+		tmpl       = template.Must(template.New("moving").Funcs(funcs).Parse(string(movingTemplateBytes)))
+		buffWriter = bufio.NewWriter(writer)
+	)
+
+	tmpl.Execute(buffWriter, solution)
+	buffWriter.Flush()
+}
@@ -0,0 +1,85 @@
+package moving
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteMovingLoad(t *testing.T) {
+	var (
+		writer   bytes.Buffer
+		element  = inkio.MakeTestPreprocessedStructure().Elements()[0]
+		solution = &process.MovingLoadSolution{
+			Metadata: structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+			Path:     []contracts.StrID{"b1", "b2"},
+			Train:    load.MakeLoadTrain([]float64{100, 50}, []float64{25}),
+			Positions: []structure.MovingLoadPosition{
+				{LoadCase: "train_1", Position: 0},
+				{LoadCase: "train_2", Position: 12.5},
+			},
+			Reactions: []*process.ReactionEnvelope{
+				{
+					NodeID:  "n1",
+					Max:     [3]float64{0, 150, 10},
+					MaxCase: [3]string{"train_1", "train_1", "train_2"},
+					Min:     [3]float64{0, 75, -10},
+					MinCase: [3]string{"train_1", "train_2", "train_1"},
+				},
+			},
+			Envelopes: []*process.ElementEnvelope{
+				{
+					Element: element,
+					AxialStress: []process.EnvelopeValue{
+						{T: nums.MinT, Max: 0, MaxCombination: "train_1", Min: 0, MinCombination: "train_1"},
+					},
+					ShearForce: []process.EnvelopeValue{
+						{T: nums.MinT, Max: 2, MaxCombination: "train_2", Min: -2, MinCombination: "train_1"},
+					},
+					BendingMoment: []process.EnvelopeValue{
+						{T: nums.MaxT, Max: 3, MaxCombination: "train_2", Min: -3, MinCombination: "train_1"},
+					},
+				},
+			},
+		}
+		want = `inkfem v2.3
+analysis: moving_load
+path: b1 b2
+
+|train|
+axle 1 -> 0 100
+axle 2 -> 25 50
+
+|positions|
+train_1 -> 0
+train_2 -> 12.5
+
+|reactions|
+n1
+fx : 0.000000 train_1 0.000000 train_1
+fy : 150.000000 train_1 75.000000 train_2
+mz : 10.000000 train_2 -10.000000 train_1
+
+|envelopes|
+b1
+__axial__
+0.000000 : 0.000000 train_1 0.000000 train_1
+__shear__
+0.000000 : 2.000000 train_2 -2.000000 train_1
+__bend__
+1.000000 : 3.000000 train_2 -3.000000 train_1
+
+`
+	)
+
+	Write(solution, &writer)
+
+	assert.Equal(t, want, writer.String())
+}
@@ -1,6 +1,7 @@
 package pre
 
 import (
+	"fmt"
 	"regexp"
 
 	inkio "github.com/angelsolaorbaiceta/inkfem/io"
@@ -8,12 +9,13 @@ import (
 	"github.com/angelsolaorbaiceta/inkfem/math"
 	"github.com/angelsolaorbaiceta/inkfem/preprocess"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 	"github.com/angelsolaorbaiceta/inkgeom/g2d"
 	"github.com/angelsolaorbaiceta/inkgeom/nums"
 )
 
 const (
-	linesPerNode         = 6
+	loadLinesPerCase     = 4
 	tPosGroupName        = "t"
 	xPosGroupName        = "x"
 	yPosGroupName        = "y"
@@ -35,19 +37,19 @@ var (
 	)
 
 	externalLoadPattern = regexp.MustCompile(
-		"^" + "ext" + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
+		"^" + "ext" + inkio.LoadCaseSuffixExpr + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
 			inkio.TorsorGroupExpr(extTorsorGroupName) + "$",
 	)
 	leftLoadPattern = regexp.MustCompile(
-		"^" + "left" + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
+		"^" + "left" + inkio.LoadCaseSuffixExpr + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
 			inkio.TorsorGroupExpr(leftTorsorGroupName) + "$",
 	)
 	rightLoadPattern = regexp.MustCompile(
-		"^" + "right" + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
+		"^" + "right" + inkio.LoadCaseSuffixExpr + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
 			inkio.TorsorGroupExpr(rightTorsorGroupName) + "$",
 	)
 	netLoadPattern = regexp.MustCompile(
-		"^" + "net" + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
+		"^" + "net" + inkio.LoadCaseSuffixExpr + inkio.OptionalSpaceExpr + ":" + inkio.OptionalSpaceExpr +
 			inkio.TorsorGroupExpr(netTorsorGroupName) + "$",
 	)
 
@@ -64,52 +66,81 @@ func DeserializeBar(
 	var (
 		originalBarDTO, nNodes = iodef.DeserializeBar(linesReader.GetNextLine())
 		originalBar            = iodef.BarFromDeserialization(originalBarDTO, data)
-		nLines                 = nNodes * linesPerNode
-		lines                  = linesReader.GetNextLines(nLines)
-		nodes                  = deserializeBarNodes(nNodes, lines)
+		nodes                  = make([]*preprocess.Node, nNodes)
 	)
 
+	for i := 0; i < nNodes; i++ {
+		nodes[i] = deserializeNode(readNodeLines(linesReader))
+	}
+
 	return preprocess.MakeElement(originalBar, nodes)
 }
 
-func deserializeBarNodes(nNodes int, lines []string) []*preprocess.Node {
-	var (
-		nodes            = make([]*preprocess.Node, nNodes)
-		idxStart, idxEnd int
-	)
+// readNodeLines reads the lines of a sliced element node, from the position line to the
+// degrees of freedom line. The number of lines depends on the number of load cases with
+// loads applied to the node.
+func readNodeLines(linesReader *inkio.LinesReader) []string {
+	var lines []string
 
-	for i := 0; i < nNodes; i++ {
-		idxStart = i * linesPerNode
-		idxEnd = idxStart + linesPerNode
-		nodes[i] = deserializeNode(lines[idxStart:idxEnd])
-	}
+	for {
+		if !linesReader.ReadNext() {
+			panic("Couldn't read all the lines of the node")
+		}
 
-	return nodes
+		line := linesReader.GetNextLine()
+		lines = append(lines, line)
+
+		if dofPattern.MatchString(line) {
+			return lines
+		}
+	}
 }
 
+// deserializeNode parses the lines of a node. After the position line, go groups of four lines
+// with the external, left, right and net loads for each load case, and the last line is the
+// degrees of freedom one.
 func deserializeNode(lines []string) *preprocess.Node {
 	var (
 		t, pos           = parsePosition(lines[0])
-		extLoad          = parseExternalLoad(lines[1])
-		leftLoad         = parseLeftLoad(lines[2])
-		rightLoad        = parseRightLoad(lines[3])
-		netLoad          = parseNetLoad(lines[4])
-		dof1, dof2, dof3 = parseDof(lines[5])
-		node             = preprocess.MakeNode(t, pos, extLoad.Fx(), extLoad.Fy(), extLoad.Mz())
+		loadLines        = lines[1 : len(lines)-1]
+		dof1, dof2, dof3 = parseDof(lines[len(lines)-1])
+		node             = preprocess.MakeUnloadedNode(t, pos)
 	)
 
+	if len(loadLines)%loadLinesPerCase != 0 {
+		panic(fmt.Sprintf("Expected %d load lines per load case in node", loadLinesPerCase))
+	}
+
 	node.SetDegreesOfFreedomNum(dof1, dof2, dof3)
-	node.AddLocalLeftLoad(leftLoad.Fx(), leftLoad.Fy(), leftLoad.Mz())
-	node.AddLocalRightLoad(rightLoad.Fx(), rightLoad.Fy(), rightLoad.Mz())
 
-	// Net load is added as a checksum. Ensure it checks out or panic.
-	if !node.NetLocalLoadTorsor().Equals(netLoad) {
-		panic("Expected net load doesn't match the read one")
+	for i := 0; i < len(loadLines); i += loadLinesPerCase {
+		deserializeNodeCaseLoads(node, loadLines[i:i+loadLinesPerCase])
 	}
 
 	return node
 }
 
+// deserializeNodeCaseLoads parses the external, left, right and net loads lines of a load
+// case, and adds the loads to the node in that load case.
+func deserializeNodeCaseLoads(node *preprocess.Node, lines []string) {
+	var (
+		loadCase, extLoad = parseExternalLoad(lines[0])
+		_, leftLoad       = parseLeftLoad(lines[1])
+		_, rightLoad      = parseRightLoad(lines[2])
+		_, netLoad        = parseNetLoad(lines[3])
+		caseNode          = node.InLoadCase(loadCase)
+	)
+
+	caseNode.AddLocalExternalLoad(extLoad)
+	caseNode.AddLocalLeftLoad(leftLoad.Fx(), leftLoad.Fy(), leftLoad.Mz())
+	caseNode.AddLocalRightLoad(rightLoad.Fx(), rightLoad.Fy(), rightLoad.Mz())
+
+	// Net load is added as a checksum. Ensure it checks out or panic.
+	if !caseNode.NetLocalLoadTorsor().Equals(netLoad) {
+		panic("Expected net load doesn't match the read one")
+	}
+}
+
 func parsePosition(line string) (nums.TParam, *g2d.Point) {
 	var (
 		groups = inkio.ExtractNamedGroups(positionPattern, line)
@@ -121,24 +152,34 @@ func parsePosition(line string) (nums.TParam, *g2d.Point) {
 	return nums.MakeTParam(t), g2d.MakePoint(x, y)
 }
 
-func parseExternalLoad(line string) *math.Torsor {
+func parseExternalLoad(line string) (string, *math.Torsor) {
 	groups := inkio.ExtractNamedGroups(externalLoadPattern, line)
-	return inkio.EnsureParseTorsor(groups[extTorsorGroupName], "external load")
+	return parseLoadCase(groups), inkio.EnsureParseTorsor(groups[extTorsorGroupName], "external load")
 }
 
-func parseLeftLoad(line string) *math.Torsor {
+func parseLeftLoad(line string) (string, *math.Torsor) {
 	groups := inkio.ExtractNamedGroups(leftLoadPattern, line)
-	return inkio.EnsureParseTorsor(groups[leftTorsorGroupName], "left load")
+	return parseLoadCase(groups), inkio.EnsureParseTorsor(groups[leftTorsorGroupName], "left load")
 }
 
-func parseRightLoad(line string) *math.Torsor {
+func parseRightLoad(line string) (string, *math.Torsor) {
 	groups := inkio.ExtractNamedGroups(rightLoadPattern, line)
-	return inkio.EnsureParseTorsor(groups[rightTorsorGroupName], "right load")
+	return parseLoadCase(groups), inkio.EnsureParseTorsor(groups[rightTorsorGroupName], "right load")
 }
 
-func parseNetLoad(line string) *math.Torsor {
+func parseNetLoad(line string) (string, *math.Torsor) {
 	groups := inkio.ExtractNamedGroups(netLoadPattern, line)
-	return inkio.EnsureParseTorsor(groups[netTorsorGroupName], "net load")
+	return parseLoadCase(groups), inkio.EnsureParseTorsor(groups[netTorsorGroupName], "net load")
+}
+
+// parseLoadCase returns the load case of a node load line, which is the default one if the
+// line has no load case suffix.
+func parseLoadCase(groups map[string]string) string {
+	if loadCase := groups[inkio.LoadCaseGrpName]; loadCase != "" {
+		return loadCase
+	}
+
+	return load.DefaultCase
 }
 
 func parseDof(line string) (int, int, int) {
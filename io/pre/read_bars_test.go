@@ -0,0 +1,40 @@
+package pre
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeNodeInLoadCases(t *testing.T) {
+	node := preprocess.MakeNode(nums.HalfT, g2d.MakePoint(100, 0), 10, 20, 30)
+	node.SetDegreesOfFreedomNum(3, 4, 5)
+	node.AddLocalLeftLoad(1, 2, 3)
+	node.InLoadCase("live").AddLocalExternalLoad(math.MakeTorsor(5, 6, 7))
+	node.InLoadCase("live").AddLocalRightLoad(-1, -2, -3)
+
+	lines := strings.Split(node.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	t.Run("writes the default case loads and then the other case loads", func(t *testing.T) {
+		assert.Equal(t, 10, len(lines))
+		assert.Regexp(t, `^ext@live\s+: {5(\.[0]+)? 6(\.[0]+)? 7(\.[0]+)?}$`, lines[5])
+		assert.Regexp(t, `^net@live\s+: {4(\.[0]+)? 4(\.[0]+)? 4(\.[0]+)?}$`, lines[8])
+	})
+
+	t.Run("reads the loads of all the load cases", func(t *testing.T) {
+		got := deserializeNode(lines)
+
+		assert.True(t, node.Equals(got))
+		assert.Equal(t, []string{"default", "live"}, got.LoadCases())
+		assert.True(t, math.MakeTorsor(11, 22, 33).Equals(got.NetLocalLoadTorsor()))
+		assert.True(t, math.MakeTorsor(4, 4, 4).Equals(got.InLoadCase("live").NetLocalLoadTorsor()))
+	})
+}
@@ -11,11 +11,13 @@ import (
 	iodef "github.com/angelsolaorbaiceta/inkfem/io/def"
 	"github.com/angelsolaorbaiceta/inkfem/preprocess"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 )
 
 var (
 	dofRegex       = regexp.MustCompile(`dof_count:\s*(\d+)`)
 	ownWeightRegex = regexp.MustCompile(`includes_own_weight:\s*(yes|no)`)
+	numberingRegex = regexp.MustCompile(`dof_numbering:\s*(\w+)`)
 )
 
 // Read parses a preprocessed structure from an .inkfempre file.
@@ -23,21 +25,24 @@ func Read(reader io.Reader) *preprocess.Structure {
 	linesReader := inkio.MakeLinesReader(reader)
 
 	var (
-		metadata          = inkio.ParseMetadata(linesReader)
-		numberOfDof       = extractNumberOfDof(linesReader)
-		includesOwnWeight = extractIncludesOwnWeight(linesReader)
-		nodes             = make(map[contracts.StrID]*structure.Node)
-		materials         = make(structure.MaterialsByName)
-		sections          = make(structure.SectionsByName)
-		bars              = make([]*preprocess.Element, 0)
-		nodesDefined      = false
-		materialsDefined  = false
-		sectionsDefined   = false
-		line              string
-		currentSection    string
+		metadata                  = inkio.ParseMetadata(linesReader)
+		numberOfDof               = extractNumberOfDof(linesReader)
+		includesOwnWeight         = extractIncludesOwnWeight(linesReader)
+		dofNumbering, hasNextLine = extractDofNumbering(linesReader)
+		nodes                     = make(map[contracts.StrID]*structure.Node)
+		materials                 = make(structure.MaterialsByName)
+		sections                  = make(structure.SectionsByName)
+		combinations              = make([]*load.Combination, 0)
+		nodalMasses               = make([]*structure.NodalMass, 0)
+		bars                      = make([]*preprocess.Element, 0)
+		nodesDefined              = false
+		materialsDefined          = false
+		sectionsDefined           = false
+		line                      string
+		currentSection            string
 	)
 
-	for linesReader.ReadNext() {
+	for ; hasNextLine; hasNextLine = linesReader.ReadNext() {
 		line = linesReader.GetNextLine()
 
 		if inkio.IsSectionHeaderLine(line) {
@@ -65,6 +70,16 @@ func Read(reader io.Reader) *preprocess.Structure {
 					sectionsDefined = true
 				}
 
+			case inkio.CombinationsHeader:
+				{
+					combinations = append(combinations, iodef.DeserializeCombination(line))
+				}
+
+			case inkio.MassesHeader:
+				{
+					nodalMasses = append(nodalMasses, iodef.DeserializeNodalMass(line))
+				}
+
 			case inkio.BarsHeader:
 				{
 					if !(nodesDefined && materialsDefined && sectionsDefined) {
@@ -91,12 +106,19 @@ func Read(reader io.Reader) *preprocess.Structure {
 		}
 	}
 
-	return preprocess.MakeStructure(
+	str := preprocess.MakeStructure(
 		metadata,
 		structure.MakeNodesById(nodes),
 		bars,
 		includesOwnWeight,
-	).SetDofsCount(numberOfDof) // TODO: should read the DOFs from the file, not reassign them
+	).
+		SetDofsCount(numberOfDof). // TODO: should read the DOFs from the file, not reassign them
+		SetDofNumbering(dofNumbering)
+	str.SetLoadCombinations(combinations)
+	str.SetNodalMasses(nodalMasses)
+	str.EnsureNodalMassesNodes(nodes)
+
+	return str
 }
 
 func extractNumberOfDof(linesReader *inkio.LinesReader) int {
@@ -125,3 +147,24 @@ func extractIncludesOwnWeight(linesReader *inkio.LinesReader) bool {
 
 	panic("Preprocessed file without 'includes_own_weight' set")
 }
+
+// extractDofNumbering reads the degrees of freedom numbering, returning whether there's a line
+// read after it. Files written before the numbering was saved don't include it: their numbering
+// is the geometric one, and the line read is the first of the sections.
+func extractDofNumbering(linesReader *inkio.LinesReader) (preprocess.DofNumbering, bool) {
+	if !linesReader.ReadNext() {
+		return preprocess.GeometricNumbering, false
+	}
+
+	line := linesReader.GetNextLine()
+	if numberingRegex.MatchString(line) {
+		numbering, err := preprocess.ParseDofNumbering(numberingRegex.FindStringSubmatch(line)[1])
+		if err != nil {
+			panic(fmt.Sprintf("Can't read the degrees of freedom numbering from '%s': %s", line, err))
+		}
+
+		return numbering, linesReader.ReadNext()
+	}
+
+	return preprocess.GeometricNumbering, true
+}
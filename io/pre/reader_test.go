@@ -1,9 +1,11 @@
 package pre
 
 import (
+	"strings"
 	"testing"
 
 	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,6 +30,10 @@ func TestReadPreprocessModel(t *testing.T) {
 		assert.Equal(t, 9, str.DofsCount())
 	})
 
+	t.Run("parses the degrees of freedom numbering", func(t *testing.T) {
+		assert.Equal(t, preprocess.GeometricNumbering, str.DofNumbering())
+	})
+
 	t.Run("parses the nodes", func(t *testing.T) {
 		var (
 			wantN1 = wantStr.GetNodeById("n1")
@@ -58,3 +64,54 @@ func TestReadPreprocessModel(t *testing.T) {
 		assert.True(t, wantBar.Equals(str.GetElementById("b1")))
 	})
 }
+
+func TestReadPreprocessModelWithoutDofNumbering(t *testing.T) {
+	var (
+		// Files written before the numbering was saved don't include it
+		reader = strings.NewReader(`inkfem v2.3
+
+		dof_count: 6
+		includes_own_weight: no
+
+		|nodes|
+		n1 -> 0.000000 0.000000 { dx dy rz } | [0 1 2]
+		n2 -> 200.000000 0.000000 { } | [3 4 5]
+
+		|materials|
+		'mat_yz' -> 1.000000 2.000000 3.000000 4.000000 5.000000 6.000000
+
+		|sections|
+		'sec_xy' -> 1.000000 2.000000 3.000000 4.000000 5.000000
+
+		|bars|
+		b1 -> n1 { dx dy rz } n2 { dx dy rz } 'mat_yz' 'sec_xy' >> 2
+		0.000000 : 0.000000 0.000000
+						ext   : {0.000000 0.000000 0.000000}
+						left  : {0.000000 0.000000 0.000000}
+						right : {0.000000 0.000000 0.000000}
+						net   : {0.000000 0.000000 0.000000}
+						dof   : [0 1 2]
+		1.000000 : 200.000000 0.000000
+						ext   : {0.000000 0.000000 0.000000}
+						left  : {0.000000 0.000000 0.000000}
+						right : {0.000000 0.000000 0.000000}
+						net   : {0.000000 0.000000 0.000000}
+						dof   : [3 4 5]
+		`)
+		str = Read(reader)
+	)
+
+	t.Run("uses the geometric degrees of freedom numbering", func(t *testing.T) {
+		assert.Equal(t, preprocess.GeometricNumbering, str.DofNumbering())
+	})
+
+	t.Run("parses the nodes", func(t *testing.T) {
+		assert.Equal(t, 2, str.NodesCount())
+		assert.Equal(t, [3]int{0, 1, 2}, str.GetNodeById("n1").DegreesOfFreedomNum())
+	})
+
+	t.Run("parses the bars", func(t *testing.T) {
+		assert.Equal(t, 6, str.DofsCount())
+		assert.Equal(t, 2, str.GetElementById("b1").NodesCount())
+	})
+}
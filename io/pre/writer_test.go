@@ -14,7 +14,7 @@ func TestWritePreprocessedStructure(t *testing.T) {
 	var (
 		str             = inkio.MakeTestPreprocessedStructure()
 		writer          bytes.Buffer
-		nodesOffset     = 3
+		nodesOffset     = 4
 		materialsOffset = nodesOffset + 3
 		sectionsOffset  = materialsOffset + 2
 		barsOffset      = sectionsOffset + 2
@@ -42,6 +42,10 @@ func TestWritePreprocessedStructure(t *testing.T) {
 		assert.Equal(t, "includes_own_weight: no", gotLines[2])
 	})
 
+	t.Run("the fourth line is the degrees of freedom numbering", func(t *testing.T) {
+		assert.Equal(t, "dof_numbering: geometric", gotLines[3])
+	})
+
 	t.Run("then go the original nodes", func(t *testing.T) {
 		var (
 			wantHeader         = "|nodes|"
@@ -0,0 +1,28 @@
+package pushover
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"text/template"
+
+	"github.com/angelsolaorbaiceta/inkfem/process"
+)
+
+//go:embed pushover.template.txt
+var pushoverTemplateBytes []byte
+
+// Write writes the result of the plastic hinge analysis to the passed in writer: the collapse
+// load factor and, for each plastic hinge in the order they form, its element and position, the
+// load factor and bending moment when it forms, followed by the global displacements
+// {dx, dy, rz} of each of the path nodes.
+func Write(solution *process.PushoverSolution, writer io.Writer) {
+	var (
+		funcs      = template.FuncMap{"inc": func(i int) int { return i + 1 }}
+		tmpl       = template.Must(template.New("pushover").Funcs(funcs).Parse(string(pushoverTemplateBytes)))
+		buffWriter = bufio.NewWriter(writer)
+	)
+
+	tmpl.Execute(buffWriter, solution)
+	buffWriter.Flush()
+}
@@ -0,0 +1,55 @@
+package pushover
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePushover(t *testing.T) {
+	var (
+		writer   bytes.Buffer
+		solution = &process.PushoverSolution{
+			Metadata:       structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+			LoadCase:       "default",
+			CollapseFactor: 2.5,
+			PathNodes:      []contracts.StrID{"n2"},
+			Hinges: []*process.PlasticHinge{
+				{
+					ElementID:     "b1",
+					T:             nums.MinT,
+					LoadFactor:    2.0,
+					Moment:        -1250,
+					Displacements: map[contracts.StrID][3]float64{"n2": {0.1, -0.2, 0.003}},
+				},
+				{
+					ElementID:     "b2",
+					T:             nums.MakeTParam(0.5),
+					LoadFactor:    2.5,
+					Moment:        1250,
+					Displacements: map[contracts.StrID][3]float64{"n2": {0.25, -0.5, 0.0075}},
+				},
+			},
+		}
+		want = `inkfem v2.3
+analysis: pushover
+load_case: default
+collapse_factor: 2.500000
+
+|hinges|
+hinge 1 -> b1 0.000000 {factor=2.000000 moment=-1250}
+n2 -> 0.1 -0.2 0.003
+hinge 2 -> b2 0.500000 {factor=2.500000 moment=1250}
+n2 -> 0.25 -0.5 0.0075
+`
+	)
+
+	Write(solution, &writer)
+
+	assert.Equal(t, want, writer.String())
+}
@@ -0,0 +1,54 @@
+package record
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+var pointRegex = regexp.MustCompile(
+	"^" + inkio.FloatGroupExpr("x") + `(?:\s*,\s*|\s+)` + inkio.FloatGroupExpr("y") + "$",
+)
+
+// Read parses a time function, like a load factor or a ground acceleration record, from a plain
+// text file. Each line has a point of the function: its time and value, separated by blank space
+// or a comma. Blank lines and those starting with "#" are ignored.
+//
+// Panics if a line isn't a point, or the times aren't increasing.
+func Read(reader io.Reader) *load.TimeFunction {
+	times, values := readPoints(reader, "time function")
+	return load.MakeTimeFunction(times, values)
+}
+
+// ReadSpectrum parses a response spectrum from a plain text file. Each line has a point of the
+// spectrum: its period and spectral acceleration, with the same format as the time functions
+// (see Read).
+//
+// Panics if a line isn't a point, or the periods aren't increasing.
+func ReadSpectrum(reader io.Reader) *load.ResponseSpectrum {
+	periods, accelerations := readPoints(reader, "response spectrum")
+	return load.MakeResponseSpectrum(periods, accelerations)
+}
+
+func readPoints(reader io.Reader, context string) (xs, ys []float64) {
+	linesReader := inkio.MakeLinesReader(reader)
+
+	for linesReader.ReadNext() {
+		line := linesReader.GetNextLine()
+
+		if !pointRegex.MatchString(line) {
+			panic(fmt.Sprintf(
+				"Invalid %s point in line %d: '%s'", context, linesReader.GetNextLineNumber(), line,
+			))
+		}
+
+		groups := inkio.ExtractNamedGroups(pointRegex, line)
+		xs = append(xs, inkio.EnsureParseFloat(groups["x"], context+" point"))
+		ys = append(ys, inkio.EnsureParseFloat(groups["y"], context+" point"))
+	}
+
+	return xs, ys
+}
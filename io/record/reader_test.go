@@ -0,0 +1,37 @@
+package record
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTimeFunction(t *testing.T) {
+	t.Run("reads the points separated by spaces or commas", func(t *testing.T) {
+		var (
+			reader = strings.NewReader("# time accel\n0.0 0.0\n\n0.01, 1.5e-1\n0.02\t-0.3\n")
+			record = Read(reader)
+		)
+
+		assert.Equal(t, 3, record.PointsCount())
+		assert.Equal(t, 0.02, record.Duration())
+		assert.InDelta(t, 0.15, record.ValueAt(0.01), 1e-10)
+		assert.InDelta(t, -0.3, record.ValueAt(0.02), 1e-10)
+	})
+
+	t.Run("panics with an invalid line", func(t *testing.T) {
+		assert.Panics(t, func() { Read(strings.NewReader("0.0 0.0\n0.01\n")) })
+	})
+}
+
+func TestReadResponseSpectrum(t *testing.T) {
+	var (
+		reader   = strings.NewReader("# period accel\n0.0 2.0\n0.1 5.0\n0.5 5.0\n2.0 1.25\n")
+		spectrum = ReadSpectrum(reader)
+	)
+
+	assert.Equal(t, 4, spectrum.PointsCount())
+	assert.InDelta(t, 3.5, spectrum.AccelerationAt(0.05), 1e-10)
+	assert.InDelta(t, 1.25, spectrum.AccelerationAt(3.0), 1e-10)
+}
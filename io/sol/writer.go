@@ -7,18 +7,34 @@ import (
 	"text/template"
 
 	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
 )
 
 //go:embed solution.template.txt
 var solutionTemplateBytes []byte
 
-// Write writes the solution of a structure to the passed in writer.
-func Write(solution *process.Solution, writer io.Writer) {
+// Write writes the solutions of a structure to the passed in writer.
+// The solution of each load case or combination is written in its own block, preceded by the
+// load case name after the "@" symbol. The elements envelopes, if any, go last.
+func Write(
+	solutions []*process.Solution,
+	envelopes []*process.ElementEnvelope,
+	writer io.Writer,
+) {
 	var (
 		tmpl       = template.Must(template.New("solution").Parse(string(solutionTemplateBytes)))
 		buffWriter = bufio.NewWriter(writer)
+		data       = struct {
+			Metadata  structure.StrMetadata
+			Solutions []*process.Solution
+			Envelopes []*process.ElementEnvelope
+		}{
+			Metadata:  solutions[0].Metadata,
+			Solutions: solutions,
+			Envelopes: envelopes,
+		}
 	)
 
-	tmpl.Execute(buffWriter, solution)
+	tmpl.Execute(buffWriter, data)
 	buffWriter.Flush()
 }
@@ -7,7 +7,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
 	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestWriteSolution(t *testing.T) {
@@ -15,7 +19,8 @@ func TestWriteSolution(t *testing.T) {
 		sol    = inkio.MakeTestSolution()
 		writer bytes.Buffer
 
-		reactionsOffset  = 1
+		loadCaseOffset   = 1
+		reactionsOffset  = loadCaseOffset + 1
 		barsOffset       = reactionsOffset + 2
 		gdxOffset        = barsOffset + 2
 		gdyOffset        = gdxOffset + 4
@@ -23,13 +28,14 @@ func TestWriteSolution(t *testing.T) {
 		ldxOffset        = grzOffset + 4
 		ldyOffset        = ldxOffset + 4
 		lrzOffset        = ldyOffset + 4
-		axialOffset      = lrzOffset + 4
+		maxLdyOffset     = lrzOffset + 4
+		axialOffset      = maxLdyOffset + 2
 		shearOffset      = axialOffset + 5
 		bendingOffset    = shearOffset + 5
 		bendStressOffset = bendingOffset + 5
 	)
 
-	Write(sol, &writer)
+	Write([]*process.Solution{sol}, nil, &writer)
 	var gotLines []string
 	for _, line := range strings.Split(writer.String(), "\n") {
 		if line != "" {
@@ -48,6 +54,14 @@ func TestWriteSolution(t *testing.T) {
 		}
 	})
 
+	t.Run("then goes the load case", func(t *testing.T) {
+		want := "@default"
+
+		if got := gotLines[loadCaseOffset]; got != want {
+			t.Errorf("Want '%s', got '%s'", want, got)
+		}
+	})
+
 	t.Run("then go the node reactions", func(t *testing.T) {
 		var (
 			wantHeader       = "|reactions|"
@@ -167,6 +181,22 @@ func TestWriteSolution(t *testing.T) {
 		}
 	})
 
+	t.Run("each bar has its maximum local Y displacement", func(t *testing.T) {
+		var (
+			maxDeflection = sol.Elements[0].MaxDeflection()
+			wantMaxLdy    = []string{
+				"__max_ldy__",
+				maxDeflection.String(),
+			}
+		)
+
+		for i := 0; i < len(wantMaxLdy); i++ {
+			if got := gotLines[maxLdyOffset+i]; got != wantMaxLdy[i] {
+				t.Errorf("Want '%s', got '%s'", wantMaxLdy[i], got)
+			}
+		}
+	})
+
 	t.Run("each bar has local axial forces", func(t *testing.T) {
 		wantAxial := []string{
 			"__axial__",
@@ -231,3 +261,119 @@ func TestWriteSolution(t *testing.T) {
 		}
 	})
 }
+
+func TestWriteCombinationsSolution(t *testing.T) {
+	var (
+		sol         = inkio.MakeTestSolution()
+		combination = load.MakeCombination("ULS1", load.CombinationTerm{Factor: 1.5, LoadCase: "default"})
+		combined    = process.CombineSolutions([]*process.Solution{sol}, []*load.Combination{combination})
+		envelopes   = process.MakeEnvelopes(combined)
+		writer      bytes.Buffer
+	)
+
+	Write(append([]*process.Solution{sol}, combined...), envelopes, &writer)
+	lines := strings.Split(writer.String(), "\n")
+
+	t.Run("the combination block header includes its definition", func(t *testing.T) {
+		assert.Contains(t, lines, "@ULS1 -> 1.5 default")
+	})
+
+	t.Run("the envelopes go last", func(t *testing.T) {
+		var envelopesIdx int
+		for i, line := range lines {
+			if line == "|envelopes|" {
+				envelopesIdx = i
+			}
+		}
+
+		assert.Greater(t, envelopesIdx, 0)
+		assert.Equal(t, "b1", lines[envelopesIdx+1])
+		assert.Equal(t, "__axial__", lines[envelopesIdx+2])
+		assert.Regexp(t, `^0\.0+ : -?[\d\.]+ ULS1 -?[\d\.]+ ULS1$`, lines[envelopesIdx+3])
+	})
+}
+
+func TestWriteSolutionWithInactiveBars(t *testing.T) {
+	var (
+		sol    = inkio.MakeTestSolution()
+		writer bytes.Buffer
+	)
+
+	sol.InactiveElements = []contracts.StrID{"b1"}
+	Write([]*process.Solution{sol}, nil, &writer)
+	lines := strings.Split(writer.String(), "\n")
+
+	t.Run("the inactive bars go before the bars", func(t *testing.T) {
+		var inactiveIdx, barsIdx int
+		for i, line := range lines {
+			switch line {
+			case "|inactive_bars|":
+				inactiveIdx = i
+			case "|bars|":
+				barsIdx = i
+			}
+		}
+
+		assert.Greater(t, inactiveIdx, 0)
+		assert.Equal(t, "b1", lines[inactiveIdx+1])
+		assert.Greater(t, barsIdx, inactiveIdx)
+	})
+
+	t.Run("there's no inactive bars section if all bars are active", func(t *testing.T) {
+		var (
+			active       = inkio.MakeTestSolution()
+			activeWriter bytes.Buffer
+		)
+
+		Write([]*process.Solution{active}, nil, &activeWriter)
+		assert.NotContains(t, activeWriter.String(), "|inactive_bars|")
+	})
+}
+
+func TestWriteSolutionWithEquilibrium(t *testing.T) {
+	var (
+		sol    = inkio.MakeTestSolution()
+		writer bytes.Buffer
+	)
+
+	sol.Equilibrium = process.CheckEquilibrium(sol, &process.GlobalDisplacementsVector{}, 1e-4)
+	Write([]*process.Solution{sol}, nil, &writer)
+	lines := strings.Split(writer.String(), "\n")
+
+	t.Run("the equilibrium goes before the bars", func(t *testing.T) {
+		var equilibriumIdx, barsIdx int
+		for i, line := range lines {
+			switch line {
+			case "|equilibrium|":
+				equilibriumIdx = i
+			case "|bars|":
+				barsIdx = i
+			}
+		}
+
+		assert.Greater(t, equilibriumIdx, 0)
+		assert.Greater(t, barsIdx, equilibriumIdx)
+
+		var (
+			number = `-?\d\.\d+e[+-]\d+`
+			torsor = number + " " + number + " " + number
+		)
+
+		assert.Regexp(t, "^applied -> "+torsor+"$", lines[equilibriumIdx+1])
+		assert.Regexp(t, "^reactions -> "+torsor+"$", lines[equilibriumIdx+2])
+		assert.Regexp(t, "^imbalance -> "+torsor+" : "+number+" "+number+"$", lines[equilibriumIdx+3])
+		assert.Regexp(t, "^residual -> "+number+" : "+number+"$", lines[equilibriumIdx+4])
+		assert.Regexp(t, "^max_node_imbalance -> n\\d "+torsor+" : "+number+"$", lines[equilibriumIdx+5])
+		assert.Regexp(t, "^tolerance -> 1.000000e-04 (ok|exceeded)$", lines[equilibriumIdx+6])
+	})
+
+	t.Run("there's no equilibrium section without the verification", func(t *testing.T) {
+		var (
+			unverified       = inkio.MakeTestSolution()
+			unverifiedWriter bytes.Buffer
+		)
+
+		Write([]*process.Solution{unverified}, nil, &unverifiedWriter)
+		assert.NotContains(t, unverifiedWriter.String(), "|equilibrium|")
+	})
+}
@@ -0,0 +1,30 @@
+package spectrum
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"text/template"
+
+	"github.com/angelsolaorbaiceta/inkfem/process"
+)
+
+//go:embed spectrum.template.txt
+var spectrumTemplateBytes []byte
+
+// Write writes the response spectrum solution to the passed in writer.
+//
+// The modes table includes, for each mode, its period, spectral acceleration, participation
+// factor, mass ratio and the factor scaling its shape into the peak displacements. Then, the
+// combination of the modes using each rule is written in its own block, preceded by the rule name
+// after the "@" symbol: the reactions {fx, fy, mz} and the elements' envelopes.
+func Write(solution *process.SpectrumSolution, writer io.Writer) {
+	var (
+		funcs      = template.FuncMap{"inc": func(i int) int { return i + 1 }}
+		tmpl       = template.Must(template.New("spectrum").Funcs(funcs).Parse(string(spectrumTemplateBytes)))
+		buffWriter = bufio.NewWriter(writer)
+	)
+
+	tmpl.Execute(buffWriter, solution)
+	buffWriter.Flush()
+}
@@ -0,0 +1,89 @@
+package spectrum
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	inkio "github.com/angelsolaorbaiceta/inkfem/io"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSpectrum(t *testing.T) {
+	var (
+		writer   bytes.Buffer
+		element  = inkio.MakeTestPreprocessedStructure().Elements()[0]
+		solution = &process.SpectrumSolution{
+			Metadata:  structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+			Direction: process.SpectrumX,
+			Modes: []*process.SpectrumModeResponse{
+				{
+					VibrationMode:        &process.VibrationMode{Period: 0.5},
+					ParticipationFactor:  1.25,
+					MassRatio:            0.75,
+					SpectralAcceleration: 200,
+					DisplacementFactor:   0.5,
+				},
+				{
+					VibrationMode:        &process.VibrationMode{Period: 0.25},
+					ParticipationFactor:  -0.5,
+					MassRatio:            0.125,
+					SpectralAcceleration: 100,
+					DisplacementFactor:   -0.25,
+				},
+			},
+			Combinations: []*process.SpectrumCombination{
+				{
+					Rule:          process.SRSSRule,
+					ReactionNodes: []contracts.StrID{"n1"},
+					Reactions:     map[contracts.StrID][3]float64{"n1": {10, 20, 300}},
+					Envelopes: []*process.ElementEnvelope{
+						{
+							Element: element,
+							AxialStress: []process.EnvelopeValue{
+								{T: nums.MinT, Max: 1, MaxCombination: "srss", Min: -1, MinCombination: "srss"},
+							},
+							ShearForce: []process.EnvelopeValue{
+								{T: nums.MinT, Max: 2, MaxCombination: "srss", Min: -2, MinCombination: "srss"},
+							},
+							BendingMoment: []process.EnvelopeValue{
+								{T: nums.MaxT, Max: 3, MaxCombination: "srss", Min: -3, MinCombination: "srss"},
+							},
+						},
+					},
+				},
+			},
+		}
+		want = `inkfem v2.3
+analysis: spectrum
+direction: x
+mass_ratio: 0.875
+
+|modes|
+mode 1 -> 0.5 200 1.25 0.75 0.5
+mode 2 -> 0.25 100 -0.5 0.125 -0.25
+
+@srss
+
+|reactions|
+n1 -> 10 20 300
+
+|envelopes|
+b1
+__axial__
+0.000000 : 1.000000 srss -1.000000 srss
+__shear__
+0.000000 : 2.000000 srss -2.000000 srss
+__bend__
+1.000000 : 3.000000 srss -3.000000 srss
+
+`
+	)
+
+	Write(solution, &writer)
+
+	assert.Equal(t, want, writer.String())
+}
@@ -127,8 +127,10 @@ func MakeTestSolution() *process.Solution {
 			MajorVersion: 2,
 			MinorVersion: 3,
 		},
+		load.DefaultCase,
 		preStructure.NodesById,
 		[]*process.ElementSolution{solElement},
+		displacements,
 	)
 }
 
@@ -137,6 +139,7 @@ func MakeTestPreprocessedReader() io.Reader {
 	
 	dof_count: 9
 	includes_own_weight: no
+	dof_numbering: geometric
 	
 	|nodes|
 	n1 -> 0.000000 0.000000 { dx dy rz } | [0 1 2]
@@ -22,11 +22,29 @@ var (
 	assembleSystemStartTime   time.Time
 	assembleSystemElapsedTime time.Duration
 
+	factorizeSystemStartTime   time.Time
+	factorizeSystemElapsedTime time.Duration
+
+	preconditionerStartTime   time.Time
+	preconditionerElapsedTime time.Duration
+
 	solveSystemStartTime   time.Time
 	solveSystemElapsedTime time.Duration
 
 	computeStressesStartTime time.Time
 	computeStressesEndTime   time.Duration
+
+	solveEigenStartTime   time.Time
+	solveEigenElapsedTime time.Duration
+
+	timeHistoryStartTime   time.Time
+	timeHistoryElapsedTime time.Duration
+
+	pushoverStartTime   time.Time
+	pushoverElapsedTime time.Duration
+
+	influenceStartTime   time.Time
+	influenceElapsedTime time.Duration
 )
 
 // SetVerbosity sets the verbosity flag value.
@@ -100,6 +118,42 @@ func EndAssembleSysEqs(sysSize int) {
 	}
 }
 
+// StartFactorizeSysEqs should be called when the structure's system of equations matrix is
+// about to be factorized by a direct solver.
+func StartFactorizeSysEqs() {
+	if isVerbose {
+		factorizeSystemStartTime = time.Now()
+	}
+}
+
+// EndFactorizeSysEqs should be called when the structure's system of equations matrix has
+// been factorized. The profile size is the number of terms stored in the factorization.
+func EndFactorizeSysEqs(profileSize int) {
+	if isVerbose {
+		factorizeSystemElapsedTime = time.Since(factorizeSystemStartTime)
+		message := fmt.Sprintf("factorized system matrix (profile of %d terms)", profileSize)
+		writeDone(message, factorizeSystemElapsedTime)
+	}
+}
+
+// StartComputePreconditioner should be called when the preconditioner of the structure's
+// system of equations matrix is about to be computed.
+func StartComputePreconditioner() {
+	if isVerbose {
+		preconditionerStartTime = time.Now()
+	}
+}
+
+// EndComputePreconditioner should be called when the preconditioner of the structure's system
+// of equations matrix has been computed.
+func EndComputePreconditioner(name string) {
+	if isVerbose {
+		preconditionerElapsedTime = time.Since(preconditionerStartTime)
+		message := fmt.Sprintf("computed %s preconditioner", name)
+		writeDone(message, preconditionerElapsedTime)
+	}
+}
+
 // StartSolveSysEqs should be called when the structure's system of equations is about
 // to be solved.
 func StartSolveSysEqs() {
@@ -118,14 +172,128 @@ func SolveSysProgress(progress lineq.IterativeSolverProgress) {
 	}
 }
 
-// EndSolveSysEqs should be called when the structure's system of equations has been solved.
-func EndSolveSysEqs(iterations int, minError float64) {
+// EndSolveSysEqs should be called when the structure's system of equations has been solved
+// for the loads in the given load case. The elapsed times of all the load cases are added.
+func EndSolveSysEqs(loadCase string, iterations int, minError float64) {
 	if isVerbose {
-		solveSystemElapsedTime = time.Since(solveSystemStartTime)
+		elapsedTime := time.Since(solveSystemStartTime)
+		solveSystemElapsedTime += elapsedTime
 		message := fmt.Sprintf(
-			"solved system of equations in %d iterations, error = %f", iterations, minError,
+			"solved system of equations for \"%s\" in %d iterations, error = %f",
+			loadCase, iterations, minError,
+		)
+		writeDone(message, elapsedTime)
+	}
+}
+
+// EndDirectSolveSysEqs should be called when the structure's system of equations has been
+// solved using a factorization of its matrix. The residual is the maximum absolute value of
+// the system's residual vector. The elapsed times of all the load cases are added.
+func EndDirectSolveSysEqs(loadCase string, residual float64) {
+	if isVerbose {
+		elapsedTime := time.Since(solveSystemStartTime)
+		solveSystemElapsedTime += elapsedTime
+		message := fmt.Sprintf(
+			"solved system of equations for \"%s\" by substitution, residual = %e",
+			loadCase, residual,
+		)
+		writeDone(message, elapsedTime)
+	}
+}
+
+// SecondOrderProgress should be called at each iteration of the second-order analysis of the
+// given load case, with the relative change of the displacements.
+func SecondOrderProgress(loadCase string, iteration int, change float64) {
+	if isVerbose {
+		log.Printf(
+			"[second order] \"%s\", iteration %3d, displacements change ~ %e\n",
+			loadCase, iteration, change,
+		)
+	}
+}
+
+// EndSecondOrder should be called when the second-order analysis of the given load case has
+// converged after the given number of iterations.
+func EndSecondOrder(loadCase string, iterations int) {
+	if isVerbose {
+		log.Printf("[second order] \"%s\" converged in %d iterations\n", loadCase, iterations)
+	}
+}
+
+// SecondOrderAmplification should be called with the largest amplification of the bending
+// moments in the second-order solution of the given load case, and the element where it happens.
+func SecondOrderAmplification(loadCase, elementID string, factor float64) {
+	if isVerbose {
+		log.Printf(
+			"[second order] \"%s\" largest moment amplification = %f (bar %s)\n",
+			loadCase, factor, elementID,
+		)
+	}
+}
+
+// ActiveSetProgress should be called at each iteration of the analysis of the given load case
+// with tension-only or compression-only elements, with the number of inactive elements.
+func ActiveSetProgress(loadCase string, iteration, inactiveCount int) {
+	if isVerbose {
+		log.Printf(
+			"[active set] \"%s\", iteration %3d, %d inactive bars\n",
+			loadCase, iteration, inactiveCount,
+		)
+	}
+}
+
+// EndActiveSet should be called when the set of inactive elements of the given load case is
+// stable after the given number of iterations.
+func EndActiveSet(loadCase string, iterations, inactiveCount int) {
+	if isVerbose {
+		log.Printf(
+			"[active set] \"%s\" stable in %d iterations, %d inactive bars\n",
+			loadCase, iterations, inactiveCount,
+		)
+	}
+}
+
+// Equilibrium should be called when the equilibrium of the solution of the given load case has
+// been verified, with the relative imbalances of the forces and moments, the relative residual
+// of the system of equations, and the node with the largest relative imbalance.
+func Equilibrium(
+	loadCase string,
+	forceImbalance, momentImbalance, residual float64,
+	nodeID string,
+	nodeImbalance float64,
+	isWithinTolerance bool,
+) {
+	if isVerbose {
+		status := "ok"
+		if !isWithinTolerance {
+			status = "TOLERANCE EXCEEDED"
+		}
+
+		log.Printf(
+			"[equilibrium] \"%s\" force = %e, moment = %e, residual = %e, node %s = %e: %s\n",
+			loadCase, forceImbalance, momentImbalance, residual, nodeID, nodeImbalance, status,
+		)
+	}
+}
+
+// NonlinearIteration should be called at each Newton–Raphson iteration of the nonlinear analysis,
+// with the largest residual force relative to the applied loads.
+func NonlinearIteration(step, iteration int, residual float64) {
+	if isVerbose {
+		log.Printf(
+			"[nonlinear] increment %3d, iteration %3d, residual ~ %e\n", step, iteration, residual,
+		)
+	}
+}
+
+// EndNonlinearStep should be called when the equilibrium of a load increment of the nonlinear
+// analysis has been found after the given number of iterations.
+func EndNonlinearStep(step int, loadFactor float64, iterations int) {
+	if isVerbose {
+		log.Printf(
+			"[nonlinear] increment %3d converged: load factor = %f, %d iterations\n",
+			step, loadFactor, iterations,
 		)
-		writeDone(message, solveSystemElapsedTime)
 	}
 }
 
@@ -137,11 +305,93 @@ func StartComputeStresses() {
 	}
 }
 
-// EndComputeStresses should be called when the stresses on all elements have been computed.
-func EndComputeStresses() {
+// EndComputeStresses should be called when the stresses on all elements have been computed
+// for the given load case. The elapsed times of all the load cases are added.
+func EndComputeStresses(loadCase string) {
+	if isVerbose {
+		elapsedTime := time.Since(computeStressesStartTime)
+		computeStressesEndTime += elapsedTime
+		writeDone(fmt.Sprintf("computed stresses for all elements in \"%s\"", loadCase), elapsedTime)
+	}
+}
+
+// StartSolveEigenproblem should be called when the structure's eigenvalue problem is about to
+// be solved.
+func StartSolveEigenproblem() {
+	if isVerbose {
+		solveEigenStartTime = time.Now()
+	}
+}
+
+// EndSolveEigenproblem should be called when the structure's eigenvalue problem, with the given
+// description, has been solved yielding the given number of modes.
+func EndSolveEigenproblem(description string, modesCount int) {
+	if isVerbose {
+		solveEigenElapsedTime = time.Since(solveEigenStartTime)
+		message := fmt.Sprintf("solved %s eigenvalue problem (%d modes)", description, modesCount)
+		writeDone(message, solveEigenElapsedTime)
+	}
+}
+
+// StartTimeHistory should be called when the time integration of the equations of motion is
+// about to start.
+func StartTimeHistory() {
+	if isVerbose {
+		timeHistoryStartTime = time.Now()
+	}
+}
+
+// EndTimeHistory should be called when the equations of motion have been integrated in the given
+// number of time steps.
+func EndTimeHistory(stepsCount int, timeStep float64) {
+	if isVerbose {
+		timeHistoryElapsedTime = time.Since(timeHistoryStartTime)
+		message := fmt.Sprintf("integrated %d time steps of %g", stepsCount, timeStep)
+		writeDone(message, timeHistoryElapsedTime)
+	}
+}
+
+// StartPushover should be called when the incremental plastic hinge analysis is about to start.
+func StartPushover() {
+	if isVerbose {
+		pushoverStartTime = time.Now()
+	}
+}
+
+// PushoverHinge should be called when the plastic hinge with the given number forms in the
+// element, at the given position and load factor.
+func PushoverHinge(hinge int, elementID string, t, loadFactor float64) {
+	if isVerbose {
+		log.Printf(
+			"[pushover] hinge %3d in %s at t = %f: load factor = %f\n", hinge, elementID, t, loadFactor,
+		)
+	}
+}
+
+// EndPushover should be called when the plastic hinges have turned the structure into a mechanism
+// at the given collapse load factor.
+func EndPushover(hingesCount int, collapseFactor float64) {
+	if isVerbose {
+		pushoverElapsedTime = time.Since(pushoverStartTime)
+		message := fmt.Sprintf("formed %d plastic hinges, collapse load factor = %f", hingesCount, collapseFactor)
+		writeDone(message, pushoverElapsedTime)
+	}
+}
+
+// StartInfluenceLines should be called when the influence lines are about to be computed.
+func StartInfluenceLines() {
+	if isVerbose {
+		influenceStartTime = time.Now()
+	}
+}
+
+// EndInfluenceLines should be called when the given number of influence lines have been computed,
+// each with the given number of points.
+func EndInfluenceLines(linesCount, pointsCount int) {
 	if isVerbose {
-		computeStressesEndTime = time.Since(computeStressesStartTime)
-		writeDone("computed stresses for all elements", computeStressesEndTime)
+		influenceElapsedTime = time.Since(influenceStartTime)
+		message := fmt.Sprintf("computed %d influence lines of %d points", linesCount, pointsCount)
+		writeDone(message, influenceElapsedTime)
 	}
 }
 
@@ -152,8 +402,14 @@ func Result() {
 		totalTime := readFileElapsedTime +
 			preprocessElapsedTime +
 			assembleSystemElapsedTime +
+			factorizeSystemElapsedTime +
+			preconditionerElapsedTime +
 			solveSystemElapsedTime +
-			computeStressesEndTime
+			computeStressesEndTime +
+			solveEigenElapsedTime +
+			timeHistoryElapsedTime +
+			pushoverElapsedTime +
+			influenceElapsedTime
 
 		log.Printf("Total time: %s\n", totalTime)
 	}
@@ -0,0 +1,355 @@
+package math
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// An EigenPair is an eigenvalue with its associated eigenvector.
+type EigenPair struct {
+	Value  float64
+	Vector vec.ReadOnlyVector
+}
+
+// SubspaceEigenSolver computes the lowest positive eigenvalues, and their eigenvectors, of the
+// generalized eigenvalue problem A·φ = λ·B·φ, where A is a symmetric and positive definite
+// matrix and B a symmetric matrix, not necessarily definite.
+//
+// It uses the subspace iteration method: a subspace of vectors is iterated with the inverse of A
+// (which is factorized only once) and, in each iteration, the Rayleigh–Ritz procedure computes
+// the best approximation to the eigenpairs in the subspace. The iteration converges to the
+// eigenvalues with the smallest absolute value, thus if B is indefinite, the subspace might need
+// to be larger for the lowest positive ones to be found.
+//
+// The returned eigenvectors are normalized with respect to A: φᵀ·A·φ = 1.
+type SubspaceEigenSolver struct {
+	// Count is the number of eigenpairs to compute.
+	Count int
+	// MaxError is the maximum relative change of the eigenvalues between two iterations for them
+	// to be considered converged.
+	MaxError float64
+	// MaxIter is the maximum number of iterations.
+	MaxIter int
+}
+
+// Solve computes the lowest positive eigenpairs of the generalized eigenvalue problem
+// A·φ = λ·B·φ, sorted by increasing eigenvalue. Less than the requested number of eigenpairs
+// might be returned if the problem doesn't have as many positive eigenvalues.
+//
+// An error is returned if A can't be factorized or the iteration doesn't converge.
+func (solver SubspaceEigenSolver) Solve(a, b mat.ReadOnlyMatrix) ([]EigenPair, error) {
+	if solver.Count <= 0 {
+		return []EigenPair{}, nil
+	}
+
+	factorization, err := MakeLDLFactorization(a)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		size          = a.Rows()
+		subspaceSize  = min(size, max(2*solver.Count, solver.Count+8))
+		vectors       = subspaceStartVectors(a, b, subspaceSize)
+		values        []float64
+		previousValue []float64
+	)
+
+	for iter := 0; iter < solver.MaxIter; iter++ {
+		// Inverse iteration: A·X' = B·X
+		iterated := make([]vec.ReadOnlyVector, len(vectors))
+		for j, vector := range vectors {
+			iterated[j] = factorization.Solve(b.TimesVector(vector))
+		}
+
+		iterated = orthonormalizeWithRespectTo(a, iterated)
+		if len(iterated) == 0 {
+			return []EigenPair{}, nil
+		}
+
+		// Rayleigh–Ritz: as the vectors are A-orthonormal, the projected problem is the standard
+		// eigenvalue problem of Xᵀ·B·X, whose eigenvalues are the inverse of λ.
+		var (
+			projected           = projectedMatrix(b, iterated)
+			inverses, rotations = SymmetricEigen(projected)
+		)
+
+		vectors, values = ritzVectors(iterated, inverses, rotations)
+
+		if previousValue != nil && hasConverged(values, previousValue, solver.Count, solver.MaxError) {
+			return positiveEigenPairs(vectors, values, solver.Count), nil
+		}
+
+		previousValue = values
+	}
+
+	return nil, fmt.Errorf("subspace iteration didn't converge after %d iterations", solver.MaxIter)
+}
+
+// subspaceStartVectors computes the starting vectors of the subspace iteration: the first vector
+// is the diagonal of B, and the rest, unit vectors in the positions where the ratio between the
+// diagonal terms of B and A is the largest, to which a small random perturbation is added so
+// that none of them is in the null space of B.
+func subspaceStartVectors(a, b mat.ReadOnlyMatrix, count int) []vec.ReadOnlyVector {
+	var (
+		size    = a.Rows()
+		random  = rand.New(rand.NewSource(1))
+		ratios  = make([]int, size)
+		vectors = make([]vec.ReadOnlyVector, count)
+	)
+
+	for i := range ratios {
+		ratios[i] = i
+	}
+
+	ratio := func(i int) float64 {
+		if diag := math.Abs(a.Value(i, i)); diag > 0 {
+			return math.Abs(b.Value(i, i)) / diag
+		}
+
+		return 0.0
+	}
+	sort.SliceStable(ratios, func(i, j int) bool {
+		return ratio(ratios[i]) > ratio(ratios[j])
+	})
+
+	for j := range vectors {
+		vector := vec.Make(size)
+		for i := 0; i < size; i++ {
+			vector.SetValue(i, 1e-3*(random.Float64()-0.5))
+		}
+
+		if j == 0 {
+			for i := 0; i < size; i++ {
+				vector.SetValue(i, vector.Value(i)+math.Abs(b.Value(i, i)))
+			}
+		} else {
+			position := ratios[(j-1)%size]
+			vector.SetValue(position, vector.Value(position)+1.0)
+		}
+
+		vectors[j] = vector
+	}
+
+	return vectors
+}
+
+// orthonormalizeWithRespectTo applies the modified Gram–Schmidt procedure to the vectors using
+// the inner product defined by the matrix: <u, v> = uᵀ·M·v. The vectors which are linearly
+// dependent on the previous ones are dropped.
+func orthonormalizeWithRespectTo(m mat.ReadOnlyMatrix, vectors []vec.ReadOnlyVector) []vec.ReadOnlyVector {
+	var (
+		orthonormal = make([]vec.ReadOnlyVector, 0, len(vectors))
+		products    = make([]vec.ReadOnlyVector, 0, len(vectors))
+	)
+
+	for _, vector := range vectors {
+		var (
+			originalNorm = math.Sqrt(math.Abs(vector.Times(m.TimesVector(vector))))
+			current      = vector
+		)
+
+		if originalNorm == 0 {
+			continue
+		}
+
+		for k, other := range orthonormal {
+			current = current.Minus(other.Scaled(current.Times(products[k])))
+		}
+
+		var (
+			product = m.TimesVector(current)
+			norm    = math.Sqrt(math.Abs(current.Times(product)))
+		)
+
+		if norm <= 1e-10*originalNorm {
+			continue
+		}
+
+		orthonormal = append(orthonormal, current.Scaled(1.0/norm))
+		products = append(products, product.Scaled(1.0/norm))
+	}
+
+	return orthonormal
+}
+
+// projectedMatrix computes the dense matrix Vᵀ·M·V, where V has the given vectors as columns.
+func projectedMatrix(m mat.ReadOnlyMatrix, vectors []vec.ReadOnlyVector) [][]float64 {
+	var (
+		size      = len(vectors)
+		projected = make([][]float64, size)
+		products  = make([]vec.ReadOnlyVector, size)
+	)
+
+	for j, vector := range vectors {
+		products[j] = m.TimesVector(vector)
+	}
+
+	for i := range projected {
+		projected[i] = make([]float64, size)
+	}
+
+	for i := 0; i < size; i++ {
+		for j := i; j < size; j++ {
+			value := 0.5 * (vectors[i].Times(products[j]) + vectors[j].Times(products[i]))
+			projected[i][j], projected[j][i] = value, value
+		}
+	}
+
+	return projected
+}
+
+// ritzVectors combines the subspace vectors with the eigenvectors of the projected problem,
+// yielding the Ritz vectors, sorted by decreasing eigenvalue of the projected problem (the
+// inverses of λ).
+func ritzVectors(
+	vectors []vec.ReadOnlyVector,
+	inverses []float64,
+	rotations [][]float64,
+) ([]vec.ReadOnlyVector, []float64) {
+	var (
+		count   = len(inverses)
+		order   = make([]int, count)
+		ritz    = make([]vec.ReadOnlyVector, count)
+		values  = make([]float64, count)
+		size    = vectors[0].Length()
+		current vec.MutableVector
+	)
+
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return inverses[order[i]] > inverses[order[j]]
+	})
+
+	for k, col := range order {
+		current = vec.Make(size)
+		for j, vector := range vectors {
+			if factor := rotations[j][col]; factor != 0 {
+				for i := 0; i < size; i++ {
+					current.SetValue(i, current.Value(i)+factor*vector.Value(i))
+				}
+			}
+		}
+
+		ritz[k] = current
+		values[k] = inverses[col]
+	}
+
+	return ritz, values
+}
+
+// hasConverged returns true if the first count values haven't changed more than the maximum
+// relative error with respect to the previous iteration.
+func hasConverged(values, previous []float64, count int, maxError float64) bool {
+	if len(values) != len(previous) {
+		return false
+	}
+
+	scale := 0.0
+	for _, value := range values {
+		scale = math.Max(scale, math.Abs(value))
+	}
+
+	for i := 0; i < min(count, len(values)); i++ {
+		if math.Abs(values[i]-previous[i]) > maxError*math.Max(math.Abs(values[i]), 1e-12*scale) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// positiveEigenPairs returns up to count eigenpairs for the positive inverse values, which are
+// sorted in decreasing order, thus the eigenvalues are increasing.
+func positiveEigenPairs(vectors []vec.ReadOnlyVector, inverses []float64, count int) []EigenPair {
+	scale := 0.0
+	for _, value := range inverses {
+		scale = math.Max(scale, math.Abs(value))
+	}
+
+	pairs := make([]EigenPair, 0, count)
+	for i, inverse := range inverses {
+		if len(pairs) == count || inverse <= 1e-12*scale {
+			break
+		}
+
+		pairs = append(pairs, EigenPair{Value: 1.0 / inverse, Vector: vectors[i]})
+	}
+
+	return pairs
+}
+
+// SymmetricEigen computes the eigenvalues and eigenvectors of a dense symmetric matrix using the
+// cyclic Jacobi method. The eigenvectors are returned as the columns of the second matrix, in
+// the same order as the eigenvalues.
+//
+// The given matrix isn't modified.
+func SymmetricEigen(matrix [][]float64) ([]float64, [][]float64) {
+	var (
+		size    = len(matrix)
+		a       = make([][]float64, size)
+		vectors = make([][]float64, size)
+		values  = make([]float64, size)
+	)
+
+	for i := range a {
+		a[i] = append([]float64(nil), matrix[i]...)
+		vectors[i] = make([]float64, size)
+		vectors[i][i] = 1.0
+	}
+
+	for sweep := 0; sweep < 100; sweep++ {
+		var offDiagonal, diagonal float64
+		for i := 0; i < size; i++ {
+			diagonal += a[i][i] * a[i][i]
+			for j := i + 1; j < size; j++ {
+				offDiagonal += a[i][j] * a[i][j]
+			}
+		}
+
+		if offDiagonal <= 1e-30*diagonal || offDiagonal == 0 {
+			break
+		}
+
+		for p := 0; p < size; p++ {
+			for q := p + 1; q < size; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+
+				var (
+					theta = (a[q][q] - a[p][p]) / (2.0 * a[p][q])
+					t     = math.Copysign(1.0, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1.0))
+					c     = 1.0 / math.Sqrt(t*t+1.0)
+					s     = t * c
+				)
+
+				for k := 0; k < size; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < size; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				for k := 0; k < size; k++ {
+					vkp, vkq := vectors[k][p], vectors[k][q]
+					vectors[k][p], vectors[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	for i := range values {
+		values[i] = a[i][i]
+	}
+
+	return values, vectors
+}
@@ -0,0 +1,70 @@
+package math
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymmetricEigen(t *testing.T) {
+	var (
+		matrix          = [][]float64{{2, 1}, {1, 2}}
+		values, vectors = SymmetricEigen(matrix)
+	)
+
+	for k, value := range values {
+		for i := range matrix {
+			got := matrix[i][0]*vectors[0][k] + matrix[i][1]*vectors[1][k]
+			assert.True(t, nums.FloatsEqual(value*vectors[i][k], got))
+		}
+	}
+
+	assert.True(t, nums.FloatsEqual(values[0]+values[1], 4.0))
+	assert.True(t, nums.FloatsEqual(values[0]*values[1], 3.0))
+}
+
+func TestSubspaceEigenSolver(t *testing.T) {
+	var (
+		a = mat.MakeSparseWithData(4, 4, []float64{
+			4, -1, 0, 0,
+			-1, 4, -1, 0,
+			0, -1, 4, -1,
+			0, 0, -1, 4,
+		})
+		b = mat.MakeSparseWithData(4, 4, []float64{
+			2, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, -1, 0,
+			0, 0, 0, 1,
+		})
+		solver = SubspaceEigenSolver{Count: 2, MaxError: 1e-10, MaxIter: 100}
+	)
+
+	pairs, err := solver.Solve(a, b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(pairs))
+	assert.True(t, pairs[0].Value > 0 && pairs[0].Value < pairs[1].Value)
+
+	t.Run("the pairs satisfy the eigenvalue problem", func(t *testing.T) {
+		for _, pair := range pairs {
+			var (
+				left  = a.TimesVector(pair.Vector)
+				right = b.TimesVector(pair.Vector).Scaled(pair.Value)
+			)
+
+			for i := 0; i < left.Length(); i++ {
+				assert.True(t, nums.FloatsEqualEps(left.Value(i), right.Value(i), 1e-6))
+			}
+		}
+	})
+
+	t.Run("the eigenvectors are normalized with respect to A", func(t *testing.T) {
+		for _, pair := range pairs {
+			norm := pair.Vector.Times(a.TimesVector(pair.Vector))
+			assert.True(t, nums.FloatsEqualEps(norm, 1.0, 1e-9))
+		}
+	})
+}
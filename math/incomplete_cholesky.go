@@ -0,0 +1,186 @@
+package math
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+const (
+	// icInitialShift is the relative diagonal shift used in the first attempt to factorize a
+	// matrix whose incomplete factorization breaks down due to a non-positive pivot.
+	icInitialShift = 1e-3
+
+	// icMaxShiftAttempts is the number of times the diagonal shift is doubled before giving up.
+	icMaxShiftAttempts = 20
+)
+
+// An IncompleteCholesky preconditioner is an approximate LDLᵀ factorization of a symmetric
+// positive definite matrix, where the terms of L outside of a given sparsity pattern are dropped.
+//
+// The pattern is the one of the original matrix, plus the fill-in terms whose magnitude is
+// relevant with respect to the diagonal:
+//
+//	|lᵢⱼ·dⱼ| ≥ threshold·√(|aᵢᵢ·aⱼⱼ|)
+//
+// With a zero threshold, no fill-in is kept and this is the IC(0) factorization. The smaller the
+// (positive) threshold, the more fill-in terms are kept and the closer the preconditioner is to
+// the complete factorization.
+//
+// If the incomplete factorization breaks down (a non-positive pivot is found), the diagonal of
+// the matrix is increasingly shifted (aᵢᵢ·(1 + α)) until the factorization succeeds.
+type IncompleteCholesky struct {
+	size  int
+	lower []sparseRow
+	diag  []float64
+	shift float64
+}
+
+// MakeIncompleteCholesky computes the incomplete Cholesky factorization of the given symmetric
+// positive definite matrix, keeping the fill-in terms above the given threshold.
+func MakeIncompleteCholesky(m mat.ReadOnlyMatrix, fillInThreshold float64) *IncompleteCholesky {
+	mustBeSquare(m)
+
+	lower, _, diag := splitSparseRows(m)
+
+	if ic, ok := factorizeIncompleteCholesky(lower, diag, fillInThreshold, 0); ok {
+		return ic
+	}
+
+	shift := icInitialShift
+	for attempt := 0; attempt < icMaxShiftAttempts; attempt++ {
+		if ic, ok := factorizeIncompleteCholesky(lower, diag, fillInThreshold, shift); ok {
+			return ic
+		}
+
+		shift *= 2
+	}
+
+	panic("Can't compute the incomplete Cholesky factorization: the matrix isn't positive definite")
+}
+
+// factorizeIncompleteCholesky computes the incomplete factorization row by row, using a dense
+// work row to accumulate the updates of the previous rows. Returns false if the factorization
+// breaks down.
+func factorizeIncompleteCholesky(
+	matLower []sparseRow,
+	matDiag []float64,
+	fillInThreshold float64,
+	shift float64,
+) (*IncompleteCholesky, bool) {
+	var (
+		size      = len(matDiag)
+		lower     = make([]sparseRow, size)
+		diag      = make([]float64, size)
+		shiftDiag = make([]float64, size)
+		// cols[j] are the rows (k > j) with a non-zero term at column j: lₖⱼ
+		cols       = make([]sparseRow, size)
+		work       = make([]float64, size)
+		inPattern  = make([]bool, size)
+		minPivot   = 0.0
+		firstInRow int
+	)
+
+	for i, value := range matDiag {
+		shiftDiag[i] = value * (1 + shift)
+		minPivot = math.Max(minPivot, math.Abs(shiftDiag[i]))
+	}
+	minPivot *= minPivotRatio
+
+	for i := 0; i < size; i++ {
+		row := matLower[i]
+		if len(row.cols) == 0 {
+			firstInRow = i
+		} else {
+			firstInRow = row.cols[0]
+		}
+
+		for k, j := range row.cols {
+			work[j] = row.values[k]
+			inPattern[j] = true
+		}
+
+		pivot := shiftDiag[i]
+		for j := firstInRow; j < i; j++ {
+			value := work[j]
+			if value == 0 {
+				continue
+			}
+
+			work[j] = 0
+			if !inPattern[j] && (fillInThreshold <= 0 ||
+				math.Abs(value) < fillInThreshold*math.Sqrt(math.Abs(shiftDiag[i]*shiftDiag[j]))) {
+				continue
+			}
+
+			l := value / diag[j]
+			pivot -= l * value
+			lower[i].cols = append(lower[i].cols, j)
+			lower[i].values = append(lower[i].values, l)
+
+			for k, row := range cols[j].cols {
+				work[row] -= value * cols[j].values[k]
+			}
+		}
+
+		for _, j := range row.cols {
+			inPattern[j] = false
+		}
+
+		if pivot <= minPivot {
+			return nil, false
+		}
+		diag[i] = pivot
+
+		for k, j := range lower[i].cols {
+			cols[j].cols = append(cols[j].cols, i)
+			cols[j].values = append(cols[j].values, lower[i].values[k])
+		}
+	}
+
+	return &IncompleteCholesky{size, lower, diag, shift}, true
+}
+
+// Shift is the relative diagonal shift that was required to compute the factorization.
+// A zero shift means that the original matrix was factorized.
+func (ic *IncompleteCholesky) Shift() float64 {
+	return ic.shift
+}
+
+// NonZeroCount is the number of non-zero terms in the factorization: the terms of L below the
+// main diagonal plus the pivots.
+func (ic *IncompleteCholesky) NonZeroCount() int {
+	count := ic.size
+	for _, row := range ic.lower {
+		count += len(row.cols)
+	}
+
+	return count
+}
+
+// Apply computes z = (L·D·Lᵀ)⁻¹·r by forward and backward substitution.
+func (ic *IncompleteCholesky) Apply(r vec.ReadOnlyVector) vec.ReadOnlyVector {
+	z := make([]float64, ic.size)
+	for i := range z {
+		z[i] = r.Value(i)
+	}
+
+	for i := 0; i < ic.size; i++ {
+		for k, j := range ic.lower[i].cols {
+			z[i] -= ic.lower[i].values[k] * z[j]
+		}
+	}
+
+	for i := 0; i < ic.size; i++ {
+		z[i] /= ic.diag[i]
+	}
+
+	for i := ic.size - 1; i >= 0; i-- {
+		for k, j := range ic.lower[i].cols {
+			z[j] -= ic.lower[i].values[k] * z[i]
+		}
+	}
+
+	return vec.MakeWithValues(z)
+}
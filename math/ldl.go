@@ -0,0 +1,169 @@
+package math
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// minPivotRatio is the smallest ratio between a pivot and the largest diagonal term of the
+// factorized matrix. Pivots below it are considered zero: the matrix is singular.
+const minPivotRatio = 1e-14
+
+// An LDLFactorization is the LDLᵀ (square root free Cholesky) decomposition of a symmetric
+// matrix, where L is a unit lower triangular matrix and D a diagonal matrix.
+//
+// The lower matrix is stored in skyline (variable band) format: for each row, only the terms
+// between the first non-zero column and the main diagonal are kept. The fill-in of the
+// factorization always happens inside this envelope, so its size (the profile) determines both
+// the memory footprint and the time required to factorize the matrix.
+//
+// Once computed, the factorization can be reused to solve the system for as many free terms
+// vectors as needed.
+type LDLFactorization struct {
+	size  int
+	first []int
+	lower [][]float64
+	diag  []float64
+}
+
+// MakeLDLFactorization computes the LDLᵀ decomposition of the given symmetric matrix.
+// Only the lower triangle of the matrix is read.
+//
+// An error is returned if the matrix isn't square or is singular (a zero pivot is found).
+func MakeLDLFactorization(m mat.ReadOnlyMatrix) (*LDLFactorization, error) {
+	if !mat.IsSquare(m) {
+		return nil, fmt.Errorf("can't factorize a non-square matrix (%dx%d)", m.Rows(), m.Cols())
+	}
+
+	var (
+		size    = m.Rows()
+		first   = make([]int, size)
+		lower   = make([][]float64, size)
+		diag    = make([]float64, size)
+		maxDiag = 0.0
+	)
+
+	// Skyline of the matrix and copy of the lower triangle terms
+	for i := 0; i < size; i++ {
+		first[i] = i
+		for _, j := range m.NonZeroIndicesAtRow(i) {
+			if j < first[i] {
+				first[i] = j
+			}
+		}
+
+		lower[i] = make([]float64, i-first[i])
+		for _, j := range m.NonZeroIndicesAtRow(i) {
+			if j < i {
+				lower[i][j-first[i]] = m.Value(i, j)
+			}
+		}
+
+		diag[i] = m.Value(i, i)
+		maxDiag = math.Max(maxDiag, math.Abs(diag[i]))
+	}
+
+	// Crout's factorization, row by row. The row terms first store the product L·D, which
+	// are then divided by the pivots.
+	for i := 0; i < size; i++ {
+		var (
+			rowI   = lower[i]
+			firstI = first[i]
+		)
+
+		for j := firstI; j < i; j++ {
+			var (
+				rowJ  = lower[j]
+				k0    = max(firstI, first[j])
+				value = rowI[j-firstI]
+			)
+
+			for k := k0; k < j; k++ {
+				value -= rowI[k-firstI] * rowJ[k-first[j]]
+			}
+
+			rowI[j-firstI] = value
+		}
+
+		pivot := diag[i]
+		for j := firstI; j < i; j++ {
+			l := rowI[j-firstI] / diag[j]
+			pivot -= l * rowI[j-firstI]
+			rowI[j-firstI] = l
+		}
+
+		if math.Abs(pivot) <= minPivotRatio*maxDiag {
+			return nil, fmt.Errorf("can't factorize a singular matrix: zero pivot at row %d", i)
+		}
+
+		diag[i] = pivot
+	}
+
+	return &LDLFactorization{size, first, lower, diag}, nil
+}
+
+// Size is the number of rows (and columns) of the factorized matrix.
+func (f *LDLFactorization) Size() int {
+	return f.size
+}
+
+// ProfileSize is the number of terms stored in the skyline of the lower matrix, including
+// the main diagonal.
+func (f *LDLFactorization) ProfileSize() int {
+	profile := f.size
+	for _, row := range f.lower {
+		profile += len(row)
+	}
+
+	return profile
+}
+
+// NegativePivotsCount is the number of negative terms in D. By Sylvester's law of inertia, this
+// is the number of negative eigenvalues of the factorized matrix.
+func (f *LDLFactorization) NegativePivotsCount() int {
+	count := 0
+	for _, pivot := range f.diag {
+		if pivot < 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Solve computes the solution of the factorized system of equations for the given free terms
+// by forward and backward substitution.
+func (f *LDLFactorization) Solve(b vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if b.Length() != f.size {
+		panic(fmt.Sprintf("Can't solve system of size %d for a vector of size %d", f.size, b.Length()))
+	}
+
+	x := make([]float64, f.size)
+	for i := range x {
+		x[i] = b.Value(i)
+	}
+
+	// Forward substitution: L·z = b
+	for i := 0; i < f.size; i++ {
+		for k, l := range f.lower[i] {
+			x[i] -= l * x[f.first[i]+k]
+		}
+	}
+
+	// Diagonal: D·y = z
+	for i := 0; i < f.size; i++ {
+		x[i] /= f.diag[i]
+	}
+
+	// Backward substitution: Lᵀ·x = y
+	for i := f.size - 1; i >= 0; i-- {
+		for k, l := range f.lower[i] {
+			x[f.first[i]+k] -= l * x[i]
+		}
+	}
+
+	return vec.MakeWithValues(x)
+}
@@ -0,0 +1,69 @@
+package math
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLDLFactorization(t *testing.T) {
+	// Symmetric, positive definite, with a zero inside the skyline of the last row.
+	matrix := mat.MakeSparseWithData(4, 4, []float64{
+		4, 1, 0, 0,
+		1, 5, 2, 0,
+		0, 2, 6, 1,
+		0, 0, 1, 3,
+	})
+
+	t.Run("computes the profile of the matrix", func(t *testing.T) {
+		ldl, err := MakeLDLFactorization(matrix)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 4, ldl.Size())
+		assert.Equal(t, 7, ldl.ProfileSize())
+		assert.Equal(t, 0, ldl.NegativePivotsCount())
+	})
+
+	t.Run("solves the system of equations", func(t *testing.T) {
+		var (
+			ldl, _ = MakeLDLFactorization(matrix)
+			want   = vec.MakeWithValues([]float64{1, -2, 3, -4})
+			b      = matrix.TimesVector(want)
+			got    = ldl.Solve(b)
+		)
+
+		for i := 0; i < want.Length(); i++ {
+			assert.True(t, nums.FloatsEqual(want.Value(i), got.Value(i)))
+		}
+	})
+
+	t.Run("the factorization can be reused", func(t *testing.T) {
+		var (
+			ldl, _ = MakeLDLFactorization(matrix)
+			want   = vec.MakeWithValues([]float64{0.5, 0, 0, 7})
+			got    = ldl.Solve(matrix.TimesVector(want))
+		)
+
+		for i := 0; i < want.Length(); i++ {
+			assert.True(t, nums.FloatsEqual(want.Value(i), got.Value(i)))
+		}
+	})
+
+	t.Run("counts the negative pivots of an indefinite matrix", func(t *testing.T) {
+		indefinite := mat.MakeSparseWithData(2, 2, []float64{1, 2, 2, 1})
+		ldl, err := MakeLDLFactorization(indefinite)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, ldl.NegativePivotsCount())
+	})
+
+	t.Run("can't factorize a singular matrix", func(t *testing.T) {
+		singular := mat.MakeSparseWithData(2, 2, []float64{1, 1, 1, 1})
+		_, err := MakeLDLFactorization(singular)
+
+		assert.Error(t, err)
+	})
+}
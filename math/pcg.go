@@ -0,0 +1,126 @@
+package math
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkmath/lineq"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// PCGSolver is an iterative Preconditioned Conjugate Gradient solver for symmetric positive
+// definite systems of equations, where the preconditioner can be any implementation of the
+// Preconditioner interface.
+//
+// The iterations stop when all the terms of the residual vector (b - A·x) are smaller than
+// the maximum error, or the maximum number of iterations is reached.
+//
+// A channel can be added to the solver to receive the progress and the current error. The
+// solver closes the channel once the system is solved.
+type PCGSolver struct {
+	MaxError       float64
+	MaxIter        int
+	Preconditioner Preconditioner
+	ProgressChan   chan<- lineq.IterativeSolverProgress
+}
+
+// Solve solves the system of equations iteratively, starting with a zero solution vector.
+func (solver PCGSolver) Solve(a mat.ReadOnlyMatrix, b vec.ReadOnlyVector) *lineq.Solution {
+	var (
+		size                 = b.Length()
+		x                    = make([]float64, size)
+		r                    = make([]float64, size)
+		p                    = vec.Make(size)
+		z                    vec.ReadOnlyVector
+		rz, alpha, beta, err float64
+		iter                 int
+		lastProgress         = -1
+	)
+
+	if solver.ProgressChan != nil {
+		defer close(solver.ProgressChan)
+	}
+
+	notifyProgress := func() {
+		if solver.ProgressChan == nil {
+			return
+		}
+
+		if progress := computeProgressPercentage(solver.MaxError, err); progress > lastProgress {
+			lastProgress = progress
+			solver.ProgressChan <- lineq.IterativeSolverProgress{
+				ProgressPercentage: progress,
+				Error:              err,
+				IterCount:          iter,
+			}
+		}
+	}
+
+	for i := 0; i < size; i++ {
+		r[i] = b.Value(i)
+	}
+
+	z = solver.Preconditioner.Apply(vec.MakeWithValues(r))
+	for i := 0; i < size; i++ {
+		p.SetValue(i, z.Value(i))
+		rz += r[i] * z.Value(i)
+	}
+
+	for iter = 0; iter < solver.MaxIter; iter++ {
+		if err = maxAbs(r); err <= solver.MaxError {
+			break
+		}
+
+		notifyProgress()
+
+		q := a.TimesVector(p)
+		alpha = rz / p.Times(q)
+
+		for i := 0; i < size; i++ {
+			x[i] += alpha * p.Value(i)
+			r[i] -= alpha * q.Value(i)
+		}
+
+		z = solver.Preconditioner.Apply(vec.MakeWithValues(r))
+		newRz := 0.0
+		for i := 0; i < size; i++ {
+			newRz += r[i] * z.Value(i)
+		}
+
+		beta = newRz / rz
+		rz = newRz
+
+		for i := 0; i < size; i++ {
+			p.SetValue(i, z.Value(i)+beta*p.Value(i))
+		}
+	}
+
+	err = maxAbs(r)
+	notifyProgress()
+
+	return &lineq.Solution{
+		ReachedMaxIter: err > solver.MaxError,
+		MinError:       err,
+		IterCount:      iter,
+		Solution:       vec.MakeWithValues(x),
+	}
+}
+
+// computeProgressPercentage returns the progress percentage given the current and the maximum
+// allowed error. The progress is logarithmic: each order of magnitude of difference between the
+// errors is 10%.
+func computeProgressPercentage(maxError, currentError float64) int {
+	diff := math.Log10(currentError) - math.Log10(maxError)
+	capDiff := math.Max(math.Min(diff, 10), 0)
+
+	return int(10 * (10 - capDiff))
+}
+
+func maxAbs(values []float64) float64 {
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = math.Max(maxValue, math.Abs(value))
+	}
+
+	return maxValue
+}
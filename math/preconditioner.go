@@ -0,0 +1,152 @@
+package math
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// A Preconditioner approximates the inverse of a system matrix, A. Applying it to a vector, r,
+// yields z = M⁻¹·r, where M ≈ A is cheap to invert.
+//
+// Preconditioners are used by the Preconditioned Conjugate Gradient solver to speed up its
+// convergence.
+type Preconditioner interface {
+	Apply(r vec.ReadOnlyVector) vec.ReadOnlyVector
+}
+
+// A JacobiPreconditioner is the diagonal of the system matrix: M = diag(A).
+type JacobiPreconditioner struct {
+	inverseDiag []float64
+}
+
+// MakeJacobiPreconditioner creates the Jacobi (diagonal) preconditioner of the given matrix.
+func MakeJacobiPreconditioner(m mat.ReadOnlyMatrix) *JacobiPreconditioner {
+	mustBeSquare(m)
+
+	inverseDiag := make([]float64, m.Rows())
+	for i := range inverseDiag {
+		inverseDiag[i] = 1.0 / m.Value(i, i)
+	}
+
+	return &JacobiPreconditioner{inverseDiag}
+}
+
+// Apply computes z = diag(A)⁻¹·r.
+func (p *JacobiPreconditioner) Apply(r vec.ReadOnlyVector) vec.ReadOnlyVector {
+	z := make([]float64, len(p.inverseDiag))
+	for i, inv := range p.inverseDiag {
+		z[i] = r.Value(i) * inv
+	}
+
+	return vec.MakeWithValues(z)
+}
+
+// An SSORPreconditioner is the Symmetric Successive Over-Relaxation preconditioner of a
+// symmetric matrix A = L + D + Lᵀ:
+//
+//	M = (D/ω + L)·(D/ω)⁻¹·(D/ω + Lᵀ)·ω/(2 - ω)
+//
+// The relaxation factor, ω, must be in the (0, 2) range. When ω = 1, this is the Symmetric
+// Gauss-Seidel preconditioner.
+type SSORPreconditioner struct {
+	omega float64
+	lower []sparseRow
+	upper []sparseRow
+	diag  []float64
+}
+
+// MakeSSORPreconditioner creates the SSOR preconditioner of the given symmetric matrix with
+// the given relaxation factor.
+func MakeSSORPreconditioner(m mat.ReadOnlyMatrix, omega float64) *SSORPreconditioner {
+	mustBeSquare(m)
+	if omega <= 0 || omega >= 2 {
+		panic(fmt.Sprintf("The SSOR relaxation factor must be in the (0, 2) range, but got %f", omega))
+	}
+
+	lower, upper, diag := splitSparseRows(m)
+	return &SSORPreconditioner{omega, lower, upper, diag}
+}
+
+// Apply computes z = M⁻¹·r by a forward and a backward Gauss-Seidel sweep.
+func (p *SSORPreconditioner) Apply(r vec.ReadOnlyVector) vec.ReadOnlyVector {
+	var (
+		size = len(p.diag)
+		z    = make([]float64, size)
+	)
+
+	// Forward sweep: (D + ωL)·y = r
+	for i := 0; i < size; i++ {
+		sum := r.Value(i)
+		for k, j := range p.lower[i].cols {
+			sum -= p.omega * p.lower[i].values[k] * z[j]
+		}
+		z[i] = sum / p.diag[i]
+	}
+
+	// Diagonal scaling: D·y
+	for i := 0; i < size; i++ {
+		z[i] *= p.diag[i]
+	}
+
+	// Backward sweep: (D + ωLᵀ)·z = D·y
+	for i := size - 1; i >= 0; i-- {
+		sum := z[i]
+		for k, j := range p.upper[i].cols {
+			sum -= p.omega * p.upper[i].values[k] * z[j]
+		}
+		z[i] = sum / p.diag[i]
+	}
+
+	factor := p.omega * (2 - p.omega)
+	for i := range z {
+		z[i] *= factor
+	}
+
+	return vec.MakeWithValues(z)
+}
+
+// A sparseRow stores the non-zero terms of a matrix row, sorted by column index.
+type sparseRow struct {
+	cols   []int
+	values []float64
+}
+
+// splitSparseRows returns the strictly lower and upper triangular terms of the rows of the
+// given matrix, together with its main diagonal.
+func splitSparseRows(m mat.ReadOnlyMatrix) (lower, upper []sparseRow, diag []float64) {
+	size := m.Rows()
+	lower = make([]sparseRow, size)
+	upper = make([]sparseRow, size)
+	diag = make([]float64, size)
+
+	for i := 0; i < size; i++ {
+		cols := m.NonZeroIndicesAtRow(i)
+		sort.Ints(cols)
+
+		for _, j := range cols {
+			value := m.Value(i, j)
+
+			switch {
+			case j < i:
+				lower[i].cols = append(lower[i].cols, j)
+				lower[i].values = append(lower[i].values, value)
+			case j > i:
+				upper[i].cols = append(upper[i].cols, j)
+				upper[i].values = append(upper[i].values, value)
+			default:
+				diag[i] = value
+			}
+		}
+	}
+
+	return lower, upper, diag
+}
+
+func mustBeSquare(m mat.ReadOnlyMatrix) {
+	if !mat.IsSquare(m) {
+		panic(fmt.Sprintf("Can't precondition a non-square matrix (%dx%d)", m.Rows(), m.Cols()))
+	}
+}
@@ -0,0 +1,105 @@
+package math
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncompleteCholesky(t *testing.T) {
+	t.Run("is the complete factorization when there's no fill-in", func(t *testing.T) {
+		var (
+			// Tridiagonal matrix: the factorization produces no fill-in terms
+			matrix = mat.MakeSparseWithData(3, 3, []float64{
+				4, 1, 0,
+				1, 4, 1,
+				0, 1, 4,
+			})
+			ic   = MakeIncompleteCholesky(matrix, 0)
+			want = vec.MakeWithValues([]float64{1, 2, 3})
+			got  = ic.Apply(matrix.TimesVector(want))
+		)
+
+		assert.Equal(t, 5, ic.NonZeroCount())
+		assert.Equal(t, 0.0, ic.Shift())
+		for i := 0; i < want.Length(); i++ {
+			assert.True(t, nums.FloatsEqual(want.Value(i), got.Value(i)))
+		}
+	})
+
+	// Arrow matrix: the factorization of the last row fills in the (2, 1) term with -0.25
+	matrix := mat.MakeSparseWithData(3, 3, []float64{
+		4, 1, 1,
+		1, 4, 0,
+		1, 0, 4,
+	})
+
+	t.Run("IC(0) drops the fill-in terms", func(t *testing.T) {
+		ic := MakeIncompleteCholesky(matrix, 0)
+		assert.Equal(t, 5, ic.NonZeroCount())
+	})
+
+	t.Run("keeps the fill-in terms above the threshold", func(t *testing.T) {
+		ic := MakeIncompleteCholesky(matrix, 0.05)
+		assert.Equal(t, 6, ic.NonZeroCount())
+	})
+
+	t.Run("drops the fill-in terms below the threshold", func(t *testing.T) {
+		ic := MakeIncompleteCholesky(matrix, 0.1)
+		assert.Equal(t, 5, ic.NonZeroCount())
+	})
+
+	t.Run("shifts the diagonal if the factorization breaks down", func(t *testing.T) {
+		ic := MakeIncompleteCholesky(mat.MakeSparseWithData(2, 2, []float64{1, 2, 2, 1}), 0)
+		assert.Greater(t, ic.Shift(), 0.0)
+	})
+}
+
+func TestPCGSolver(t *testing.T) {
+	var (
+		matrix = mat.MakeSparseWithData(4, 4, []float64{
+			4, 1, 0, 0,
+			1, 5, 2, 0,
+			0, 2, 6, 1,
+			0, 0, 1, 3,
+		})
+		want = vec.MakeWithValues([]float64{1, -2, 3, -4})
+		b    = matrix.TimesVector(want)
+	)
+
+	preconditioners := map[string]Preconditioner{
+		"Jacobi":              MakeJacobiPreconditioner(matrix),
+		"SSOR":                MakeSSORPreconditioner(matrix, 1.2),
+		"Incomplete Cholesky": MakeIncompleteCholesky(matrix, 0),
+	}
+
+	for name, preconditioner := range preconditioners {
+		t.Run("solves the system with the "+name+" preconditioner", func(t *testing.T) {
+			var (
+				solver = PCGSolver{MaxError: 1e-10, MaxIter: 10, Preconditioner: preconditioner}
+				got    = solver.Solve(matrix, b)
+			)
+
+			assert.False(t, got.ReachedMaxIter)
+			for i := 0; i < want.Length(); i++ {
+				assert.True(t, nums.FloatsEqualEps(want.Value(i), got.Solution.Value(i), 1e-8))
+			}
+		})
+	}
+
+	t.Run("the incomplete Cholesky without fill-in converges in one iteration", func(t *testing.T) {
+		var (
+			solver = PCGSolver{
+				MaxError:       1e-10,
+				MaxIter:        10,
+				Preconditioner: MakeIncompleteCholesky(matrix, 0),
+			}
+			got = solver.Solve(matrix, b)
+		)
+
+		assert.Equal(t, 1, got.IterCount)
+	})
+}
@@ -25,6 +25,13 @@ type PlotConfig struct {
 
 	BendingColor     string
 	BendingFillColor string
+
+	ModeShapeColor string
+	ModeShapeWidth int
+
+	InfluenceLineColor     string
+	InfluenceLineFillColor string
+	InfluenceLineWidth     int
 }
 
 func DefaultPlotConfig() *PlotConfig {
@@ -51,6 +58,13 @@ func DefaultPlotConfig() *PlotConfig {
 
 		BendingColor:     "#1E88E5",
 		BendingFillColor: "#42A5F533",
+
+		ModeShapeColor: "#E53935",
+		ModeShapeWidth: 2,
+
+		InfluenceLineColor:     "#00897B",
+		InfluenceLineFillColor: "#26A69A33",
+		InfluenceLineWidth:     2,
 	}
 }
 
@@ -78,5 +92,12 @@ func DarkPlotConfig() *PlotConfig {
 
 		BendingColor:     "#42A5F5",
 		BendingFillColor: "#42A5F533",
+
+		ModeShapeColor: "#EF5350",
+		ModeShapeWidth: 2,
+
+		InfluenceLineColor:     "#4DB6AC",
+		InfluenceLineFillColor: "#4DB6AC33",
+		InfluenceLineWidth:     2,
 	}
 }
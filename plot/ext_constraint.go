@@ -31,18 +31,21 @@ func drawExternalConstraints(
 			pos = scale.applyToPoint(node.Position)
 			x, y = int(pos.X()), int(pos.Y())
 
-			// A group that sets the coordinate origin at the node's position and the
-			// y-axis pointing downwards.
+			// A group that sets the coordinate origin at the node's position, rotated the angle of
+			// inclined constraints, and the y-axis pointing downwards. The rotation goes before the
+			// y-axis flip, so that it's counterclockwise, as the angle.
 			canvas.Gtransform(
-				fmt.Sprintf("translate(%d,%d) scale(1,-1)", x, y),
+				fmt.Sprintf(
+					"translate(%d,%d) rotate(%f) scale(1,-1)", x, y, node.ExternalConstraint.Angle(),
+				),
 			)
 
-			if node.ExternalConstraint.Equals(&structure.FullConstraint) {
+			if node.ExternalConstraint.ConstrainsSameDofs(&structure.FullConstraint) {
 				drawGround(canvas, l, 0)
-			} else if node.ExternalConstraint.Equals(&structure.DispConstraint) {
+			} else if node.ExternalConstraint.ConstrainsSameDofs(&structure.DispConstraint) {
 				drawTriangle(canvas, l)
 				drawGround(canvas, l, int(l/2))
-			} else if node.ExternalConstraint.Equals(&structure.DispYConstraint) {
+			} else if node.ExternalConstraint.ConstrainsSameDofs(&structure.DispYConstraint) {
 				drawTriangle(canvas, l)
 				drawWheels(canvas, l)
 				drawGround(canvas, l, int(3*l/4))
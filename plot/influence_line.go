@@ -0,0 +1,127 @@
+package plot
+
+import (
+	"fmt"
+	"io"
+
+	svg "github.com/ajstarks/svgo"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+// InfluenceOrdinate is the value of an influence line when the unit load is at a point in a bar,
+// given by its T parameter.
+type InfluenceOrdinate struct {
+	T     nums.TParam
+	Value float64
+}
+
+// An InfluenceLine is the value of a quantity, like a reaction, as a unit load travels along a
+// path of bars, given by its ordinates at points along them. The ordinates are normalized so that
+// the largest is one. The title describes the quantity, and is included in the plot.
+type InfluenceLine struct {
+	Title     string
+	Ordinates map[contracts.StrID][]InfluenceOrdinate
+}
+
+// InfluenceLineToSVG generates an SVG diagram with the structure's geometry and supports, and the
+// given influence line drawn over the bars of its path, and writes the result to the given writer.
+//
+// The ordinates are drawn in the global Y direction, the positive ones upwards. The largest
+// ordinate is drawn with the length of the longest bar times the ModeShapeScale in the options.
+func InfluenceLineToSVG(
+	st *structure.Structure,
+	line *InfluenceLine,
+	options *StructurePlotOps,
+	config *PlotConfig,
+	w io.Writer,
+) {
+	var (
+		unitsScale = determineUnitsScale(st)
+		rectBounds = structureRectBounds(st, options, unitsScale)
+		canvas     = svg.New(w)
+		ctx        = plotContext{
+			canvas:     canvas,
+			config:     config,
+			options:    options,
+			unitsScale: unitsScale,
+		}
+	)
+
+	canvas.Start(int(rectBounds.Width()), int(rectBounds.Height()))
+
+	canvas.Def()
+	defineExtConstrainGroundPattern(canvas, config)
+	canvas.DefEnd()
+
+	canvas.Gtransform(
+		transformMatrix(
+			options.Scale, -options.Scale,
+			float64(options.MinMargin), rectBounds.Height()-float64(options.MinMargin),
+		),
+	)
+	drawGeometry(st, &ctx)
+	drawExternalConstraints(st, &ctx)
+	drawInfluenceLine(st, line, &ctx)
+	canvas.Gend()
+
+	canvas.Text(
+		options.MinMargin/2, options.MinMargin/2,
+		line.Title,
+		fmt.Sprintf("fill=\"%s\"", config.InfluenceLineColor),
+	)
+	canvas.End()
+}
+
+// drawInfluenceLine draws, for each bar in the influence line's path, a polygon enclosed by the
+// bar and its ordinates.
+func drawInfluenceLine(st *structure.Structure, line *InfluenceLine, ctx *plotContext) {
+	var (
+		canvas    = ctx.canvas
+		config    = ctx.config
+		scale     = ctx.unitsScale
+		amplitude = ctx.options.ModeShapeScale * longestBarLength(st)
+	)
+
+	canvas.Gstyle(
+		fmt.Sprintf(
+			"stroke:%s;stroke-width:%d;fill:%s",
+			config.InfluenceLineColor, config.InfluenceLineWidth, config.InfluenceLineFillColor,
+		),
+	)
+
+	for _, element := range st.Elements() {
+		ordinates, exists := line.Ordinates[element.GetID()]
+		if !exists || len(ordinates) == 0 {
+			continue
+		}
+
+		var (
+			pointsCount = len(ordinates) + 2
+			x           = make([]int, pointsCount)
+			y           = make([]int, pointsCount)
+			start       = scale.applyToPoint(element.PointAt(ordinates[0].T))
+			end         = scale.applyToPoint(element.PointAt(ordinates[len(ordinates)-1].T))
+		)
+
+		x[0], y[0] = int(start.X()), int(start.Y())
+		for i, ordinate := range ordinates {
+			var (
+				position = element.PointAt(ordinate.T)
+				point    = scale.applyToPoint(g2d.MakePoint(
+					position.X(),
+					position.Y()+amplitude*ordinate.Value,
+				))
+			)
+
+			x[i+1], y[i+1] = int(point.X()), int(point.Y())
+		}
+		x[pointsCount-1], y[pointsCount-1] = int(end.X()), int(end.Y())
+
+		canvas.Polygon(x, y, fmt.Sprintf("id=\"influence__%s\"", element.GetID()))
+	}
+
+	canvas.Gend()
+}
@@ -0,0 +1,128 @@
+package plot
+
+import (
+	"fmt"
+	"io"
+
+	svg "github.com/ajstarks/svgo"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+// ModeShapePoint is the global displacement of a point in a bar, given by its T parameter, in
+// a mode shape.
+type ModeShapePoint struct {
+	T      nums.TParam
+	Dx, Dy float64
+}
+
+// A ModeShape is the shape of a mode of the structure, like a buckling mode, given by the
+// displacements of points along its bars. The displacements are normalized so that the largest
+// is one. The title describes the mode, and is included in the plot.
+type ModeShape struct {
+	Title         string
+	Displacements map[contracts.StrID][]ModeShapePoint
+}
+
+// ModeShapeToSVG generates an SVG diagram with the structure's geometry and supports, and the
+// given mode shape drawn over it, and writes the result to the given writer.
+//
+// The largest displacement in the mode shape is drawn with the length of the longest bar times
+// the ModeShapeScale in the options.
+func ModeShapeToSVG(
+	st *structure.Structure,
+	shape *ModeShape,
+	options *StructurePlotOps,
+	config *PlotConfig,
+	w io.Writer,
+) {
+	var (
+		unitsScale = determineUnitsScale(st)
+		rectBounds = structureRectBounds(st, options, unitsScale)
+		canvas     = svg.New(w)
+		ctx        = plotContext{
+			canvas:     canvas,
+			config:     config,
+			options:    options,
+			unitsScale: unitsScale,
+		}
+	)
+
+	canvas.Start(int(rectBounds.Width()), int(rectBounds.Height()))
+
+	canvas.Def()
+	defineExtConstrainGroundPattern(canvas, config)
+	canvas.DefEnd()
+
+	canvas.Gtransform(
+		transformMatrix(
+			options.Scale, -options.Scale,
+			float64(options.MinMargin), rectBounds.Height()-float64(options.MinMargin),
+		),
+	)
+	drawGeometry(st, &ctx)
+	drawExternalConstraints(st, &ctx)
+	drawModeShape(st, shape, &ctx)
+	canvas.Gend()
+
+	canvas.Text(
+		options.MinMargin/2, options.MinMargin/2,
+		shape.Title,
+		fmt.Sprintf("fill=\"%s\"", config.ModeShapeColor),
+	)
+	canvas.End()
+}
+
+// drawModeShape draws the displaced position of each bar in the mode shape as a polyline through
+// the displaced points.
+func drawModeShape(st *structure.Structure, shape *ModeShape, ctx *plotContext) {
+	var (
+		canvas    = ctx.canvas
+		config    = ctx.config
+		scale     = ctx.unitsScale
+		amplitude = ctx.options.ModeShapeScale * longestBarLength(st)
+	)
+
+	canvas.Gstyle(
+		fmt.Sprintf("stroke:%s;stroke-width:%d;fill:none", config.ModeShapeColor, config.ModeShapeWidth),
+	)
+
+	for _, element := range st.Elements() {
+		points, exists := shape.Displacements[element.GetID()]
+		if !exists {
+			continue
+		}
+
+		var (
+			x = make([]int, len(points))
+			y = make([]int, len(points))
+		)
+
+		for i, point := range points {
+			var (
+				position  = element.PointAt(point.T)
+				displaced = scale.applyToPoint(g2d.MakePoint(
+					position.X()+amplitude*point.Dx,
+					position.Y()+amplitude*point.Dy,
+				))
+			)
+
+			x[i], y[i] = int(displaced.X()), int(displaced.Y())
+		}
+
+		canvas.Polyline(x, y, fmt.Sprintf("id=\"mode__%s\"", element.GetID()))
+	}
+
+	canvas.Gend()
+}
+
+func longestBarLength(st *structure.Structure) float64 {
+	stats, err := calculateBarLengthStats(st)
+	if err != nil {
+		panic(err)
+	}
+
+	return stats.MaxLength
+}
@@ -28,6 +28,9 @@ type StructurePlotOps struct {
 	DistLoadScale float64
 	// MinMargin is the minimum margin between the structure and the canvas border.
 	MinMargin int
+	// ModeShapeScale is the size of the largest displacement in the mode shapes, as a fraction
+	// of the length of the longest bar.
+	ModeShapeScale float64
 }
 
 func (ops StructurePlotOps) ApplyDrawingScale(value int) int {
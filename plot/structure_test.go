@@ -96,3 +96,39 @@ func TestStructureToSVG(t *testing.T) {
 		assert.Regexp(t, wantNodeThreePattern, got)
 	})
 }
+
+func TestInclinedSupportToSVG(t *testing.T) {
+	var (
+		nodeOne = structure.MakeNodeAtPosition("n1", 0, 0, &structure.FullConstraint)
+		nodeTwo = structure.MakeNodeAtPosition("n2", 200, 0, structure.DispYConstraint.WithAngle(30))
+
+		bar = structure.
+			MakeElementBuilder("b1").
+			WithStartNode(nodeOne, &structure.FullConstraint).
+			WithEndNode(nodeTwo, &structure.FullConstraint).
+			WithMaterial(structure.MakeUnitMaterial()).
+			WithSection(structure.MakeUnitSection()).
+			Build()
+
+		strDefinition = structure.Make(
+			structure.StrMetadata{
+				MajorVersion: 1,
+				MinorVersion: 0,
+			},
+			map[contracts.StrID]*structure.Node{
+				"n1": nodeOne,
+				"n2": nodeTwo,
+			},
+			[]*structure.Element{bar},
+		)
+
+		b bytes.Buffer
+	)
+
+	StructureToSVG(strDefinition, &StructurePlotOps{Scale: 1.0, MinMargin: 100}, DefaultPlotConfig(), &b)
+
+	t.Run("rotates the support counterclockwise before flipping the y-axis", func(t *testing.T) {
+		wantTransformPattern := "translate\\(200,0\\) rotate\\(30(\\.[0]+)?\\) scale\\(1,-1\\)"
+		assert.Regexp(t, wantTransformPattern, b.String())
+	})
+}
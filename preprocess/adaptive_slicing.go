@@ -0,0 +1,156 @@
+package preprocess
+
+import (
+	"fmt"
+	gomath "math"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+const (
+	// DefaultAdaptiveTolerance is the default largest error of the bending moment's linear
+	// interpolation in a slice, relative to the largest bending moment in the structure.
+	DefaultAdaptiveTolerance = 0.01
+
+	// maxAdaptiveRefinements is the maximum number of times the slices are refined.
+	maxAdaptiveRefinements = 5
+)
+
+// refineSlices refines the slices of the preprocessed structure until the error estimate of every
+// slice is below the options' adaptive tolerance, or the maximum number of refinements is reached.
+//
+// In each refinement, the sliced structure is solved for all of its load cases, and the slices
+// whose error estimate is too large are split in two halves: the structure is sliced again with
+// their middle positions. See slicesToRefine.
+func refineSlices(str *structure.Structure, options *PreprocessOptions, preStructure *Structure) *Structure {
+	var (
+		tolerance = options.AdaptiveTolerance
+		extraTPos = make(map[contracts.StrID][]nums.TParam)
+	)
+
+	if tolerance <= 0 {
+		tolerance = DefaultAdaptiveTolerance
+	}
+
+	for i := 0; i < maxAdaptiveRefinements; i++ {
+		refinements := preStructure.slicesToRefine(tolerance)
+		if len(refinements) == 0 {
+			break
+		}
+
+		for id, tPos := range refinements {
+			extraTPos[id] = append(extraTPos[id], tPos...)
+		}
+
+		preStructure = sliceStructure(str, options, extraTPos)
+	}
+
+	return preStructure
+}
+
+// slicesToRefine solves the structure for each load case and returns, for each element, the middle
+// positions of the slices whose bending moment error estimate is larger than the tolerance,
+// relative to the largest bending moment in the structure across all load cases.
+//
+// The error estimate of a slice is the largest deviation of the bending moment from its linear
+// interpolation between the slice's nodes, which is where the bending moment's gradient, the shear
+// force, changes along the slice: l·|ΔV|/8, exact for a uniform load. Slices shorter than four
+// times the minimum distance between slices aren't refined.
+func (str *Structure) slicesToRefine(tolerance float64) map[contracts.StrID][]nums.TParam {
+	var (
+		loadCases    = str.LoadCases()
+		maxMoment    = 0.0
+		maxErrors    = make(map[contracts.StrID][]float64, str.ElementsCount())
+		refinements  = make(map[contracts.StrID][]nums.TParam)
+		displacement = str.solveLoadCases(loadCases)
+	)
+
+	for _, element := range str.Elements() {
+		maxErrors[element.GetID()] = make([]float64, element.NodesCount()-1)
+	}
+
+	for i, loadCase := range loadCases {
+		for _, element := range str.Elements() {
+			var (
+				caseElement = element.InLoadCase(loadCase)
+				errors      = maxErrors[element.GetID()]
+			)
+
+			for j := 1; j < caseElement.NodesCount(); j++ {
+				var (
+					trailNode, leadNode       = caseElement.NodeAt(j - 1), caseElement.NodeAt(j)
+					trailMoment, leadMoment   = caseElement.sliceEndMoments(j-1, displacement[i])
+					shearChange               = trailNode.LocalLeftFy() + leadNode.LocalRightFy()
+					sliceLength               = element.LengthBetween(trailNode.T, leadNode.T)
+					interpolationErrorAtSlice = sliceLength * gomath.Abs(shearChange) / 8.0
+				)
+
+				maxMoment = gomath.Max(maxMoment, gomath.Max(gomath.Abs(trailMoment), gomath.Abs(leadMoment)))
+				errors[j-1] = gomath.Max(errors[j-1], interpolationErrorAtSlice)
+			}
+		}
+	}
+
+	if maxMoment == 0 {
+		return refinements
+	}
+
+	for _, element := range str.Elements() {
+		for j, sliceError := range maxErrors[element.GetID()] {
+			var (
+				trailT, leadT = element.NodeAt(j).T, element.NodeAt(j + 1).T
+			)
+
+			if sliceError > tolerance*maxMoment && trailT.DistanceTo(leadT) > 4*minDistBetweenTSlices {
+				refinements[element.GetID()] = append(
+					refinements[element.GetID()],
+					nums.MakeTParam(0.5*(trailT.Value()+leadT.Value())),
+				)
+			}
+		}
+	}
+
+	return refinements
+}
+
+// solveLoadCases solves the structure's system of equations for each of the load cases with the
+// direct solver, returning the displacements in the global frame.
+func (str *Structure) solveLoadCases(loadCases []string) []vec.ReadOnlyVector {
+	factorization, err := math.MakeLDLFactorization(str.MakeStiffnessMatrix())
+	if err != nil {
+		panic(fmt.Sprintf("Can't solve the system of equations to refine the slices: %s", err))
+	}
+
+	displacements := make([]vec.ReadOnlyVector, len(loadCases))
+	for i, loadCase := range loadCases {
+		displacements[i] = str.GlobalDisplacements(factorization.Solve(str.MakeLoadVector(loadCase)))
+	}
+
+	return displacements
+}
+
+// sliceEndMoments computes the bending moments at the start and end of the i-th slice of the
+// element, given the structure's global displacements, as the element solutions do: the moments
+// due to the slice's deformation minus the equivalent moments of the slice's loads.
+func (element *Element) sliceEndMoments(i int, displacements vec.ReadOnlyVector) (trail, lead float64) {
+	var (
+		trailNode, leadNode = element.NodeAt(i), element.NodeAt(i + 1)
+		stiffness           = element.StiffnessGlobalMat(trailNode.T, leadNode.T)
+		trailDofs           = trailNode.DegreesOfFreedomNum()
+		leadDofs            = leadNode.DegreesOfFreedomNum()
+		dofs                = [6]int{
+			trailDofs[0], trailDofs[1], trailDofs[2], leadDofs[0], leadDofs[1], leadDofs[2],
+		}
+	)
+
+	for k, dof := range dofs {
+		trail -= stiffness.Value(2, k) * displacements.Value(dof)
+		lead += stiffness.Value(5, k) * displacements.Value(dof)
+	}
+
+	return trail + trailNode.LocalLeftMz(), lead - leadNode.LocalRightMz()
+}
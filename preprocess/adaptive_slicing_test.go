@@ -0,0 +1,80 @@
+package preprocess
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveSlicing(t *testing.T) {
+	build.ReadBuildInfo()
+
+	t.Run("refines the slices of a uniformly loaded beam", func(t *testing.T) {
+		var (
+			str = makeSimpleBeamStructure(
+				structure.DispConstraint,
+				structure.DispYConstraint,
+				nil,
+				[]*load.DistributedLoad{
+					load.MakeDistributed(load.FY, true, nums.MinT, -10.0, nums.MaxT, -10.0),
+				},
+			)
+			// The error of a slice of length l is ql²/8, and the maximum moment qL²/8, so slices
+			// need to be shorter than L/√1000: the 10 slices are refined twice into 40.
+			options = &PreprocessOptions{Adaptive: true, AdaptiveTolerance: 1e-3}
+			result  = StructureModel(str, options)
+			el      = result.Elements()[0]
+		)
+
+		assert.Equal(t, 41, el.NodesCount())
+		for i, node := range el.Nodes() {
+			assert.InDelta(t, float64(i)/40.0, node.T.Value(), 1e-10)
+		}
+	})
+
+	t.Run("doesn't refine the slices of a cantilever with a tip load", func(t *testing.T) {
+		var (
+			str = makeSimpleBeamStructure(
+				structure.FullConstraint,
+				structure.NilConstraint,
+				[]*load.ConcentratedLoad{load.MakeConcentrated(load.FY, true, nums.MaxT, -10.0)},
+				nil,
+			)
+			options = &PreprocessOptions{Adaptive: true, AdaptiveTolerance: 1e-3}
+			result  = StructureModel(str, options)
+		)
+
+		assert.Equal(t, elementWithLoadsSlices+1, result.Elements()[0].NodesCount())
+	})
+}
+
+func makeSimpleBeamStructure(
+	startConstraint, endConstraint structure.Constraint,
+	concentratedLoads []*load.ConcentratedLoad,
+	distributedLoads []*load.DistributedLoad,
+) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("n1", g2d.MakePoint(0, 0), &startConstraint)
+		nodeTwo = structure.MakeNode("n2", g2d.MakePoint(300, 0), &endConstraint)
+		bar     = structure.MakeElementBuilder("b1").
+			WithStartNode(nodeOne, &structure.FullConstraint).
+			WithEndNode(nodeTwo, &structure.FullConstraint).
+			WithMaterial(structure.MakeUnitMaterial()).
+			WithSection(structure.MakeUnitSection()).
+			AddConcentratedLoads(concentratedLoads).
+			AddDistributedLoads(distributedLoads).
+			Build()
+		meta = structure.StrMetadata{MajorVersion: 2, MinorVersion: 3}
+	)
+
+	return structure.Make(meta, map[contracts.StrID]*structure.Node{
+		"n1": nodeOne,
+		"n2": nodeTwo,
+	}, []*structure.Element{bar})
+}
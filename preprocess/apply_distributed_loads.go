@@ -13,7 +13,17 @@ func applyDistributedLoadsToNodes(nodes []*Node, loads []*load.DistributedLoad)
 		trailNode, leadNode = nodes[i], nodes[j]
 
 		for _, load := range loads {
-			applyDistributedLoadToNodes(load, trailNode, leadNode)
+			// The slice is outside the load: its values at the nodes would be zero at one end,
+			// and a ramp would be applied instead of no load.
+			if !trailNode.T.IsLessThan(load.EndT) || !load.StartT.IsLessThan(leadNode.T) {
+				continue
+			}
+
+			applyDistributedLoadToNodes(
+				load,
+				trailNode.InLoadCase(load.LoadCase),
+				leadNode.InLoadCase(load.LoadCase),
+			)
 		}
 	}
 }
@@ -0,0 +1,62 @@
+package preprocess
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+// applyThermalLoadsToNodes adds the equivalent loads of the element's thermal loads to the
+// trailing and leading nodes of each of its finite elements.
+//
+// A thermal load doesn't depend on the length of the finite element: a uniform temperature
+// change, ΔT, is equivalent to a pair of opposite axial forces of value EAαΔT, and a temperature
+// gradient to a pair of opposite moments of value EIαΔT/h. These are stored as the left and right
+// loads of the nodes, hence they are subtracted from the stresses computed from the
+// displacements, as the thermal strains don't produce stresses.
+//
+// Panics if the element has a temperature gradient load and its section has no depth.
+func applyThermalLoadsToNodes(element *structure.Element, nodes []*Node) {
+	for _, ld := range element.ThermalLoads {
+		fx, mz := thermalLoadEquivalentValues(element, ld)
+
+		for i := 1; i < len(nodes); i++ {
+			nodes[i-1].InLoadCase(ld.LoadCase).AddLocalLeftLoad(-fx, 0.0, -mz)
+			nodes[i].InLoadCase(ld.LoadCase).AddLocalRightLoad(fx, 0.0, mz)
+		}
+	}
+}
+
+// thermalLoadEquivalentValues computes the axial force and moment which, applied at the end of
+// a finite element, and with opposite sign at its start, are equivalent to the thermal load.
+//
+// The temperature gradient is the difference between the top and bottom fibers' temperature,
+// hence a positive gradient elongates the top fiber and bends the element with a negative
+// curvature: -αΔT/h.
+func thermalLoadEquivalentValues(element *structure.Element, ld *load.ThermalLoad) (fx, mz float64) {
+	var (
+		material = element.Material()
+		section  = element.Section()
+		alpha    = material.ThermalExpansion
+	)
+
+	switch ld.Term {
+	case load.UniformTemperature:
+		return material.YoungMod * section.Area * alpha * ld.Value, 0.0
+
+	case load.TemperatureGradient:
+		if section.Depth <= 0 {
+			panic(fmt.Sprintf(
+				"Element %s has a temperature gradient load, but its section '%s' has no depth",
+				element.GetID(), section.Name,
+			))
+		}
+
+		curvature := -alpha * ld.Value / section.Depth
+		return 0.0, material.YoungMod * section.IStrong * curvature
+
+	default:
+		panic(fmt.Sprintf("Invalid thermal load term: '%s'", ld.Term))
+	}
+}
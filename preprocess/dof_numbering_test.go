@@ -0,0 +1,81 @@
+package preprocess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseCuthillMcKee(t *testing.T) {
+	// Path graph 0 - 4 - 2 - 5 - 1 - 3: the numbering has a bandwidth of 4
+	adjacency := [][]int{{4}, {3, 5}, {4, 5}, {1}, {0, 2}, {1, 2}}
+
+	t.Run("yields a permutation of the vertices", func(t *testing.T) {
+		newIdx := reverseCuthillMcKee(adjacency)
+		assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5}, newIdx)
+	})
+
+	t.Run("reduces the bandwidth of a path to one", func(t *testing.T) {
+		newIdx := reverseCuthillMcKee(adjacency)
+		assert.Equal(t, 1, bandwidth(adjacency, newIdx))
+	})
+
+	t.Run("numbers all connected components", func(t *testing.T) {
+		newIdx := reverseCuthillMcKee([][]int{{1}, {0}, {}, {4}, {3}})
+		assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, newIdx)
+	})
+}
+
+func TestRenumberDof(t *testing.T) {
+	str := makeStructure().AssignDof().RenumberDof(RCMNumbering)
+
+	t.Run("records the numbering", func(t *testing.T) {
+		assert.Equal(t, RCMNumbering, str.DofNumbering())
+	})
+
+	t.Run("keeps the degrees of freedom count", func(t *testing.T) {
+		assert.Equal(t, 16, str.DofsCount())
+	})
+
+	t.Run("assigns each degree of freedom number once", func(t *testing.T) {
+		seen := make(map[int]bool)
+		for _, element := range str.Elements() {
+			for _, node := range element.Nodes() {
+				for _, dof := range node.DegreesOfFreedomNum() {
+					seen[dof] = true
+				}
+			}
+		}
+
+		assert.Equal(t, 16, len(seen))
+	})
+
+	t.Run("elements sharing a node share the degrees of freedom", func(t *testing.T) {
+		var (
+			structuralDofs = str.GetNodeById("1").DegreesOfFreedomNum()
+			dofsA          = str.Elements()[0].NodeAt(0).DegreesOfFreedomNum()
+			dofsB          = str.Elements()[1].NodeAt(0).DegreesOfFreedomNum()
+		)
+
+		assert.Equal(t, structuralDofs, dofsA)
+		assert.Equal(t, structuralDofs[0], dofsB[0])
+		assert.Equal(t, structuralDofs[1], dofsB[1])
+		assert.NotEqual(t, structuralDofs[2], dofsB[2])
+	})
+
+	t.Run("the geometric numbering keeps the assigned numbers", func(t *testing.T) {
+		str := makeStructure().AssignDof().RenumberDof(GeometricNumbering)
+		assert.Equal(t, [3]int{0, 1, 9}, str.Elements()[1].NodeAt(0).DegreesOfFreedomNum())
+	})
+}
+
+func bandwidth(adjacency [][]int, newIdx []int) int {
+	maxBandwidth := 0
+	for v, neighbors := range adjacency {
+		for _, neighbor := range neighbors {
+			maxBandwidth = max(maxBandwidth, newIdx[v]-newIdx[neighbor])
+		}
+	}
+
+	return maxBandwidth
+}
@@ -38,10 +38,14 @@ func (element Element) NodeAt(i int) *Node {
 	return element.nodes[i]
 }
 
-// setEquationTerms sets this element's stiffness and load terms into the global system of equations.
-func (element *Element) setEquationTerms(matrix mat.MutableMatrix, vector vec.MutableVector) {
-	element.addTermsToStiffnessMatrix(matrix)
-	element.addTermsToLoadVector(vector)
+// InLoadCase returns a view of the element whose nodes refer to the loads of the given load case.
+func (element Element) InLoadCase(loadCase string) *Element {
+	nodes := make([]*Node, len(element.nodes))
+	for i, node := range element.nodes {
+		nodes[i] = node.InLoadCase(loadCase)
+	}
+
+	return MakeElement(element.Element, nodes)
 }
 
 func (element *Element) addTermsToStiffnessMatrix(matrix mat.MutableMatrix) {
@@ -72,7 +76,50 @@ func (element *Element) addTermsToStiffnessMatrix(matrix mat.MutableMatrix) {
 	}
 }
 
-func (element *Element) addTermsToLoadVector(sysVector vec.MutableVector) {
+// addPrescribedDispTermsToLoadVector subtracts from the global loads vector the forces due to
+// the prescribed displacements of the given degrees of freedom: for each prescribed degree of
+// freedom c with value u, the term K[i][c] · u is subtracted from the i-th vector term.
+func (element *Element) addPrescribedDispTermsToLoadVector(
+	sysVector vec.MutableVector,
+	prescribed map[int]float64,
+) {
+	var (
+		stiffMat                    mat.ReadOnlyMatrix
+		trailNode, leadNode         *Node
+		trailNodeDofs, leadNodeDofs [3]int
+		dofs                        [6]int
+	)
+
+	for i := 1; i < len(element.nodes); i++ {
+		trailNode, leadNode = element.nodes[i-1], element.nodes[i]
+		trailNodeDofs, leadNodeDofs = trailNode.DegreesOfFreedomNum(), leadNode.DegreesOfFreedomNum()
+		dofs = [6]int{
+			trailNodeDofs[0], trailNodeDofs[1], trailNodeDofs[2],
+			leadNodeDofs[0], leadNodeDofs[1], leadNodeDofs[2],
+		}
+
+		stiffMat = nil
+		for col, dof := range dofs {
+			value, isPrescribed := prescribed[dof]
+			if !isPrescribed {
+				continue
+			}
+
+			if stiffMat == nil {
+				stiffMat = element.StiffnessGlobalMat(trailNode.T, leadNode.T)
+			}
+
+			for row := 0; row < stiffMat.Rows(); row++ {
+				sysVector.SetValue(dofs[row], sysVector.Value(dofs[row])-stiffMat.Value(row, col)*value)
+			}
+		}
+	}
+}
+
+// addTermsToLoadVector adds the net loads of the element's nodes in the given load case to the
+// global loads vector. The nodes at the ends of the element share degrees of freedom with other
+// elements, so the loads are added to the existing values.
+func (element *Element) addTermsToLoadVector(sysVector vec.MutableVector, loadCase string) {
 	var (
 		globalTorsor *math.Torsor
 		dofs         [3]int
@@ -80,12 +127,12 @@ func (element *Element) addTermsToLoadVector(sysVector vec.MutableVector) {
 	)
 
 	for _, node := range element.nodes {
-		globalTorsor = node.NetLocalLoadTorsor().ProjectedToGlobal(refFrame)
+		globalTorsor = node.InLoadCase(loadCase).NetLocalLoadTorsor().ProjectedToGlobal(refFrame)
 		dofs = node.DegreesOfFreedomNum()
 
-		sysVector.SetValue(dofs[0], globalTorsor.Fx())
-		sysVector.SetValue(dofs[1], globalTorsor.Fy())
-		sysVector.SetValue(dofs[2], globalTorsor.Mz())
+		sysVector.SetValue(dofs[0], sysVector.Value(dofs[0])+globalTorsor.Fx())
+		sysVector.SetValue(dofs[1], sysVector.Value(dofs[1])+globalTorsor.Fy())
+		sysVector.SetValue(dofs[2], sysVector.Value(dofs[2])+globalTorsor.Mz())
 	}
 }
 
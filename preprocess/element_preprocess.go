@@ -1,7 +1,10 @@
 package preprocess
 
 import (
+	gomath "math"
+
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
 )
 
 const (
@@ -9,27 +12,66 @@ const (
 	elementWithoutLoadsSlices = 6
 	// Minimum distance between two consecutive t values in the slices.
 	minDistBetweenTSlices = 1e-3
+	// Maximum number of slices of a bar, so that its t values are further apart than the minimum.
+	maxElementSlices = 500
 )
 
 // sliceElement slices the given bar into finite elements.
 // The algorithm ensures that between two nodes, there's always a minimum
-// distance of 0.003 between the t values of the slices.
+// distance of 0.001 between the t values of the slices.
 // The result is sent through a channel.
 //
 // Depending on the nature of the bar, it is sliced differently:
 //
 //   - Axial bars aren't sliced at all.
-//   - Bars without loads are sliced into 6 elements.
-//   - Bars with loads are sliced into 10 elements.
+//   - Bars without loads are sliced into 6 elements, unless otherwise stated in the options.
+//   - Bars with loads are sliced into 10 elements, unless otherwise stated in the options.
+//
+// If the options limit the length of the slices, bars are sliced into as many elements as
+// needed for their slices not to be longer. See elementSlicesCount.
 //
 // Intermediate points (not end nodes) where a concentrated load is applied,
-// also generate intermediate nodes for the load to be included.
-func sliceElement(element *structure.Element, c chan<- *Element) {
+// also generate intermediate nodes for the load to be included, and so do the
+// extra t positions, like those added when refining the slices.
+func sliceElement(
+	element *structure.Element,
+	options *PreprocessOptions,
+	extraTPos []nums.TParam,
+	c chan<- *Element,
+) {
 	if element.IsAxialMember() {
 		c <- sliceAxialElement(element)
 	} else if element.HasLoadsApplied() {
-		c <- sliceLoadedElement(element, elementWithLoadsSlices)
+		c <- sliceLoadedElement(element, elementSlicesCount(element, options), extraTPos...)
 	} else {
-		c <- sliceElementWithoutLoads(element, elementWithoutLoadsSlices)
+		c <- sliceElementWithoutLoads(element, elementSlicesCount(element, options), extraTPos...)
+	}
+}
+
+// elementSlicesCount returns the number of slices a non-axial bar is sliced into: the number
+// for the bar's type in the options, or the default one if not set, increased so that no slice
+// is longer than the options' maximum slice length. It's never larger than maxElementSlices.
+func elementSlicesCount(element *structure.Element, options *PreprocessOptions) int {
+	slices := elementWithoutLoadsSlices
+	if element.HasLoadsApplied() {
+		slices = elementWithLoadsSlices
+		if options.LoadedSlices > 0 {
+			slices = options.LoadedSlices
+		}
+	} else if options.UnloadedSlices > 0 {
+		slices = options.UnloadedSlices
 	}
+
+	if options.MaxSliceLength > 0 {
+		lengthSlices := int(gomath.Ceil(element.Length() / options.MaxSliceLength))
+		if lengthSlices > slices {
+			slices = lengthSlices
+		}
+	}
+
+	if slices > maxElementSlices {
+		return maxElementSlices
+	}
+
+	return slices
 }
@@ -170,6 +170,89 @@ func TestMultipleLoadsNotAddingPositionTwice(t *testing.T) {
 	}
 }
 
+func TestExtraPositionsAddedToSlicePositions(t *testing.T) {
+	var (
+		loads = []*load.ConcentratedLoad{
+			load.MakeConcentrated(load.FY, true, nums.MakeTParam(0.75), 45.0),
+		}
+		tPos = sliceLoadedElementPositions(
+			loads,
+			[]*load.DistributedLoad{},
+			2,
+			nums.MakeTParam(0.25),
+			nums.MakeTParam(0.75),
+		)
+	)
+
+	if posCount := len(tPos); posCount != 5 {
+		t.Errorf("Expected 5 positions, got %d", posCount)
+	}
+	if extraPos := tPos[1]; extraPos.Value() != 0.25 {
+		t.Errorf("Expected extra position to be at 0.25, found it at %f", extraPos)
+	}
+}
+
+/* <-- Non Axial Member : slices count --> */
+
+func TestElementSlicesCount(t *testing.T) {
+	var (
+		loadedElement = makeElementWithLoads([]*load.ConcentratedLoad{
+			load.MakeConcentrated(load.FY, true, nums.HalfT, 45.0),
+		})
+		unloadedElement = makeElementWithoutLoads()
+	)
+
+	t.Run("uses the default number of slices", func(t *testing.T) {
+		options := &PreprocessOptions{}
+
+		if got := elementSlicesCount(loadedElement, options); got != elementWithLoadsSlices {
+			t.Errorf("Want %d slices, got %d", elementWithLoadsSlices, got)
+		}
+		if got := elementSlicesCount(unloadedElement, options); got != elementWithoutLoadsSlices {
+			t.Errorf("Want %d slices, got %d", elementWithoutLoadsSlices, got)
+		}
+	})
+
+	t.Run("uses the number of slices in the options", func(t *testing.T) {
+		options := &PreprocessOptions{LoadedSlices: 4, UnloadedSlices: 2}
+
+		if got := elementSlicesCount(loadedElement, options); got != 4 {
+			t.Errorf("Want 4 slices, got %d", got)
+		}
+		if got := elementSlicesCount(unloadedElement, options); got != 2 {
+			t.Errorf("Want 2 slices, got %d", got)
+		}
+	})
+
+	t.Run("limits the length of the slices", func(t *testing.T) {
+		// The elements are 2√2 long
+		options := &PreprocessOptions{MaxSliceLength: 0.1}
+
+		if got := elementSlicesCount(loadedElement, options); got != 29 {
+			t.Errorf("Want 29 slices, got %d", got)
+		}
+		if got := elementSlicesCount(unloadedElement, options); got != 29 {
+			t.Errorf("Want 29 slices, got %d", got)
+		}
+	})
+
+	t.Run("the length limit doesn't reduce the number of slices", func(t *testing.T) {
+		options := &PreprocessOptions{MaxSliceLength: 100.0}
+
+		if got := elementSlicesCount(loadedElement, options); got != elementWithLoadsSlices {
+			t.Errorf("Want %d slices, got %d", elementWithLoadsSlices, got)
+		}
+	})
+
+	t.Run("the number of slices is limited", func(t *testing.T) {
+		options := &PreprocessOptions{MaxSliceLength: 1e-6}
+
+		if got := elementSlicesCount(loadedElement, options); got != maxElementSlices {
+			t.Errorf("Want %d slices, got %d", maxElementSlices, got)
+		}
+	})
+}
+
 /* <-- Non Axial Member : Loaded -> loads --> */
 
 func TestDistributedLocalLoadDistribution(t *testing.T) {
@@ -278,6 +361,39 @@ func TestDistributedGlobalLoadDistribution(t *testing.T) {
 	}
 }
 
+func TestPartialDistributedLoadNotAppliedOutsideItsPositions(t *testing.T) {
+	element := structure.MakeElementBuilder(
+		"1",
+	).WithStartNode(
+		structure.MakeFreeNodeAtPosition("1", 0.0, 0.0), &structure.DispConstraint,
+	).WithEndNode(
+		structure.MakeFreeNodeAtPosition("2", 4.0, 0.0), &structure.DispConstraint,
+	).WithSection(
+		structure.MakeUnitSection(),
+	).WithMaterial(
+		structure.MakeUnitMaterial(),
+	).AddDistributedLoads(
+		[]*load.DistributedLoad{
+			load.MakeDistributed(load.FY, true, nums.HalfT, 5.0, nums.MaxT, 5.0),
+		},
+	).Build()
+
+	slicedEl := sliceLoadedElement(element, 2)
+
+	if fy := slicedEl.NodeAt(0).NetLocalFy(); fy != 0.0 {
+		t.Errorf("First node Fy expected to be 0.0, but was %f", fy)
+	}
+	if mz := slicedEl.NodeAt(0).NetLocalMz(); mz != 0.0 {
+		t.Errorf("First node Mz expected to be 0.0, but was %f", mz)
+	}
+	if fy := slicedEl.NodeAt(1).NetLocalFy(); fy != 5.0 {
+		t.Errorf("Second node Fy expected to be 5.0, but was %f", fy)
+	}
+	if fy := slicedEl.NodeAt(2).NetLocalFy(); fy != 5.0 {
+		t.Errorf("Third node Fy expected to be 5.0, but was %f", fy)
+	}
+}
+
 func TestConcentratedLocalLoadDistribution(t *testing.T) {
 	element := structure.MakeElementBuilder(
 		"1",
@@ -340,6 +456,33 @@ func TestConcentratedGlobalLoadDistribution(t *testing.T) {
 	}
 }
 
+func TestConcentratedLoadTooCloseToSlicePositionAppliedToClosestNode(t *testing.T) {
+	element := structure.MakeElementBuilder(
+		"1",
+	).WithStartNode(
+		structure.MakeFreeNodeAtPosition("1", 0.0, 0.0), &structure.DispConstraint,
+	).WithEndNode(
+		structure.MakeFreeNodeAtPosition("2", 4.0, 0.0), &structure.DispConstraint,
+	).WithSection(
+		structure.MakeUnitSection(),
+	).WithMaterial(
+		structure.MakeUnitMaterial(),
+	).AddConcentratedLoads(
+		[]*load.ConcentratedLoad{
+			load.MakeConcentrated(load.FY, true, nums.MakeTParam(0.5005), 5.0),
+		},
+	).Build()
+
+	slicedEl := sliceLoadedElement(element, 2)
+
+	if nodesCount := slicedEl.NodesCount(); nodesCount != 3 {
+		t.Errorf("Expected 3 nodes, got %d", nodesCount)
+	}
+	if fy := slicedEl.NodeAt(1).NetLocalFy(); fy != 5.0 {
+		t.Errorf("Middle node Fy expected to be 5.0, but was %f", fy)
+	}
+}
+
 /* <-- Utils --> */
 
 func makeElementWithLoads(loads []*load.ConcentratedLoad) *structure.Element {
@@ -0,0 +1,104 @@
+package preprocess
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+)
+
+// MakeGeometricStiffnessMatrix assembles the global geometric stiffness matrix from the sliced
+// element's geometric stiffness matrices, given the axial force (positive in tension) in each of
+// the slices of each element. The axial forces are given in the same order as the structure's
+// elements, and for each element, in the same order as its slices.
+//
+// The degrees of freedom of the nodes with an inclined external constraint are in the support's
+// local frame, as in the stiffness matrix. The rows and columns of the constrained degrees of
+// freedom are zero, so that they aren't part of the buckling modes.
+//
+// Panics if the number of axial forces doesn't match the number of elements or slices.
+func (str *Structure) MakeGeometricStiffnessMatrix(axialForces [][]float64) mat.ReadOnlyMatrix {
+	str.ensureAxialForcesCount(axialForces)
+
+	sysMatrix := mat.MakeSparse(str.DofsCount(), str.DofsCount())
+
+	for i, element := range str.Elements() {
+		element.addTermsToGeometricStiffnessMatrix(sysMatrix, axialForces[i])
+	}
+
+	str.rotateInclinedDofsInMatrix(sysMatrix)
+	str.setZeroConstrainedDofsInMatrix(sysMatrix)
+
+	return sysMatrix
+}
+
+// setZeroConstrainedDofsInMatrix sets to zero the rows and columns of the constrained degrees of
+// freedom, so that they aren't part of the eigenvalue problems' modes.
+func (str *Structure) setZeroConstrainedDofsInMatrix(matrix mat.MutableMatrix) {
+	str.forEachConstrainedDof(func(dof int, _ float64) {
+		matrix.SetZeroCol(dof)
+		matrix.SetIdentityRow(dof)
+		matrix.SetValue(dof, dof, 0.0)
+	})
+}
+
+// MakeSecondOrderStiffnessMatrix assembles the global stiffness matrix, as MakeStiffnessMatrix
+// does, adding the geometric stiffness terms of the sliced elements subject to the given axial
+// forces (positive in tension). The axial forces are given in the same order as the structure's
+// elements, and for each element, in the same order as its slices.
+//
+// Panics if the number of axial forces doesn't match the number of elements or slices.
+func (str *Structure) MakeSecondOrderStiffnessMatrix(axialForces [][]float64) mat.ReadOnlyMatrix {
+	str.ensureAxialForcesCount(axialForces)
+
+	return str.makeStiffnessMatrix(axialForces, nil)
+}
+
+func (str *Structure) ensureAxialForcesCount(axialForces [][]float64) {
+	if len(axialForces) != str.ElementsCount() {
+		panic(fmt.Sprintf(
+			"Expected the axial forces of %d elements, got %d", str.ElementsCount(), len(axialForces),
+		))
+	}
+}
+
+// addTermsToGeometricStiffnessMatrix adds the geometric stiffness terms of each of the element's
+// slices, subject to the given axial forces, to the global matrix.
+func (element *Element) addTermsToGeometricStiffnessMatrix(matrix mat.MutableMatrix, axialForces []float64) {
+	if len(axialForces) != element.NodesCount()-1 {
+		panic(fmt.Sprintf(
+			"Element %s: expected the axial forces of %d slices, got %d",
+			element.GetID(), element.NodesCount()-1, len(axialForces),
+		))
+	}
+
+	var (
+		stiffMat                    mat.ReadOnlyMatrix
+		trailNode, leadNode         *Node
+		trailNodeDofs, leadNodeDofs [3]int
+		dofs                        [6]int
+		stiffVal                    float64
+	)
+
+	for i := 1; i < len(element.nodes); i++ {
+		if axialForces[i-1] == 0 {
+			continue
+		}
+
+		trailNode, leadNode = element.nodes[i-1], element.nodes[i]
+		trailNodeDofs, leadNodeDofs = trailNode.DegreesOfFreedomNum(), leadNode.DegreesOfFreedomNum()
+		stiffMat = element.GeometricStiffnessGlobalMat(trailNode.T, leadNode.T, axialForces[i-1])
+		dofs = [6]int{
+			trailNodeDofs[0], trailNodeDofs[1], trailNodeDofs[2],
+			leadNodeDofs[0], leadNodeDofs[1], leadNodeDofs[2],
+		}
+
+		for row := 0; row < stiffMat.Rows(); row++ {
+			for col := 0; col < stiffMat.Cols(); col++ {
+				if stiffVal = stiffMat.Value(row, col); !nums.IsCloseToZero(stiffVal) {
+					matrix.AddToValue(dofs[row], dofs[col], stiffVal)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package preprocess
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// A Hinge releases the rotation of one of the ends of an element's slice: the start end, at the
+// slice's trailing node, or the end, at its leading node. The released end rotates independently
+// of the slice node, as the end of an element with a pinned link does with respect to its
+// structural node, thus it transmits no bending moment.
+//
+// The rotation of the released end is an additional degree of freedom of the hinged system of
+// equations (see MakeHingedSystem), numbered after those of the structure.
+type Hinge struct {
+	ElementID contracts.StrID
+	Slice     int
+	AtEnd     bool
+}
+
+// NodeIndex is the index, in the sliced element's nodes, of the slice node where the hinge is.
+func (h Hinge) NodeIndex() int {
+	if h.AtEnd {
+		return h.Slice + 1
+	}
+
+	return h.Slice
+}
+
+// MakeHingedSystem assembles the system of equations of the structure with the given hinges, for
+// the loads in the load case.
+//
+// The system has a degree of freedom for the released rotation of each hinge, after those of
+// the structure: the i-th hinge's is DofsCount() + i. The equivalent moment of the slice's loads
+// at a hinged end is applied to the hinge's degree of freedom instead of the slice node's. The
+// prescribed displacements of the external constraints aren't included: the constrained degrees
+// of freedom are fixed.
+//
+// Panics if a hinge refers to an element or slice which doesn't exist.
+func (str *Structure) MakeHingedSystem(
+	hinges []Hinge,
+	loadCase string,
+) (mat.ReadOnlyMatrix, vec.ReadOnlyVector) {
+	var (
+		size      = str.DofsCount() + len(hinges)
+		sysMatrix = mat.MakeSparse(size, size)
+		sysVector = vec.Make(size)
+	)
+
+	str.ensureHingesExist(hinges)
+
+	for _, element := range str.Elements() {
+		element.addHingedTermsToStiffnessMatrix(sysMatrix, str.DofsCount(), hinges)
+		element.addTermsToLoadVector(sysVector, loadCase)
+	}
+
+	for i, hinge := range hinges {
+		var (
+			element = str.GetElementById(hinge.ElementID)
+			node    = element.NodeAt(hinge.NodeIndex()).InLoadCase(loadCase)
+			nodeDof = node.DegreesOfFreedomNum()[2]
+			moment  = node.LocalLeftMz()
+		)
+
+		if hinge.AtEnd {
+			moment = node.LocalRightMz()
+		}
+
+		sysVector.SetValue(nodeDof, sysVector.Value(nodeDof)-moment)
+		sysVector.SetValue(str.DofsCount()+i, moment)
+	}
+
+	str.addLinkSpringsToMatrix(sysMatrix)
+	str.rotateInclinedDofsInMatrix(sysMatrix)
+	str.rotateInclinedDofsInVector(sysVector)
+	str.addSpringsToMatrix(sysMatrix)
+	str.addDispConstraintsToMatrix(sysMatrix)
+	str.addDispConstraintsToVector(sysVector, false)
+
+	return sysMatrix, sysVector
+}
+
+// HingedSliceDofs returns the degrees of freedom of the element's slice between the nodes at the
+// given index and the next one, in the system of equations with the given hinges (see
+// MakeHingedSystem): the rotation of a hinged end is the hinge's degree of freedom.
+func (str *Structure) HingedSliceDofs(element *Element, slice int, hinges []Hinge) [6]int {
+	return element.sliceDofs(slice, str.DofsCount(), hinges)
+}
+
+func (str *Structure) ensureHingesExist(hinges []Hinge) {
+	for _, hinge := range hinges {
+		element := str.GetElementById(hinge.ElementID)
+		if hinge.Slice < 0 || hinge.Slice >= element.NodesCount()-1 {
+			panic(fmt.Sprintf(
+				"Element %s: can't add a hinge to the slice %d, it has %d slices",
+				hinge.ElementID, hinge.Slice, element.NodesCount()-1,
+			))
+		}
+	}
+}
+
+// addHingedTermsToStiffnessMatrix adds the stiffness terms of each of the element's slices to the
+// global matrix, where the rotations of the hinged ends are the hinges' degrees of freedom,
+// numbered after the given structure's degrees of freedom count.
+func (element *Element) addHingedTermsToStiffnessMatrix(
+	matrix mat.MutableMatrix,
+	dofsCount int,
+	hinges []Hinge,
+) {
+	var (
+		stiffMat mat.ReadOnlyMatrix
+		dofs     [6]int
+		stiffVal float64
+	)
+
+	for i := 1; i < len(element.nodes); i++ {
+		stiffMat = element.StiffnessGlobalMat(element.nodes[i-1].T, element.nodes[i].T)
+		dofs = element.sliceDofs(i-1, dofsCount, hinges)
+
+		for row := 0; row < stiffMat.Rows(); row++ {
+			for col := 0; col < stiffMat.Cols(); col++ {
+				if stiffVal = stiffMat.Value(row, col); !nums.IsCloseToZero(stiffVal) {
+					matrix.AddToValue(dofs[row], dofs[col], stiffVal)
+				}
+			}
+		}
+	}
+}
+
+// sliceDofs returns the degrees of freedom of the slice between the nodes at the given index and
+// the next one, where the rotations of the hinged ends are the hinges' degrees of freedom.
+func (element *Element) sliceDofs(slice, dofsCount int, hinges []Hinge) [6]int {
+	var (
+		trailNodeDofs = element.nodes[slice].DegreesOfFreedomNum()
+		leadNodeDofs  = element.nodes[slice+1].DegreesOfFreedomNum()
+		dofs          = [6]int{
+			trailNodeDofs[0], trailNodeDofs[1], trailNodeDofs[2],
+			leadNodeDofs[0], leadNodeDofs[1], leadNodeDofs[2],
+		}
+	)
+
+	for i, hinge := range hinges {
+		if hinge.ElementID != element.GetID() || hinge.Slice != slice {
+			continue
+		}
+
+		if hinge.AtEnd {
+			dofs[5] = dofsCount + i
+		} else {
+			dofs[2] = dofsCount + i
+		}
+	}
+
+	return dofs
+}
@@ -0,0 +1,84 @@
+package preprocess
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHingedSystem(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		nodeOne = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("n2", g2d.MakePoint(300, 0), &structure.FullConstraint)
+		bar     = structure.MakeElementBuilder("b1").
+			WithStartNode(nodeOne, &structure.FullConstraint).
+			WithEndNode(nodeTwo, &structure.FullConstraint).
+			WithMaterial(structure.MakeUnitMaterial()).
+			WithSection(structure.MakeUnitSection()).
+			AddDistributedLoads([]*load.DistributedLoad{
+				load.MakeDistributed(load.FY, true, nums.MinT, -1.0, nums.MaxT, -1.0),
+			}).
+			Build()
+		str = StructureModel(
+			structure.Make(
+				structure.StrMetadata{MajorVersion: 2, MinorVersion: 3},
+				map[contracts.StrID]*structure.Node{"n1": nodeOne, "n2": nodeTwo},
+				[]*structure.Element{bar},
+			),
+			&PreprocessOptions{},
+		)
+		element = str.Elements()[0]
+		hinges  = []Hinge{
+			{ElementID: "b1", Slice: 0, AtEnd: false},
+			{ElementID: "b1", Slice: 4, AtEnd: true},
+		}
+		dofsCount = str.DofsCount()
+	)
+
+	t.Run("the hinges add a degree of freedom each", func(t *testing.T) {
+		sysMatrix, sysVector := str.MakeHingedSystem(hinges, load.DefaultCase)
+
+		assert.Equal(t, dofsCount+2, sysMatrix.Rows())
+		assert.Equal(t, dofsCount+2, sysVector.Length())
+	})
+
+	t.Run("the hinged ends' rotation is the hinge's degree of freedom", func(t *testing.T) {
+		var (
+			startDofs = str.HingedSliceDofs(element, 0, hinges)
+			endDofs   = str.HingedSliceDofs(element, 4, hinges)
+			otherDofs = str.HingedSliceDofs(element, 5, hinges)
+		)
+
+		assert.Equal(t, dofsCount, startDofs[2])
+		assert.Equal(t, element.NodeAt(1).DegreesOfFreedomNum()[2], startDofs[5])
+		assert.Equal(t, element.NodeAt(4).DegreesOfFreedomNum()[2], endDofs[2])
+		assert.Equal(t, dofsCount+1, endDofs[5])
+		assert.Equal(t, element.NodeAt(5).DegreesOfFreedomNum()[2], otherDofs[2])
+	})
+
+	t.Run("the hinged ends' load moments are applied to the hinges", func(t *testing.T) {
+		var (
+			_, sysVector = str.MakeHingedSystem(hinges, load.DefaultCase)
+			node         = element.NodeAt(5)
+			nodeDof      = node.DegreesOfFreedomNum()[2]
+		)
+
+		assert.InDelta(t, element.NodeAt(0).LocalLeftMz(), sysVector.Value(dofsCount), 1e-10)
+		assert.InDelta(t, node.LocalRightMz(), sysVector.Value(dofsCount+1), 1e-10)
+		assert.InDelta(t, node.LocalLeftMz(), sysVector.Value(nodeDof), 1e-10)
+	})
+
+	t.Run("panics if the slice doesn't exist", func(t *testing.T) {
+		assert.Panics(t, func() {
+			str.MakeHingedSystem([]Hinge{{ElementID: "b1", Slice: element.NodesCount() - 1}}, load.DefaultCase)
+		})
+	})
+}
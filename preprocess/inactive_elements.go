@@ -0,0 +1,86 @@
+package preprocess
+
+import (
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// An inactive element is removed from the structure's system of equations: it adds no stiffness,
+// as a slack cable or a bracing rod in compression. The loads of its nodes are still applied to
+// the degrees of freedom shared with the structural nodes, but the degrees of freedom which only
+// the inactive element has, those of its middle nodes and released ends, are fixed.
+
+// MakeActiveStiffnessMatrix assembles the global stiffness matrix, as MakeStiffnessMatrix does,
+// without the stiffness terms of the elements in the inactive set, nor those of their links'
+// springs. The degrees of freedom which only the inactive elements have are fixed: their rows are
+// the identity.
+func (str *Structure) MakeActiveStiffnessMatrix(inactive map[contracts.StrID]bool) mat.ReadOnlyMatrix {
+	return str.makeStiffnessMatrix(nil, inactive)
+}
+
+// MakeActiveLoadVector assembles the global loads vector in the given load case, as MakeLoadVector
+// does, for the structure without the elements in the inactive set. The free values of the
+// degrees of freedom which only the inactive elements have are zero.
+func (str *Structure) MakeActiveLoadVector(
+	loadCase string,
+	inactive map[contracts.StrID]bool,
+) vec.ReadOnlyVector {
+	return str.makeLoadVector(loadCase, true, inactive)
+}
+
+// addInactiveDofsToMatrix sets the rows of the degrees of freedom which only the inactive
+// elements have as the identity, as addDispConstraintsToMatrix does with the constrained ones.
+func (str *Structure) addInactiveDofsToMatrix(matrix mat.MutableMatrix, inactive map[contracts.StrID]bool) {
+	for _, dof := range str.inactiveDofs(inactive) {
+		matrix.SetZeroCol(dof)
+		matrix.SetIdentityRow(dof)
+	}
+}
+
+// addInactiveDofsToVector sets to zero the free values of the degrees of freedom which only the
+// inactive elements have.
+func (str *Structure) addInactiveDofsToVector(vector vec.MutableVector, inactive map[contracts.StrID]bool) {
+	for _, dof := range str.inactiveDofs(inactive) {
+		vector.SetZero(dof)
+	}
+}
+
+// inactiveDofs returns the sorted degrees of freedom of the inactive elements' nodes which aren't
+// shared with a structural node.
+func (str *Structure) inactiveDofs(inactive map[contracts.StrID]bool) []int {
+	if len(inactive) == 0 {
+		return nil
+	}
+
+	var (
+		nodeDofs = make(map[int]bool)
+		dofs     []int
+	)
+
+	for _, node := range str.GetAllNodes() {
+		for _, dof := range node.DegreesOfFreedomNum() {
+			nodeDofs[dof] = true
+		}
+	}
+
+	for _, element := range str.Elements() {
+		if !inactive[element.GetID()] {
+			continue
+		}
+
+		for _, node := range element.Nodes() {
+			for _, dof := range node.DegreesOfFreedomNum() {
+				if !nodeDofs[dof] {
+					dofs = append(dofs, dof)
+				}
+			}
+		}
+	}
+
+	sort.Ints(dofs)
+
+	return dofs
+}
@@ -0,0 +1,103 @@
+package preprocess
+
+import (
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// The displacement degrees of freedom of a node with an inclined external constraint are
+// expressed in the support's local frame, so that the constraint restrains them as it does in
+// the global frame. Being T the rotation from the support's local frame to the global frame for
+// the node's displacements, the global displacements are u = T · u', the system's matrix is
+// transformed into Tᵀ · K · T and the vector into Tᵀ · f.
+
+// GlobalDisplacements returns the displacements of the structure in the global frame, given
+// the solution of the system of equations, where the displacements of the nodes with an
+// inclined external constraint are in the support's local frame.
+func (str *Structure) GlobalDisplacements(sysSolution vec.ReadOnlyVector) vec.ReadOnlyVector {
+	inclinedNodes := str.inclinedNodes()
+	if len(inclinedNodes) == 0 {
+		return sysSolution
+	}
+
+	displacements := vec.Make(sysSolution.Length())
+	for i := 0; i < sysSolution.Length(); i++ {
+		displacements.SetValue(i, sysSolution.Value(i))
+	}
+
+	for _, node := range inclinedNodes {
+		var (
+			dofs   = node.DegreesOfFreedomNum()
+			global = node.ExternalConstraint.RefFrame().ProjectionsToGlobal(
+				sysSolution.Value(dofs[0]),
+				sysSolution.Value(dofs[1]),
+			)
+		)
+
+		displacements.SetValue(dofs[0], global.X())
+		displacements.SetValue(dofs[1], global.Y())
+	}
+
+	return displacements
+}
+
+// rotateInclinedDofsInMatrix transforms the system's matrix into Tᵀ · K · T: the rows and then
+// the columns of the displacement degrees of freedom of each node with an inclined external
+// constraint are rotated to the support's local frame.
+func (str *Structure) rotateInclinedDofsInMatrix(matrix mat.MutableMatrix) {
+	for _, node := range str.inclinedNodes() {
+		var (
+			dofs     = node.DegreesOfFreedomNum()
+			i, j     = dofs[0], dofs[1]
+			frame    = node.ExternalConstraint.RefFrame()
+			cos, sin = frame.Cos(), frame.Sin()
+			indices  = sortedUnique(
+				append(append([]int{i, j}, matrix.NonZeroIndicesAtRow(i)...), matrix.NonZeroIndicesAtRow(j)...),
+			)
+		)
+
+		for _, k := range indices {
+			a, b := matrix.Value(i, k), matrix.Value(j, k)
+			matrix.SetValue(i, k, cos*a+sin*b)
+			matrix.SetValue(j, k, -sin*a+cos*b)
+		}
+
+		for _, k := range indices {
+			a, b := matrix.Value(k, i), matrix.Value(k, j)
+			matrix.SetValue(k, i, cos*a+sin*b)
+			matrix.SetValue(k, j, -sin*a+cos*b)
+		}
+	}
+}
+
+// rotateInclinedDofsInVector transforms the system's vector into Tᵀ · f: the terms of the
+// displacement degrees of freedom of each node with an inclined external constraint are
+// projected to the support's local frame.
+func (str *Structure) rotateInclinedDofsInVector(vector vec.MutableVector) {
+	for _, node := range str.inclinedNodes() {
+		var (
+			dofs  = node.DegreesOfFreedomNum()
+			local = node.ExternalConstraint.RefFrame().ProjectProjections(
+				vector.Value(dofs[0]),
+				vector.Value(dofs[1]),
+			)
+		)
+
+		vector.SetValue(dofs[0], local.X())
+		vector.SetValue(dofs[1], local.Y())
+	}
+}
+
+// inclinedNodes returns the nodes with an inclined external constraint.
+func (str *Structure) inclinedNodes() []*structure.Node {
+	var nodes []*structure.Node
+
+	for _, node := range str.GetAllNodes() {
+		if node.ExternalConstraint.IsInclined() {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
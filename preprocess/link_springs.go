@@ -0,0 +1,132 @@
+package preprocess
+
+import (
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// The released degrees of freedom of a semi-rigid link are numbered apart from the node's, as in
+// a pinned link, and connected to them by the link's springs. Each spring works as a zero-length
+// element between the element's end and the node, whose stiffness terms are added to the
+// system's matrix. This is equivalent to condensing the released degrees of freedom of the
+// element's end into its stiffness matrix.
+
+// addLinkSpringsToMatrix adds the stiffness terms of the elements' semi-rigid links to the
+// system of equations matrix.
+func (str *Structure) addLinkSpringsToMatrix(matrix mat.MutableMatrix) {
+	str.addActiveLinkSpringsToMatrix(matrix, nil)
+}
+
+// addActiveLinkSpringsToMatrix adds the stiffness terms of the semi-rigid links of the elements
+// which aren't in the inactive set to the system of equations matrix.
+func (str *Structure) addActiveLinkSpringsToMatrix(
+	matrix mat.MutableMatrix,
+	inactive map[contracts.StrID]bool,
+) {
+	str.forEachLinkSpring(func(element *Element, elementDofs, nodeDofs [3]int, stiffness [3][3]float64) {
+		if inactive[element.GetID()] {
+			return
+		}
+
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				if k := stiffness[i][j]; k != 0 {
+					matrix.AddToValue(elementDofs[i], elementDofs[j], k)
+					matrix.AddToValue(nodeDofs[i], nodeDofs[j], k)
+					matrix.AddToValue(elementDofs[i], nodeDofs[j], -k)
+					matrix.AddToValue(nodeDofs[i], elementDofs[j], -k)
+				}
+			}
+		}
+	})
+}
+
+// addPrescribedLinkSpringsTerms subtracts from the system's vector the forces of the semi-rigid
+// links' springs due to the prescribed displacements of the given degrees of freedom, as
+// addPrescribedDispTerms does for the elements. A support reached through a link has its
+// prescribed displacements transmitted to the element's end by the springs.
+func (str *Structure) addPrescribedLinkSpringsTerms(
+	vector vec.MutableVector,
+	prescribed map[int]float64,
+	inactive map[contracts.StrID]bool,
+) {
+	str.forEachLinkSpring(func(element *Element, elementDofs, nodeDofs [3]int, stiffness [3][3]float64) {
+		if inactive[element.GetID()] {
+			return
+		}
+
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				k := stiffness[i][j]
+				if k == 0 {
+					continue
+				}
+
+				if value, isPrescribed := prescribed[nodeDofs[j]]; isPrescribed {
+					vector.SetValue(nodeDofs[i], vector.Value(nodeDofs[i])-k*value)
+					vector.SetValue(elementDofs[i], vector.Value(elementDofs[i])+k*value)
+				}
+				if value, isPrescribed := prescribed[elementDofs[j]]; isPrescribed {
+					vector.SetValue(elementDofs[i], vector.Value(elementDofs[i])-k*value)
+					vector.SetValue(nodeDofs[i], vector.Value(nodeDofs[i])+k*value)
+				}
+			}
+		}
+	})
+}
+
+// forEachLinkSpring calls the given function for each element link with springs, with the
+// element, the degrees of freedom of the element's end, those of the node, and the springs' stiffness matrix
+// in global coordinates.
+//
+// The terms of the constrained degrees of freedom, which are shared by the element and the node,
+// cancel out when added to the system's matrix.
+func (str *Structure) forEachLinkSpring(
+	fn func(element *Element, elementDofs, nodeDofs [3]int, stiffness [3][3]float64),
+) {
+	for _, element := range str.Elements() {
+		var (
+			startNode, endNode = str.GetElementNodes(element)
+			startLink, endLink = element.StartLink(), element.EndLink()
+			refFrame           = element.RefFrame()
+		)
+
+		if startLink.HasSprings() {
+			fn(
+				element,
+				element.NodeAt(0).DegreesOfFreedomNum(),
+				startNode.DegreesOfFreedomNum(),
+				linkSpringsStiffness(startLink, refFrame),
+			)
+		}
+
+		if endLink.HasSprings() {
+			fn(
+				element,
+				element.NodeAt(element.NodesCount()-1).DegreesOfFreedomNum(),
+				endNode.DegreesOfFreedomNum(),
+				linkSpringsStiffness(endLink, refFrame),
+			)
+		}
+	}
+}
+
+// linkSpringsStiffness computes the stiffness matrix of a link's springs in global coordinates.
+// The translational springs are in the element's local frame, thus their stiffness is rotated
+// as Rᵀ · diag(kx, ky) · R.
+func linkSpringsStiffness(link *structure.Constraint, refFrame *g2d.RefFrame) [3][3]float64 {
+	var (
+		c, s   = refFrame.Cos(), refFrame.Sin()
+		kx, ky = link.SpringDispX(), link.SpringDispY()
+		kxy    = c * s * (kx - ky)
+	)
+
+	return [3][3]float64{
+		{c*c*kx + s*s*ky, kxy, 0},
+		{kxy, s*s*kx + c*c*ky, 0},
+		{0, 0, link.SpringRotation()},
+	}
+}
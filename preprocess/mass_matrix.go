@@ -0,0 +1,113 @@
+package preprocess
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// MakeMassMatrix assembles the global mass matrix from the sliced element's mass matrices,
+// consistent or lumped, and the structure's nodal masses.
+//
+// The material's density is a weight per unit volume, as used by the own weight loads, thus the
+// elements' masses are divided by the given gravity acceleration. A gravity of one takes the
+// density as a mass per unit volume. The nodal masses are already masses.
+//
+// The degrees of freedom of the nodes with an inclined external constraint are in the support's
+// local frame, as in the stiffness matrix. The rows and columns of the constrained degrees of
+// freedom are zero.
+//
+// Panics if the gravity isn't positive.
+func (str *Structure) MakeMassMatrix(lumped bool, gravity float64) mat.ReadOnlyMatrix {
+	if gravity <= 0 {
+		panic(fmt.Sprintf("The gravity acceleration must be positive, got %f", gravity))
+	}
+
+	sysMatrix := mat.MakeSparse(str.DofsCount(), str.DofsCount())
+
+	for _, element := range str.Elements() {
+		element.addTermsToMassMatrix(sysMatrix, lumped, 1.0/gravity)
+	}
+
+	for _, nodalMass := range str.NodalMasses() {
+		dofs := str.GetNodeById(nodalMass.NodeID).DegreesOfFreedomNum()
+
+		sysMatrix.AddToValue(dofs[0], dofs[0], nodalMass.Mass)
+		sysMatrix.AddToValue(dofs[1], dofs[1], nodalMass.Mass)
+		sysMatrix.AddToValue(dofs[2], dofs[2], nodalMass.RotationalInertia)
+	}
+
+	str.rotateInclinedDofsInMatrix(sysMatrix)
+	str.setZeroConstrainedDofsInMatrix(sysMatrix)
+
+	return sysMatrix
+}
+
+// MakeRigidTranslationVector returns the displacements of the system's degrees of freedom when
+// the whole structure translates rigidly the given distances in the global X and Y directions,
+// without rotating. The constrained degrees of freedom are zero.
+//
+// It's the influence vector of a ground motion: the mass matrix times this vector are the
+// inertia forces of a unit acceleration of the supports.
+func (str *Structure) MakeRigidTranslationVector(dx, dy float64) vec.ReadOnlyVector {
+	vector := vec.Make(str.DofsCount())
+
+	for _, element := range str.Elements() {
+		for _, node := range element.Nodes() {
+			dofs := node.DegreesOfFreedomNum()
+			vector.SetValue(dofs[0], dx)
+			vector.SetValue(dofs[1], dy)
+		}
+	}
+
+	str.rotateInclinedDofsInVector(vector)
+	str.forEachConstrainedDof(func(dof int, _ float64) {
+		vector.SetZero(dof)
+	})
+
+	return vector
+}
+
+// MakeDynamicLoadVector assembles the global loads vector in the given load case for the dynamic
+// analyses, where the supports don't move: the terms of the constrained degrees of freedom are
+// zero, ignoring the prescribed displacements.
+func (str *Structure) MakeDynamicLoadVector(loadCase string) vec.ReadOnlyVector {
+	vector := str.makeLoadVector(loadCase, false, nil).(vec.MutableVector)
+
+	str.forEachConstrainedDof(func(dof int, _ float64) {
+		vector.SetZero(dof)
+	})
+
+	return vector
+}
+
+// addTermsToMassMatrix adds the mass terms of each of the element's slices, scaled by the given
+// factor, to the global matrix.
+func (element *Element) addTermsToMassMatrix(matrix mat.MutableMatrix, lumped bool, factor float64) {
+	var (
+		massMat                     mat.ReadOnlyMatrix
+		trailNode, leadNode         *Node
+		trailNodeDofs, leadNodeDofs [3]int
+		dofs                        [6]int
+		massVal                     float64
+	)
+
+	for i := 1; i < len(element.nodes); i++ {
+		trailNode, leadNode = element.nodes[i-1], element.nodes[i]
+		trailNodeDofs, leadNodeDofs = trailNode.DegreesOfFreedomNum(), leadNode.DegreesOfFreedomNum()
+		massMat = element.MassGlobalMat(trailNode.T, leadNode.T, lumped)
+		dofs = [6]int{
+			trailNodeDofs[0], trailNodeDofs[1], trailNodeDofs[2],
+			leadNodeDofs[0], leadNodeDofs[1], leadNodeDofs[2],
+		}
+
+		for row := 0; row < massMat.Rows(); row++ {
+			for col := 0; col < massMat.Cols(); col++ {
+				if massVal = massMat.Value(row, col); massVal != 0 {
+					matrix.AddToValue(dofs[row], dofs[col], factor*massVal)
+				}
+			}
+		}
+	}
+}
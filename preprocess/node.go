@@ -2,8 +2,10 @@ package preprocess
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 	"github.com/angelsolaorbaiceta/inkgeom/g2d"
 	"github.com/angelsolaorbaiceta/inkgeom/nums"
 )
@@ -15,35 +17,52 @@ const unsetDOF = -1
 // This point has a T Parameter associated, external loads applied and degrees of freedom
 // numbering for the global system.
 //
-// The "leftLocalLoad" is the equivalent load, in local coordinates, from the finite element located
-// to the left of the node.
+// The loads are grouped by load case. For each load case, the node has:
 //
-// The "rightLocalLoad" is the equivalent load, in local coordinates, from the finite element located
-// to the right of the node.
+//   - the external load, in local coordinates, applied directly to the node,
+//   - the "left" load: the equivalent load, in local coordinates, from the finite element located
+//     to the left of the node,
+//   - the "right" load: the equivalent load, in local coordinates, from the finite element located
+//     to the right of the node.
+//
+// The load methods of a node refer to the default load case. To work with the loads of other
+// load case, use the InLoadCase method, which returns a view of the node in the given case.
 type Node struct {
-	T                 nums.TParam
-	Position          *g2d.Point
+	T        nums.TParam
+	Position *g2d.Point
+	loadCase string
+	loads    map[string]*nodeLoads
+	dofs     [3]int
+}
+
+// nodeLoads are the loads applied to a node in a load case.
+type nodeLoads struct {
 	externalLocalLoad *math.Torsor
 	leftLocalLoad     *math.Torsor
 	rightLocalLoad    *math.Torsor
-	dofs              [3]int
 }
 
+func makeNilNodeLoads() *nodeLoads {
+	return &nodeLoads{
+		externalLocalLoad: math.MakeNilTorsor(),
+		leftLocalLoad:     math.MakeNilTorsor(),
+		rightLocalLoad:    math.MakeNilTorsor(),
+	}
+}
+
+var nilNodeLoads = makeNilNodeLoads()
+
 // MakeNode creates a new node with given T parameter value, position and local external
-// loads {fx, fy, mz}.
+// loads {fx, fy, mz} in the default load case.
 func MakeNode(
 	t nums.TParam,
 	position *g2d.Point,
 	fx, fy, mz float64,
 ) *Node {
-	return &Node{
-		T:                 t,
-		Position:          position,
-		externalLocalLoad: math.MakeTorsor(fx, fy, mz),
-		leftLocalLoad:     math.MakeNilTorsor(),
-		rightLocalLoad:    math.MakeNilTorsor(),
-		dofs:              [3]int{unsetDOF, unsetDOF, unsetDOF},
-	}
+	node := MakeUnloadedNode(t, position)
+	node.AddLocalExternalLoad(math.MakeTorsor(fx, fy, mz))
+
+	return node
 }
 
 // MakeNodeWithDofs creates a new node with given T parameter value, position and the
@@ -59,71 +78,135 @@ func MakeNodeWithDofs(
 	position *g2d.Point,
 	dofs [3]int,
 ) *Node {
-	return &Node{
-		T:                 t,
-		Position:          position,
-		externalLocalLoad: math.MakeNilTorsor(),
-		leftLocalLoad:     math.MakeNilTorsor(),
-		rightLocalLoad:    math.MakeNilTorsor(),
-		dofs:              dofs,
-	}
+	node := MakeUnloadedNode(t, position)
+	node.dofs = dofs
+
+	return node
 }
 
 // MakeUnloadedNode creates a new node with given T parameter value, position, and no loads applied.
 func MakeUnloadedNode(t nums.TParam, position *g2d.Point) *Node {
 	return &Node{
-		T:                 t,
-		Position:          position,
-		externalLocalLoad: math.MakeNilTorsor(),
-		leftLocalLoad:     math.MakeNilTorsor(),
-		rightLocalLoad:    math.MakeNilTorsor(),
-		dofs:              [3]int{unsetDOF, unsetDOF, unsetDOF},
+		T:        t,
+		Position: position,
+		loadCase: load.DefaultCase,
+		loads:    make(map[string]*nodeLoads),
+		dofs:     [3]int{unsetDOF, unsetDOF, unsetDOF},
 	}
 }
 
+// InLoadCase returns a view of the node where the load methods refer to the given load case.
+// Loads added to the view are added to the node.
+//
+// The view copies the degrees of freedom numbers the node has at the moment of its creation.
+func (n *Node) InLoadCase(loadCase string) *Node {
+	return &Node{
+		T:        n.T,
+		Position: n.Position,
+		loadCase: loadCase,
+		loads:    n.loads,
+		dofs:     n.dofs,
+	}
+}
+
+// LoadCase is the load case the load methods of the node refer to.
+func (n Node) LoadCase() string {
+	return n.loadCase
+}
+
+// LoadCases returns the names of the load cases with loads applied to the node, sorted
+// alphabetically with the default case first.
+func (n Node) LoadCases() []string {
+	loadCases := make([]string, 0, len(n.loads))
+	for loadCase := range n.loads {
+		loadCases = append(loadCases, loadCase)
+	}
+
+	return load.SortLoadCases(loadCases)
+}
+
+// caseLoads returns the node loads in the node's current load case.
+func (n Node) caseLoads() *nodeLoads {
+	if loads, exists := n.loads[n.loadCase]; exists {
+		return loads
+	}
+
+	return nilNodeLoads
+}
+
+// mutableCaseLoads returns the node loads in the node's current load case, creating them if
+// there weren't loads in the case.
+func (n *Node) mutableCaseLoads() *nodeLoads {
+	loads, exists := n.loads[n.loadCase]
+	if !exists {
+		loads = makeNilNodeLoads()
+		n.loads[n.loadCase] = loads
+	}
+
+	return loads
+}
+
 // NetLocalTorsor returns the resulting torsor of adding the external loads and the loads added
 // by the left and right finite elements, all projected in local coordinates.
 func (n Node) NetLocalTorsor() *math.Torsor {
-	return n.externalLocalLoad.Plus(n.leftLocalLoad).Plus(n.rightLocalLoad)
+	loads := n.caseLoads()
+	return loads.externalLocalLoad.Plus(loads.leftLocalLoad).Plus(loads.rightLocalLoad)
+}
+
+// LocalExternalLoad returns the load applied directly to the node, in local coordinates.
+func (n Node) LocalExternalLoad() *math.Torsor {
+	return n.caseLoads().externalLocalLoad
+}
+
+// LocalLeftLoad returns the equivalent load from the finite element to the left of the node,
+// in local coordinates.
+func (n Node) LocalLeftLoad() *math.Torsor {
+	return n.caseLoads().leftLocalLoad
+}
+
+// LocalRightLoad returns the equivalent load from the finite element to the right of the node,
+// in local coordinates.
+func (n Node) LocalRightLoad() *math.Torsor {
+	return n.caseLoads().rightLocalLoad
 }
 
 // NetLocalFx returns the magnitude of the net force in X, projected in local coordinates.
 func (n Node) NetLocalFx() float64 {
-	return n.externalLocalLoad.Fx() + n.leftLocalLoad.Fx() + n.rightLocalLoad.Fx()
+	return n.caseLoads().externalLocalLoad.Fx() + n.LocalLeftFx() + n.LocalRightFx()
 }
 
 func (n Node) LocalLeftFx() float64 {
-	return n.leftLocalLoad.Fx()
+	return n.caseLoads().leftLocalLoad.Fx()
 }
 
 func (n Node) LocalRightFx() float64 {
-	return n.rightLocalLoad.Fx()
+	return n.caseLoads().rightLocalLoad.Fx()
 }
 
 // NetLocalFy returns the magnitude of the net force in Y, projected in local coordinates.
 func (n Node) NetLocalFy() float64 {
-	return n.externalLocalLoad.Fy() + n.LocalLeftFy() + n.LocalRightFy()
+	return n.caseLoads().externalLocalLoad.Fy() + n.LocalLeftFy() + n.LocalRightFy()
 }
 
 func (n Node) LocalLeftFy() float64 {
-	return n.leftLocalLoad.Fy()
+	return n.caseLoads().leftLocalLoad.Fy()
 }
 
 func (n Node) LocalRightFy() float64 {
-	return n.rightLocalLoad.Fy()
+	return n.caseLoads().rightLocalLoad.Fy()
 }
 
 // NetLocalMz returns the magnitude of the net moment about Z, projected in local coordinates.
 func (n Node) NetLocalMz() float64 {
-	return n.externalLocalLoad.Mz() + n.LocalLeftMz() + n.LocalRightMz()
+	return n.caseLoads().externalLocalLoad.Mz() + n.LocalLeftMz() + n.LocalRightMz()
 }
 
 func (n Node) LocalLeftMz() float64 {
-	return n.leftLocalLoad.Mz()
+	return n.caseLoads().leftLocalLoad.Mz()
 }
 
 func (n Node) LocalRightMz() float64 {
-	return n.rightLocalLoad.Mz()
+	return n.caseLoads().rightLocalLoad.Mz()
 }
 
 // NetLocalLoadTorsor returns the torsor of net load values {Fx, Fy, Mz} projected in
@@ -170,46 +253,80 @@ func (n *Node) DistanceTo(other *Node) float64 {
 
 // AddLocalExternalLoad adds the given load values to the externally applied load.
 func (n *Node) AddLocalExternalLoad(loadTorsor *math.Torsor) {
-	n.externalLocalLoad = n.externalLocalLoad.Plus(loadTorsor)
+	loads := n.mutableCaseLoads()
+	loads.externalLocalLoad = loads.externalLocalLoad.Plus(loadTorsor)
 }
 
 // AddLocalLeftLoad adds the given load values to the load applied from the finite element where this
 // node is to the left of it (where this node is the element's trailing node).
 func (n *Node) AddLocalLeftLoad(fx, fy, mz float64) {
-	n.leftLocalLoad = n.leftLocalLoad.PlusComponents(fx, fy, mz)
+	loads := n.mutableCaseLoads()
+	loads.leftLocalLoad = loads.leftLocalLoad.PlusComponents(fx, fy, mz)
 }
 
 // AddLocalRightLoad adds the given load values to the load applied from the finite element where this
 // node is to the right of it (where this node is the element's leading node).
 func (n *Node) AddLocalRightLoad(fx, fy, mz float64) {
-	n.rightLocalLoad = n.rightLocalLoad.PlusComponents(fx, fy, mz)
+	loads := n.mutableCaseLoads()
+	loads.rightLocalLoad = loads.rightLocalLoad.PlusComponents(fx, fy, mz)
 }
 
-// Equals returns true if this and the other node equal.
+// Equals returns true if this and the other node equal, including the loads in all load cases.
 func (n *Node) Equals(other *Node) bool {
-	return n.T.Equals(other.T) &&
+	if !(n.T.Equals(other.T) &&
 		n.Position.Equals(other.Position) &&
-		n.externalLocalLoad.Equals(other.externalLocalLoad) &&
-		n.leftLocalLoad.Equals(other.leftLocalLoad) &&
-		n.rightLocalLoad.Equals(other.rightLocalLoad) &&
 		n.dofs[0] == other.dofs[0] &&
 		n.dofs[1] == other.dofs[1] &&
-		n.dofs[2] == other.dofs[2]
+		n.dofs[2] == other.dofs[2]) {
+		return false
+	}
+
+	for _, loadCase := range append(n.LoadCases(), other.LoadCases()...) {
+		var (
+			loads      = n.InLoadCase(loadCase).caseLoads()
+			otherLoads = other.InLoadCase(loadCase).caseLoads()
+		)
+
+		if !(loads.externalLocalLoad.Equals(otherLoads.externalLocalLoad) &&
+			loads.leftLocalLoad.Equals(otherLoads.leftLocalLoad) &&
+			loads.rightLocalLoad.Equals(otherLoads.rightLocalLoad)) {
+			return false
+		}
+	}
+
+	return true
 }
 
 // String representation of the node.
 // This method is used for serialization, thus if the format is changed, the preprocessed
 // file format might be affected.
+//
+// The loads of the default load case are always included. The loads of other load cases
+// follow, with the name of the case after the "@" symbol.
 func (n Node) String() string {
-	return fmt.Sprintf(
-		"%f : %f %f\n\text   : %s\n\tleft  : %s\n\tright : %s\n\tnet   : %s\n\tdof   : %v",
-		n.T.Value(),
-		n.Position.X(),
-		n.Position.Y(),
-		n.externalLocalLoad,
-		n.leftLocalLoad,
-		n.rightLocalLoad,
-		n.NetLocalLoadTorsor(),
-		n.DegreesOfFreedomNum(),
-	)
+	var str strings.Builder
+
+	str.WriteString(fmt.Sprintf("%f : %f %f", n.T.Value(), n.Position.X(), n.Position.Y()))
+
+	writeCaseLoads := func(node *Node, suffix string) {
+		str.WriteString(fmt.Sprintf(
+			"\n\text%[1]s   : %[2]s\n\tleft%[1]s  : %[3]s\n\tright%[1]s : %[4]s\n\tnet%[1]s   : %[5]s",
+			suffix,
+			node.LocalExternalLoad(),
+			node.LocalLeftLoad(),
+			node.LocalRightLoad(),
+			node.NetLocalLoadTorsor(),
+		))
+	}
+
+	writeCaseLoads(n.InLoadCase(load.DefaultCase), "")
+	for _, loadCase := range n.LoadCases() {
+		if loadCase != load.DefaultCase {
+			writeCaseLoads(n.InLoadCase(loadCase), "@"+loadCase)
+		}
+	}
+
+	str.WriteString(fmt.Sprintf("\n\tdof   : %v", n.DegreesOfFreedomNum()))
+
+	return str.String()
 }
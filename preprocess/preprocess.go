@@ -2,7 +2,9 @@ package preprocess
 
 import (
 	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
 )
 
 // PreprocessOptions contains the options to configure the preprocessing of the
@@ -11,6 +13,33 @@ type PreprocessOptions struct {
 	// IncludeOwnWeight indicates whether the weight of each bar should be included
 	// as a distributed load.
 	IncludeOwnWeight bool
+
+	// DofNumbering is the strategy used to number the degrees of freedom. If not set, the
+	// geometric numbering is used.
+	DofNumbering DofNumbering
+
+	// LoadedSlices is the number of slices of the bars with loads applied. If not set, they're
+	// sliced into 10 slices.
+	LoadedSlices int
+
+	// UnloadedSlices is the number of slices of the non-axial bars without loads applied. If not
+	// set, they're sliced into 6 slices.
+	UnloadedSlices int
+
+	// MaxSliceLength is the maximum length of the slices, in the structure's units. Bars are
+	// sliced into more slices than their type's number if needed. If not set, the length of the
+	// slices isn't limited. Axial bars aren't sliced regardless of their length.
+	MaxSliceLength float64
+
+	// Adaptive indicates whether the slices are refined where the bending moment's linear
+	// interpolation between the nodes isn't accurate enough, using the results of a first
+	// solve. See AdaptiveTolerance.
+	Adaptive bool
+
+	// AdaptiveTolerance is the largest error of the bending moment's linear interpolation in a
+	// slice, relative to the largest bending moment in the structure, allowed in the adaptive
+	// mode. If not set, DefaultAdaptiveTolerance is used.
+	AdaptiveTolerance float64
 }
 
 // StructureModel preprocesses the structure by concurrently slicing each of the
@@ -21,6 +50,28 @@ type PreprocessOptions struct {
 // The passed in options are used to configure the preprocessing.
 // See the PreprocessOptions struct for more information.
 func StructureModel(str *structure.Structure, options *PreprocessOptions) *Structure {
+	if options.IncludeOwnWeight {
+		for _, element := range str.Elements() {
+			element.AddOwnWeight()
+		}
+	}
+
+	preStructure := sliceStructure(str, options, nil)
+
+	if options.Adaptive {
+		preStructure = refineSlices(str, options, preStructure)
+	}
+
+	return preStructure
+}
+
+// sliceStructure concurrently slices each of the structure's bars, including the extra t
+// positions of each bar, and numbers the degrees of freedom of the resulting structure.
+func sliceStructure(
+	str *structure.Structure,
+	options *PreprocessOptions,
+	extraTPos map[contracts.StrID][]nums.TParam,
+) *Structure {
 	var (
 		numOfBars      = str.ElementsCount()
 		channel        = make(chan *Element, numOfBars)
@@ -32,11 +83,7 @@ func StructureModel(str *structure.Structure, options *PreprocessOptions) *Struc
 	)
 
 	for _, element := range str.Elements() {
-		if options.IncludeOwnWeight {
-			element.AddOwnWeight()
-		}
-
-		go sliceElement(element, channel)
+		go sliceElement(element, options, extraTPos[element.GetID()], channel)
 	}
 
 	for i := 0; i < numOfBars; i++ {
@@ -44,10 +91,14 @@ func StructureModel(str *structure.Structure, options *PreprocessOptions) *Struc
 	}
 	close(channel)
 
-	return MakeStructure(
+	preStructure := MakeStructure(
 		metadata,
 		str.NodesById.Copy(),
 		slicedElements,
 		options.IncludeOwnWeight,
-	).AssignDof()
+	)
+	preStructure.SetLoadCombinations(str.LoadCombinations())
+	preStructure.SetNodalMasses(str.NodalMasses())
+
+	return preStructure.AssignDof().RenumberDof(options.DofNumbering)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/angelsolaorbaiceta/inkfem/contracts"
 	"github.com/angelsolaorbaiceta/inkfem/math"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 	"github.com/angelsolaorbaiceta/inkgeom/g2d"
 	"github.com/angelsolaorbaiceta/inkgeom/nums"
 	"github.com/stretchr/testify/assert"
@@ -147,3 +148,47 @@ func TestPreprocessStructureWithoutLoads(t *testing.T) {
 		})
 	})
 }
+
+func TestLoadVectorAddsTheLoadsOfBarsSharingANode(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		qy       = -10.0
+		barLoads = func() []*load.DistributedLoad {
+			return []*load.DistributedLoad{load.MakeDistributed(load.FY, true, nums.MinT, qy, nums.MaxT, qy)}
+		}
+		nodeOne = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("n2", g2d.MakePoint(100, 0), &structure.NilConstraint)
+		nodeThr = structure.MakeNode("n3", g2d.MakePoint(200, 0), &structure.NilConstraint)
+		barOne  = structure.MakeElementBuilder("b1").
+			WithStartNode(nodeOne, &structure.FullConstraint).
+			WithEndNode(nodeTwo, &structure.FullConstraint).
+			WithMaterial(structure.MakeUnitMaterial()).
+			WithSection(structure.MakeUnitSection()).
+			AddDistributedLoads(barLoads()).
+			Build()
+		barTwo = structure.MakeElementBuilder("b2").
+			WithStartNode(nodeTwo, &structure.FullConstraint).
+			WithEndNode(nodeThr, &structure.FullConstraint).
+			WithMaterial(structure.MakeUnitMaterial()).
+			WithSection(structure.MakeUnitSection()).
+			AddDistributedLoads(barLoads()).
+			Build()
+		meta = structure.StrMetadata{MajorVersion: 2, MinorVersion: 3}
+		str  = structure.Make(meta, map[contracts.StrID]*structure.Node{
+			"n1": nodeOne,
+			"n2": nodeTwo,
+			"n3": nodeThr,
+		}, []*structure.Element{barOne, barTwo})
+		result = StructureModel(str, &PreprocessOptions{})
+		vector = result.MakeLoadVector(load.DefaultCase)
+		dofs   = result.GetNodeById("n2").DegreesOfFreedomNum()
+		// Each bar's end node carries half the load of its end slice
+		wantFy = qy * 100.0 / elementWithLoadsSlices
+	)
+
+	// The moments of both bars' end nodes cancel out
+	assert.InDelta(t, 0.0, vector.Value(dofs[0]), 1e-10)
+	assert.InDelta(t, wantFy, vector.Value(dofs[1]), 1e-10)
+	assert.InDelta(t, 0.0, vector.Value(dofs[2]), 1e-10)
+}
@@ -20,23 +20,22 @@ func sliceAxialElement(element *structure.Element) *Element {
 		panic("Expected an axial element")
 	}
 
-	if element.HasLoadsApplied() {
-		sFx, sFy, eFx, eFy := netNodalLoadValues(element.ConcentratedLoads, element.RefFrame())
+	var (
+		startNode = MakeUnloadedNode(nums.MinT, element.StartPoint())
+		endNode   = MakeUnloadedNode(nums.MaxT, element.EndPoint())
+	)
 
-		return MakeElement(
-			element,
-			[]*Node{
-				MakeNode(nums.MinT, element.StartPoint(), sFx, sFy, 0.0),
-				MakeNode(nums.MaxT, element.EndPoint(), eFx, eFy, 0.0),
-			})
+	for _, ld := range element.ConcentratedLoads {
+		sFx, sFy, eFx, eFy := netNodalLoadValues([]*load.ConcentratedLoad{ld}, element.RefFrame())
+
+		startNode.InLoadCase(ld.LoadCase).AddLocalExternalLoad(math.MakeTorsor(sFx, sFy, 0.0))
+		endNode.InLoadCase(ld.LoadCase).AddLocalExternalLoad(math.MakeTorsor(eFx, eFy, 0.0))
 	}
 
-	return MakeElement(
-		element,
-		[]*Node{
-			MakeUnloadedNode(nums.MinT, element.StartPoint()),
-			MakeUnloadedNode(nums.MaxT, element.EndPoint()),
-		})
+	nodes := []*Node{startNode, endNode}
+	applyThermalLoadsToNodes(element, nodes)
+
+	return MakeElement(element, nodes)
 }
 
 // NetNodalLoadValues computes the net, locally projected loads at the start end (sFx & sFy) and at
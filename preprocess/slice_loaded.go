@@ -20,13 +20,21 @@ import (
 //
 // The positions where distributed loads start and end also introduce discontinuities,
 // so we also include nodes in those positions.
-func sliceLoadedElement(element *structure.Element, slices int) *Element {
+//
+// The extra t positions, like those added when refining the slices, are also included.
+func sliceLoadedElement(element *structure.Element, slices int, extraTPos ...nums.TParam) *Element {
 	var (
-		tPos  = sliceLoadedElementPositions(element.ConcentratedLoads, element.DistributedLoads, slices)
+		tPos = sliceLoadedElementPositions(
+			element.ConcentratedLoads,
+			element.DistributedLoads,
+			slices,
+			extraTPos...,
+		)
 		nodes = makeNodesWithConcentratedLoads(element, tPos)
 	)
 
 	applyDistributedLoadsToNodes(nodes, element.DistributedLoads)
+	applyThermalLoadsToNodes(element, nodes)
 
 	return MakeElement(element, nodes)
 }
@@ -34,23 +42,33 @@ func sliceLoadedElement(element *structure.Element, slices int) *Element {
 // Computes all the t values where to slice an element with loads applied.
 //
 // It starts by slicing the element a given number of times, and then adds all
-// the load start and end t values, removing any possible duplications.
+// the load start and end t values and the extra t values, removing any possible
+// duplications.
+//
+// The positions where a concentrated load is applied might be removed: the load is then
+// applied to the closest node by the makeNodesWithConcentratedLoads function.
 func sliceLoadedElementPositions(
 	concentratedLoads []*load.ConcentratedLoad,
 	distributedLoads []*load.DistributedLoad,
 	slices int,
+	extraTPos ...nums.TParam,
 ) []nums.TParam {
 	tPos := nums.SubTParamCompleteRangeTimes(slices)
 	tPos = append(tPos, slicePositionsForConcentratedLoads(concentratedLoads)...)
 	tPos = append(tPos, slicePositionsForDistributedLoads(distributedLoads)...)
+	tPos = append(tPos, extraTPos...)
 
+	return sortedSlicePositions(tPos)
+}
+
+// sortedSlicePositions sorts the t positions and removes those closer than the minimum
+// distance between slices to the previous one.
+func sortedSlicePositions(tPos []nums.TParam) []nums.TParam {
 	sort.Sort(nums.ByTParamValue(tPos))
 
 	var correctedTPos []nums.TParam
 	correctedTPos = append(correctedTPos, tPos[0])
 
-	// FIXME: this might remove positions where a concentrated load is applied, then,
-	// the load will never be applied by the makeNodesWithConcentratedLoads function.
 	for i := 1; i < len(tPos); i++ {
 		if tPos[i-1].DistanceTo(tPos[i]) > minDistBetweenTSlices {
 			correctedTPos = append(correctedTPos, tPos[i])
@@ -93,7 +111,9 @@ func slicePositionsForDistributedLoads(loads []*load.DistributedLoad) []nums.TPa
 }
 
 // MakeNodesWithConcentratedLoads creates all the nodes for the given t positions and applies the
-// concentrated loads on those t positions where one is defined.
+// concentrated loads on the node closest to their t position, each in its load case. The closest
+// node is the one at the load's position unless it was too close to another slice position and
+// removed (see sliceLoadedElementPositions).
 //
 // If the load is in global coordinates, its vector representation is projected into the element's
 // local reference frame.
@@ -104,24 +124,35 @@ func makeNodesWithConcentratedLoads(element *structure.Element, tPos []nums.TPar
 	)
 
 	for i, t := range tPos {
-		node := MakeUnloadedNode(t, element.PointAt(t))
-
-		for _, load := range element.ConcentratedLoads {
-			if t.Equals(load.T) {
-				var localLoadTorsor *math.Torsor
+		nodes[i] = MakeUnloadedNode(t, element.PointAt(t))
+	}
 
-				if load.IsInLocalCoords {
-					localLoadTorsor = load.AsTorsor()
-				} else {
-					localLoadTorsor = load.AsTorsorProjectedTo(elemRefFrame)
-				}
+	for _, load := range element.ConcentratedLoads {
+		var localLoadTorsor *math.Torsor
 
-				node.AddLocalExternalLoad(localLoadTorsor)
-			}
+		if load.IsInLocalCoords {
+			localLoadTorsor = load.AsTorsor()
+		} else {
+			localLoadTorsor = load.AsTorsorProjectedTo(elemRefFrame)
 		}
 
-		nodes[i] = node
+		node := nodes[closestTPosIndex(tPos, load.T)]
+		node.InLoadCase(load.LoadCase).AddLocalExternalLoad(localLoadTorsor)
 	}
 
 	return nodes
 }
+
+// closestTPosIndex returns the index of the sorted t positions closest to the given t.
+func closestTPosIndex(tPos []nums.TParam, t nums.TParam) int {
+	i := sort.Search(len(tPos), func(i int) bool { return !tPos[i].IsLessThan(t) })
+
+	switch {
+	case i == len(tPos):
+		return i - 1
+	case i > 0 && tPos[i-1].DistanceTo(t) < tPos[i].DistanceTo(t):
+		return i - 1
+	default:
+		return i
+	}
+}
@@ -9,16 +9,19 @@ import (
 // specified number of slices.
 //
 // Non-axial elements which have no loads applied are sliced just by subdividing their
-// geometry into a given number of slices, so that the slices have the same length.
-func sliceElementWithoutLoads(element *structure.Element, slices int) *Element {
+// geometry into a given number of slices, so that the slices have the same length. The
+// extra t positions, like those added when refining the slices, are also included.
+func sliceElementWithoutLoads(element *structure.Element, slices int, extraTPos ...nums.TParam) *Element {
 	if element.HasLoadsApplied() {
 		panic("Expected an element without external loads")
 	}
 
-	var (
-		tPos  = nums.SubTParamCompleteRangeTimes(slices)
-		nodes = make([]*Node, len(tPos))
-	)
+	tPos := nums.SubTParamCompleteRangeTimes(slices)
+	if len(extraTPos) > 0 {
+		tPos = sortedSlicePositions(append(tPos, extraTPos...))
+	}
+
+	nodes := make([]*Node, len(tPos))
 
 	for i := 0; i < len(tPos); i++ {
 		nodes[i] = MakeUnloadedNode(tPos[i], element.PointAt(tPos[i]))
@@ -3,7 +3,9 @@ package preprocess
 import (
 	"sort"
 
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 	"github.com/angelsolaorbaiceta/inkmath/mat"
 	"github.com/angelsolaorbaiceta/inkmath/vec"
 )
@@ -20,7 +22,10 @@ type Structure struct {
 	Metadata structure.StrMetadata
 	structure.NodesById
 	ElementsSeq
+	structure.LoadCombinationsSeq
+	structure.NodalMassesSeq
 	dofsCount         int
+	dofNumbering      DofNumbering
 	includesOwnWeight bool
 }
 
@@ -155,58 +160,245 @@ func (str *Structure) AssignDof() *Structure {
 	return str
 }
 
+// LoadCases returns the names of the load cases with loads applied to the structure, sorted
+// alphabetically with the default case first. The default case is always included.
+func (str *Structure) LoadCases() []string {
+	var (
+		loadCases = []string{load.DefaultCase}
+		seen      = map[string]bool{load.DefaultCase: true}
+	)
+
+	for _, element := range str.Elements() {
+		for _, node := range element.Nodes() {
+			for _, loadCase := range node.LoadCases() {
+				if !seen[loadCase] {
+					seen[loadCase] = true
+					loadCases = append(loadCases, loadCase)
+				}
+			}
+		}
+	}
+
+	return load.SortLoadCases(loadCases)
+}
+
 // MakeSystemOfEquations generates the system of equations matrix and vector from the
-// preprocessed structure.
+// preprocessed structure, for the loads in the default load case.
 //
 // It computes each of the sliced element's stiffness matrices and assembles them into one
 // global matrix. It also assembles the global loads vector from the sliced element nodes.
 func (str *Structure) MakeSystemOfEquations() (mat.ReadOnlyMatrix, vec.ReadOnlyVector) {
-	var (
-		sysMatrix = mat.MakeSparse(str.DofsCount(), str.DofsCount())
-		sysVector = vec.Make(str.DofsCount())
-	)
+	return str.MakeStiffnessMatrix(), str.MakeLoadVector(load.DefaultCase)
+}
+
+// MakeStiffnessMatrix assembles the global stiffness matrix from the sliced element's stiffness
+// matrices, with the external constraints applied.
+//
+// The degrees of freedom of the nodes with an inclined external constraint are in the support's
+// local frame (see GlobalDisplacements).
+//
+// The stiffness matrix doesn't depend on the loads, hence the same matrix is used to solve all
+// the load cases.
+func (str *Structure) MakeStiffnessMatrix() mat.ReadOnlyMatrix {
+	return str.makeStiffnessMatrix(nil, nil)
+}
+
+// makeStiffnessMatrix assembles the global stiffness matrix. If the axial forces in the slices
+// of the elements are given, their geometric stiffness terms are added to the matrix. The
+// elements in the inactive set aren't included (see MakeActiveStiffnessMatrix).
+func (str *Structure) makeStiffnessMatrix(
+	axialForces [][]float64,
+	inactive map[contracts.StrID]bool,
+) mat.ReadOnlyMatrix {
+	sysMatrix := mat.MakeSparse(str.DofsCount(), str.DofsCount())
+
+	for i, element := range str.Elements() {
+		if inactive[element.GetID()] {
+			continue
+		}
+
+		element.addTermsToStiffnessMatrix(sysMatrix)
+
+		if axialForces != nil {
+			element.addTermsToGeometricStiffnessMatrix(sysMatrix, axialForces[i])
+		}
+	}
+
+	str.addActiveLinkSpringsToMatrix(sysMatrix, inactive)
+	str.rotateInclinedDofsInMatrix(sysMatrix)
+	str.addSpringsToMatrix(sysMatrix)
+	str.addDispConstraintsToMatrix(sysMatrix)
+	str.addInactiveDofsToMatrix(sysMatrix, inactive)
+
+	return sysMatrix
+}
+
+// MakeLoadVector assembles the global loads vector from the sliced element nodes loads in the
+// given load case, with the external constraints applied.
+//
+// The prescribed displacements of the external constraints, if any, act in the default load case.
+func (str *Structure) MakeLoadVector(loadCase string) vec.ReadOnlyVector {
+	return str.makeLoadVector(loadCase, true, nil)
+}
+
+// makeLoadVector assembles the global loads vector in the given load case. The known terms of the
+// prescribed displacements are moved to the free values only if requested. The elements in the
+// inactive set don't contribute to the prescribed displacements terms, and the degrees of freedom
+// which only they have are zero (see MakeActiveLoadVector).
+func (str *Structure) makeLoadVector(
+	loadCase string,
+	movePrescribedTerms bool,
+	inactive map[contracts.StrID]bool,
+) vec.ReadOnlyVector {
+	sysVector := vec.Make(str.DofsCount())
 
 	for _, element := range str.Elements() {
-		element.setEquationTerms(sysMatrix, sysVector)
+		element.addTermsToLoadVector(sysVector, loadCase)
+	}
+
+	isPrescribedCase := loadCase == load.DefaultCase
+	if isPrescribedCase && movePrescribedTerms {
+		str.addPrescribedDispTerms(sysVector, inactive)
 	}
 
-	str.addDispConstraints(sysMatrix, sysVector)
+	str.rotateInclinedDofsInVector(sysVector)
+	str.addDispConstraintsToVector(sysVector, isPrescribedCase)
+	str.addInactiveDofsToVector(sysVector, inactive)
+
+	return sysVector
+}
+
+// MakeSystemVector transforms a vector with a force term for each of the structure's degrees of
+// freedom, in the global frame, into a free values vector of the system of equations: the terms
+// of the nodes with an inclined external constraint are projected to the support's local frame,
+// and those of the constrained degrees of freedom are zero. This is the transformation applied to
+// the loads in MakeLoadVector, to solve the system of equations for other free values.
+func (str *Structure) MakeSystemVector(globalVector vec.ReadOnlyVector) vec.ReadOnlyVector {
+	sysVector := globalVector.Clone().AsMutable()
+
+	str.rotateInclinedDofsInVector(sysVector)
+	str.addDispConstraintsToVector(sysVector, false)
 
-	return sysMatrix, sysVector
+	return sysVector
 }
 
-// AddDispConstraints sets the node's external constraints in the system of equations
-// matrix and vector.
+// addDispConstraintsToMatrix sets the node's external constraints in the system of equations
+// matrix.
 //
 // A constrained degree of freedom is enforced by setting the corresponding matrix row as the
-// identity, and the associated free value as zero. This yields a trivial equation of the form
-// x = 0, where x is the constrained degree of freedom.
-func (s *Structure) addDispConstraints(matrix mat.MutableMatrix, vector vec.MutableVector) {
+// identity, and the associated free value as the prescribed value (see
+// addDispConstraintsToVector). This yields a trivial equation of the form x = u, where x is the
+// constrained degree of freedom and u its prescribed value, zero unless otherwise stated.
+func (s *Structure) addDispConstraintsToMatrix(matrix mat.MutableMatrix) {
+	s.forEachConstrainedDof(func(dof int, _ float64) {
+		matrix.SetZeroCol(dof)
+		matrix.SetIdentityRow(dof)
+	})
+}
+
+// addSpringsToMatrix adds the stiffness of the external constraints' springs to the diagonal
+// terms of the system of equations matrix.
+func (s *Structure) addSpringsToMatrix(matrix mat.MutableMatrix) {
+	for _, node := range s.GetAllNodes() {
+		if constraint := node.ExternalConstraint; constraint.HasSprings() {
+			dofs := node.DegreesOfFreedomNum()
+
+			matrix.AddToValue(dofs[0], dofs[0], constraint.SpringDispX())
+			matrix.AddToValue(dofs[1], dofs[1], constraint.SpringDispY())
+			matrix.AddToValue(dofs[2], dofs[2], constraint.SpringRotation())
+		}
+	}
+}
+
+// addDispConstraintsToVector sets the free value of the constrained degrees of freedom in the
+// system of equations vector: their prescribed value if the prescribed values are to be
+// included, and zero otherwise.
+func (s *Structure) addDispConstraintsToVector(vector vec.MutableVector, includePrescribed bool) {
+	s.forEachConstrainedDof(func(dof int, prescribedValue float64) {
+		if includePrescribed {
+			vector.SetValue(dof, prescribedValue)
+		} else {
+			vector.SetZero(dof)
+		}
+	})
+}
+
+// addPrescribedDispTerms moves the known terms of the prescribed displacements to the system's
+// vector. As the columns of the constrained degrees of freedom are zeroed in the stiffness
+// matrix, their contribution, K[i][c] · u[c], is subtracted from the free values.
+//
+// The prescribed displacements of inclined constraints are projected to the global frame, as
+// the vector terms are rotated to the supports' local frames afterwards. The springs of the
+// semi-rigid links add their terms too. The elements in the inactive set have no stiffness, thus
+// they add no terms.
+func (s *Structure) addPrescribedDispTerms(vector vec.MutableVector, inactive map[contracts.StrID]bool) {
+	prescribed := make(map[int]float64)
+
+	for _, node := range s.GetAllNodes() {
+		constraint := node.ExternalConstraint
+		if !constraint.HasPrescribedValues() {
+			continue
+		}
+
+		var (
+			dofs = node.DegreesOfFreedomNum()
+			disp = constraint.RefFrame().ProjectionsToGlobal(
+				constraint.PrescribedDispX(),
+				constraint.PrescribedDispY(),
+			)
+		)
+
+		for i, value := range [3]float64{disp.X(), disp.Y(), constraint.PrescribedRotation()} {
+			if value != 0 {
+				prescribed[dofs[i]] = value
+			}
+		}
+	}
+
+	if len(prescribed) == 0 {
+		return
+	}
+
+	for _, element := range s.Elements() {
+		if inactive[element.GetID()] {
+			continue
+		}
+
+		element.addPrescribedDispTermsToLoadVector(vector, prescribed)
+	}
+
+	s.addPrescribedLinkSpringsTerms(vector, prescribed, inactive)
+}
+
+// forEachConstrainedDof calls the given function with each of the degrees of freedom constrained
+// by an external constraint, and its prescribed value.
+//
+// The degrees of freedom of the structural nodes which nothing reaches are fixed too, with a zero
+// value (see unconnectedDofs).
+func (s *Structure) forEachConstrainedDof(fn func(dof int, prescribedValue float64)) {
 	var (
 		constraint *structure.Constraint
 		dofs       [3]int
 	)
 
-	addConstraintAtDof := func(dof int) {
-		matrix.SetZeroCol(dof)
-		matrix.SetIdentityRow(dof)
-		vector.SetZero(dof)
-	}
-
 	for _, node := range s.GetAllNodes() {
 		if node.IsExternallyConstrained() {
 			constraint = node.ExternalConstraint
 			dofs = node.DegreesOfFreedomNum()
 
 			if !constraint.AllowsDispX() {
-				addConstraintAtDof(dofs[0])
+				fn(dofs[0], constraint.PrescribedDispX())
 			}
 			if !constraint.AllowsDispY() {
-				addConstraintAtDof(dofs[1])
+				fn(dofs[1], constraint.PrescribedDispY())
 			}
 			if !constraint.AllowsRotation() {
-				addConstraintAtDof(dofs[2])
+				fn(dofs[2], constraint.PrescribedRotation())
 			}
 		}
 	}
+
+	for _, dof := range s.unconnectedDofs() {
+		fn(dof, 0)
+	}
 }
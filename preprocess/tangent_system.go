@@ -0,0 +1,125 @@
+package preprocess
+
+import (
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// The nonlinear analyses solve the structure's equilibrium, f_int(u) = λ · f_ext, iteratively:
+// in each iteration, the tangent stiffness matrix and the internal forces vector are assembled
+// for the current displacements. The sliced elements' contributions depend on the formulation
+// used by the analysis, whereas the link and external constraint springs remain linear.
+//
+// The constrained degrees of freedom are enforced as in the linear system: the tangent matrix rows
+// are the identity, and the internal forces are the current displacements, so that the residual
+// is the difference between the prescribed and current displacements.
+
+// A SliceStateFunc computes the tangent stiffness matrix and the internal forces, in global
+// coordinates, of the element's slice between the nodes at the given index and the next one.
+type SliceStateFunc func(element *Element, slice int) (mat.ReadOnlyMatrix, [6]float64)
+
+// MakeTangentSystem assembles the tangent stiffness matrix and the internal forces vector of the
+// structure, given the current solution of the system (see GlobalDisplacements) and the function
+// that computes the state of each of the elements' slices.
+//
+// The degrees of freedom of the nodes with an inclined external constraint are in the support's
+// local frame, as in the stiffness matrix.
+func (str *Structure) MakeTangentSystem(
+	sysDispl vec.ReadOnlyVector,
+	sliceState SliceStateFunc,
+) (mat.ReadOnlyMatrix, vec.ReadOnlyVector) {
+	var (
+		sysMatrix   = mat.MakeSparse(str.DofsCount(), str.DofsCount())
+		forces      = vec.Make(str.DofsCount())
+		linkSprings = mat.MakeSparse(str.DofsCount(), str.DofsCount())
+	)
+
+	for _, element := range str.Elements() {
+		element.addTermsToTangentSystem(sysMatrix, forces, sliceState)
+	}
+
+	str.addLinkSpringsToMatrix(sysMatrix)
+	str.addLinkSpringsToMatrix(linkSprings)
+	addToVector(forces, linkSprings.TimesVector(str.GlobalDisplacements(sysDispl)))
+
+	str.rotateInclinedDofsInMatrix(sysMatrix)
+	str.rotateInclinedDofsInVector(forces)
+
+	str.addSpringsToMatrix(sysMatrix)
+	str.addSpringsForcesToVector(forces, sysDispl)
+
+	str.addDispConstraintsToMatrix(sysMatrix)
+	str.forEachConstrainedDof(func(dof int, _ float64) {
+		forces.SetValue(dof, sysDispl.Value(dof))
+	})
+
+	return sysMatrix, forces
+}
+
+// MakeExternalLoadVector assembles the global loads vector in the given load case, as
+// MakeLoadVector does, but without moving the known terms of the prescribed displacements to the
+// free values, as the nonlinear analyses impose them directly (see MakeTangentSystem).
+func (str *Structure) MakeExternalLoadVector(loadCase string) vec.ReadOnlyVector {
+	return str.makeLoadVector(loadCase, false, nil)
+}
+
+// addSpringsForcesToVector adds the forces of the external constraints' springs, the stiffness
+// times the displacement in the support's local frame, to the internal forces vector.
+func (s *Structure) addSpringsForcesToVector(forces vec.MutableVector, sysDispl vec.ReadOnlyVector) {
+	for _, node := range s.GetAllNodes() {
+		if constraint := node.ExternalConstraint; constraint.HasSprings() {
+			dofs := node.DegreesOfFreedomNum()
+			stiffness := [3]float64{
+				constraint.SpringDispX(),
+				constraint.SpringDispY(),
+				constraint.SpringRotation(),
+			}
+
+			for i, dof := range dofs {
+				forces.SetValue(dof, forces.Value(dof)+stiffness[i]*sysDispl.Value(dof))
+			}
+		}
+	}
+}
+
+// addTermsToTangentSystem adds the tangent stiffness terms and internal forces of each of the
+// element's slices to the global matrix and vector.
+func (element *Element) addTermsToTangentSystem(
+	matrix mat.MutableMatrix,
+	forces vec.MutableVector,
+	sliceState SliceStateFunc,
+) {
+	var (
+		trailNodeDofs, leadNodeDofs [3]int
+		dofs                        [6]int
+		stiffVal                    float64
+	)
+
+	for i := 1; i < len(element.nodes); i++ {
+		trailNodeDofs = element.nodes[i-1].DegreesOfFreedomNum()
+		leadNodeDofs = element.nodes[i].DegreesOfFreedomNum()
+		dofs = [6]int{
+			trailNodeDofs[0], trailNodeDofs[1], trailNodeDofs[2],
+			leadNodeDofs[0], leadNodeDofs[1], leadNodeDofs[2],
+		}
+
+		stiffMat, sliceForces := sliceState(element, i-1)
+
+		for row := 0; row < stiffMat.Rows(); row++ {
+			forces.SetValue(dofs[row], forces.Value(dofs[row])+sliceForces[row])
+
+			for col := 0; col < stiffMat.Cols(); col++ {
+				if stiffVal = stiffMat.Value(row, col); !nums.IsCloseToZero(stiffVal) {
+					matrix.AddToValue(dofs[row], dofs[col], stiffVal)
+				}
+			}
+		}
+	}
+}
+
+func addToVector(vector vec.MutableVector, other vec.ReadOnlyVector) {
+	for i := 0; i < vector.Length(); i++ {
+		vector.SetValue(i, vector.Value(i)+other.Value(i))
+	}
+}
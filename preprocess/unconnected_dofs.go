@@ -0,0 +1,60 @@
+package preprocess
+
+import "sort"
+
+// unconnectedDofs returns the sorted degrees of freedom of the structural nodes which neither the
+// elements, nor the springs of their links, nor those of the external constraints reach, and
+// which aren't externally constrained. These are the degrees of freedom released by the links of
+// all the elements in the node, like the rotation of a node where all the bars are pinned.
+//
+// Nothing resists the displacements of these degrees of freedom, which would make the system of
+// equations singular, but nothing loads them either: the loads act on the elements' ends.
+func (str *Structure) unconnectedDofs() []int {
+	var (
+		connected = make(map[int]bool)
+		dofs      []int
+	)
+
+	for _, element := range str.Elements() {
+		for _, node := range []*Node{element.NodeAt(0), element.NodeAt(element.NodesCount() - 1)} {
+			for _, dof := range node.DegreesOfFreedomNum() {
+				connected[dof] = true
+			}
+		}
+	}
+
+	str.forEachLinkSpring(func(_ *Element, _, nodeDofs [3]int, stiffness [3][3]float64) {
+		for i, row := range stiffness {
+			for _, value := range row {
+				if value != 0 {
+					connected[nodeDofs[i]] = true
+				}
+			}
+		}
+	})
+
+	for _, node := range str.GetAllNodes() {
+		if !node.HasDegreesOfFreedomNum() {
+			continue
+		}
+
+		var (
+			constraint = node.ExternalConstraint
+			isFree     = [3]bool{
+				constraint.AllowsDispX() && constraint.SpringDispX() == 0,
+				constraint.AllowsDispY() && constraint.SpringDispY() == 0,
+				constraint.AllowsRotation() && constraint.SpringRotation() == 0,
+			}
+		)
+
+		for i, dof := range node.DegreesOfFreedomNum() {
+			if isFree[i] && !connected[dof] {
+				dofs = append(dofs, dof)
+			}
+		}
+	}
+
+	sort.Ints(dofs)
+
+	return dofs
+}
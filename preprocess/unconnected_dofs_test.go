@@ -0,0 +1,62 @@
+package preprocess
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnconnectedDofs(t *testing.T) {
+	build.ReadBuildInfo()
+
+	t.Run("the rotation of a node where all the bars are pinned", func(t *testing.T) {
+		var (
+			str    = makePinnedInTheMiddleStructure(&structure.DispConstraint)
+			rzDof  = str.GetNodeById("n2").RzDegreeOfFreedomNum()
+			matrix = str.MakeStiffnessMatrix()
+		)
+
+		assert.Equal(t, []int{rzDof}, str.unconnectedDofs())
+		assert.Equal(t, 1.0, matrix.Value(rzDof, rzDof))
+		assert.Equal(t, []int{rzDof}, matrix.NonZeroIndicesAtRow(rzDof))
+	})
+
+	t.Run("a link's spring connects the node", func(t *testing.T) {
+		str := makePinnedInTheMiddleStructure(structure.MakeConstraint(true, true, false).WithSprings(0, 0, 1e3))
+
+		assert.Empty(t, str.unconnectedDofs())
+	})
+}
+
+// makePinnedInTheMiddleStructure makes a beam of two bars, fixed at both ends, whose bars are
+// joined in the middle node: the first is pinned, and the second has the given link.
+func makePinnedInTheMiddleStructure(secondBarLink *structure.Constraint) *Structure {
+	var (
+		nodeOne   = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo   = structure.MakeNode("n2", g2d.MakePoint(100, 0), &structure.NilConstraint)
+		nodeThree = structure.MakeNode("n3", g2d.MakePoint(200, 0), &structure.FullConstraint)
+		barOne    = structure.MakeElementBuilder("b1").
+				WithStartNode(nodeOne, &structure.FullConstraint).
+				WithEndNode(nodeTwo, &structure.DispConstraint).
+				WithMaterial(structure.MakeUnitMaterial()).
+				WithSection(structure.MakeUnitSection()).
+				Build()
+		barTwo = structure.MakeElementBuilder("b2").
+			WithStartNode(nodeTwo, secondBarLink).
+			WithEndNode(nodeThree, &structure.FullConstraint).
+			WithMaterial(structure.MakeUnitMaterial()).
+			WithSection(structure.MakeUnitSection()).
+			Build()
+		str = structure.Make(
+			structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+			map[contracts.StrID]*structure.Node{"n1": nodeOne, "n2": nodeTwo, "n3": nodeThree},
+			[]*structure.Element{barOne, barTwo},
+		)
+	)
+
+	return StructureModel(str, &PreprocessOptions{})
+}
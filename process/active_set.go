@@ -0,0 +1,181 @@
+package process
+
+import (
+	"fmt"
+	gomath "math"
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+const (
+	// activeSetMaxIter is the maximum number of times the structure is solved with a different set
+	// of inactive elements before giving up: the set doesn't stabilize but cycles.
+	activeSetMaxIter = 50
+
+	// negligibleAxialForceRatio is the ratio to the largest axial force in the structure below which
+	// the axial forces are considered zero: a slack element with no force is still valid.
+	negligibleAxialForceRatio = 1e-9
+)
+
+// hasAxialBehaviorLimits returns true if any of the structure's elements is either tension-only
+// or compression-only.
+func hasAxialBehaviorLimits(str *preprocess.Structure) bool {
+	for _, element := range str.Elements() {
+		if element.HasAxialBehaviorLimit() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ensureAxialBehaviorLimitsAreSupported panics if a tension-only or compression-only element
+// isn't an axial member: only the elements pinned in both ends and without loads other than
+// nodal forces can be removed from the structure keeping its loads.
+func ensureAxialBehaviorLimitsAreSupported(str *preprocess.Structure) {
+	for _, element := range str.Elements() {
+		if element.HasAxialBehaviorLimit() && !element.IsAxialMember() {
+			panic(fmt.Sprintf(
+				"Element %s: only the axial members (pinned in both ends and loaded in them) can be %s-only",
+				element.GetID(), element.AxialBehavior(),
+			))
+		}
+	}
+}
+
+// solveActiveSet computes the solution of the elements for the loads in the load case, starting
+// from the solution of the structure with all its elements and its displacements, when some of
+// them are tension-only or compression-only. Returns the solutions, the sorted ids of the inactive
+// elements and the displacements of the last system of equations solved, with its residual.
+//
+// In each iteration, the elements whose axial force isn't allowed by their behavior are
+// deactivated, and the structure without them is solved again (see
+// preprocess.MakeActiveStiffnessMatrix). The axial force of an inactive element is the one it'd
+// have if it were active, given the displacements of its ends, thus an element which would work
+// again is reactivated. The iteration stops when the set of inactive elements doesn't change.
+//
+// The inactive elements have the displacements of the structure, but no stresses. Panics if the
+// set of inactive elements doesn't stabilize.
+func solveActiveSet(
+	str *preprocess.Structure,
+	loadCase string,
+	allActive []*ElementSolution,
+	allActiveDispl *GlobalDisplacementsVector,
+	options SolveOptions,
+) ([]*ElementSolution, []contracts.StrID, *GlobalDisplacementsVector) {
+	var (
+		elements  = allActive
+		inactive  = make(map[contracts.StrID]bool)
+		loadCases = []string{loadCase}
+		displ     = allActiveDispl
+	)
+
+	for iter := 1; iter <= activeSetMaxIter; iter++ {
+		violating := violatingElements(str, elements)
+		if sameElementsSet(violating, inactive) {
+			log.EndActiveSet(loadCase, iter, len(inactive))
+
+			for i, element := range str.Elements() {
+				if inactive[element.GetID()] {
+					elements[i] = makeInactiveElementSolution(element.InLoadCase(loadCase), displ)
+				}
+			}
+
+			return elements, sortedElementIds(inactive), displ
+		}
+
+		inactive = violating
+		log.ActiveSetProgress(loadCase, iter, len(inactive))
+
+		log.StartAssembleSysEqs()
+		var (
+			sysMatrix  = str.MakeActiveStiffnessMatrix(inactive)
+			sysVectors = []vec.ReadOnlyVector{str.MakeActiveLoadVector(loadCase, inactive)}
+		)
+		log.EndAssembleSysEqs(str.DofsCount())
+
+		displ = solveSystems(str, sysMatrix, sysVectors, loadCases, options)[0]
+		elements = makeElementSolutions(str, loadCase, displ)
+	}
+
+	panic(fmt.Sprintf(
+		"The inactive bars of \"%s\" didn't stabilize after %d iterations", loadCase, activeSetMaxIter,
+	))
+}
+
+// violatingElements returns the set of elements whose axial force, in the given solutions, isn't
+// allowed by their axial behavior. The axial force of an element is the average of its slices'.
+func violatingElements(str *preprocess.Structure, elements []*ElementSolution) map[contracts.StrID]bool {
+	var (
+		forces    = sliceAxialForces(elements)
+		maxForce  = 0.0
+		violating = make(map[contracts.StrID]bool)
+	)
+
+	for i := range forces {
+		for _, force := range forces[i] {
+			maxForce = gomath.Max(maxForce, gomath.Abs(force))
+		}
+	}
+
+	for i, element := range str.Elements() {
+		if !element.HasAxialBehaviorLimit() {
+			continue
+		}
+
+		force := 0.0
+		for _, sliceForce := range forces[i] {
+			force += sliceForce / float64(len(forces[i]))
+		}
+
+		if !element.AxialBehavior().AllowsAxialForce(force, negligibleAxialForceRatio*maxForce) {
+			violating[element.GetID()] = true
+		}
+	}
+
+	return violating
+}
+
+// makeInactiveElementSolution creates the solution of an inactive element: its displacements are
+// set from the structure's global displacements, but it has no stresses, as it has no stiffness.
+func makeInactiveElementSolution(
+	element *preprocess.Element,
+	globalDisp *GlobalDisplacementsVector,
+) *ElementSolution {
+	solution := makeDisplacedElementSolution(element, globalDisp)
+
+	for i := 1; i < element.NodesCount(); i++ {
+		solution.appendSliceStresses(element.NodeAt(i-1), element.NodeAt(i), 0, 0, 0, 0, globalDisp.MaxError)
+	}
+
+	return solution
+}
+
+func sameElementsSet(a, b map[contracts.StrID]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for id := range a {
+		if !b[id] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sortedElementIds(set map[contracts.StrID]bool) []contracts.StrID {
+	ids := make([]contracts.StrID, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
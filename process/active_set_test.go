@@ -0,0 +1,72 @@
+package process
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+// This test uses a cantilever beam, from (0, 0) to (100, 0), propped by a tension-only brace from
+// (0, 100). The upwards load at the beam's free end compresses the brace, which is deactivated.
+func TestActiveSetDisplacementsAreTheLastSolved(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		fixedNode = structure.MakeNode("fixed", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		freeNode  = structure.MakeNode("free", g2d.MakePoint(100, 0), &structure.NilConstraint)
+		braceNode = structure.MakeNode("brace", g2d.MakePoint(0, 100), &structure.FullConstraint)
+		beam      = structure.MakeElementBuilder("beam").
+				WithStartNode(fixedNode, &structure.FullConstraint).
+				WithEndNode(freeNode, &structure.FullConstraint).
+				WithMaterial(structure.MakeUnitMaterial()).
+				WithSection(structure.MakeUnitSection()).
+				AddConcentratedLoad(load.MakeConcentrated(load.FY, false, nums.MaxT, 1000)).
+				Build()
+		brace = structure.MakeElementBuilder("brace").
+			WithStartNode(braceNode, &structure.DispConstraint).
+			WithEndNode(freeNode, &structure.DispConstraint).
+			WithMaterial(structure.MakeUnitMaterial()).
+			WithSection(structure.MakeUnitSection()).
+			WithAxialBehavior(structure.TensionOnly).
+			Build()
+		str = preprocess.StructureModel(
+			structure.Make(
+				structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+				map[contracts.StrID]*structure.Node{"fixed": fixedNode, "free": freeNode, "brace": braceNode},
+				[]*structure.Element{beam, brace},
+			),
+			&preprocess.PreprocessOptions{},
+		)
+		loadCases = []string{load.DefaultCase}
+		// An inaccurate solution, whose residual is far from the rounding errors
+		options   = SolveOptions{MaxDisplacementsError: 1.0, Solver: PCGSolver}
+		allActive = computeGlobalDisplacements(str, loadCases, options)[0]
+
+		_, inactive, displ = solveActiveSet(
+			str, load.DefaultCase, makeElementSolutions(str, load.DefaultCase, allActive), allActive, options,
+		)
+	)
+
+	t.Run("the brace is inactive", func(t *testing.T) {
+		assert.Equal(t, []contracts.StrID{"brace"}, inactive)
+	})
+
+	t.Run("the residual is that of the active elements system", func(t *testing.T) {
+		var (
+			inactiveSet = map[contracts.StrID]bool{"brace": true}
+			sysVector   = str.MakeActiveLoadVector(load.DefaultCase, inactiveSet)
+			residual    = str.MakeActiveStiffnessMatrix(inactiveSet).TimesVector(displ.Vector).Minus(sysVector)
+		)
+
+		assert.NotSame(t, allActive, displ)
+		assert.InDelta(t, sysVector.Norm(), displ.LoadNorm, 1e-10)
+		assert.InDelta(t, residual.Norm(), displ.ResidualNorm, 1e-8)
+	})
+}
@@ -0,0 +1,167 @@
+package process
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+)
+
+const (
+	// DefaultEigenMaxError is the maximum relative change of the eigenvalues between iterations
+	// used when none is given.
+	DefaultEigenMaxError = 1e-8
+
+	// DefaultEigenMaxIter is the maximum number of iterations of the eigenvalue solver used when
+	// none is given.
+	DefaultEigenMaxIter = 500
+)
+
+// BucklingOptions includes configuration parameters for the linear buckling analysis.
+//
+// The solve options are used to compute the first-order solution from which the axial forces
+// in the elements are obtained. The loads are those of the given load case or combination, the
+// default case if none is given.
+type BucklingOptions struct {
+	SolveOptions
+	LoadCase   string
+	ModesCount int
+	MaxError   float64
+	MaxIter    int
+}
+
+// A BucklingMode is a critical load factor with its associated mode shape: the loads multiplied
+// by the factor make the structure buckle following the shape.
+type BucklingMode struct {
+	LoadFactor float64
+	Elements   []*ElementModeShape
+}
+
+// BucklingSolution is the result of the linear buckling analysis of a structure for the loads
+// in a load case or combination: the buckling modes sorted by increasing load factor.
+type BucklingSolution struct {
+	Metadata structure.StrMetadata
+	LoadCase string
+	Modes    []*BucklingMode
+}
+
+// SolveBuckling computes the elastic critical load factors, and their buckling mode shapes, of
+// the structure subject to the loads in the load case given in the options.
+//
+// The analysis first solves the structure to obtain the axial forces in its elements, with which
+// the geometric stiffness matrix is assembled. Then, it solves the generalized eigenvalue problem
+// (K + λ·Kg)·φ = 0 for the lowest positive load factors λ. Note that the elements which aren't
+// sliced, like axial members, are modeled with a single finite element, which overestimates
+// their own buckling load.
+//
+// Panics if the structure has no load case or combination with the given name, or the
+// eigenvalue problem can't be solved.
+func SolveBuckling(str *preprocess.Structure, options BucklingOptions) *BucklingSolution {
+	var (
+		loadCase  = bucklingLoadCase(options)
+		firstSol  = solveLoadCaseOrCombination(str, loadCase, options.SolveOptions)
+		stiffness = str.MakeStiffnessMatrix()
+		geometric = str.MakeGeometricStiffnessMatrix(sliceAxialForces(firstSol.Elements))
+		solver    = math.SubspaceEigenSolver{
+			Count:    options.ModesCount,
+			MaxError: options.MaxError,
+			MaxIter:  options.MaxIter,
+		}
+	)
+
+	if solver.MaxError == 0 {
+		solver.MaxError = DefaultEigenMaxError
+	}
+	if solver.MaxIter == 0 {
+		solver.MaxIter = DefaultEigenMaxIter
+	}
+
+	log.StartSolveEigenproblem()
+	pairs, err := solver.Solve(stiffness, negated(geometric))
+	if err != nil {
+		panic(fmt.Sprintf("Can't solve the buckling eigenvalue problem: %s", err))
+	}
+	log.EndSolveEigenproblem("buckling", len(pairs))
+
+	modes := make([]*BucklingMode, len(pairs))
+	for i, pair := range pairs {
+		modes[i] = &BucklingMode{
+			LoadFactor: pair.Value,
+			Elements:   makeModeShapes(str, pair.Vector),
+		}
+	}
+
+	return &BucklingSolution{
+		Metadata: structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		},
+		LoadCase: loadCase,
+		Modes:    modes,
+	}
+}
+
+func bucklingLoadCase(options BucklingOptions) string {
+	if options.LoadCase == "" {
+		return load.DefaultCase
+	}
+
+	return options.LoadCase
+}
+
+// solveLoadCaseOrCombination solves the structure and returns the solution for the load case or
+// combination with the given name. Panics if there isn't such load case or combination.
+func solveLoadCaseOrCombination(
+	str *preprocess.Structure,
+	loadCase string,
+	options SolveOptions,
+) *Solution {
+	solutions := Solve(str, options)
+	solutions = append(solutions, CombineSolutions(solutions, str.LoadCombinations())...)
+
+	for _, solution := range solutions {
+		if solution.LoadCase == loadCase {
+			return solution
+		}
+	}
+
+	panic(fmt.Sprintf("The structure has no load case or combination named '%s'", loadCase))
+}
+
+// sliceAxialForces computes the axial force (positive in tension) in each of the slices of each
+// of the element solutions, as the average of the values at both ends of the slice.
+func sliceAxialForces(elements []*ElementSolution) [][]float64 {
+	forces := make([][]float64, len(elements))
+
+	for i, element := range elements {
+		var (
+			area     = element.Section().Area
+			expanded = sliceEndValues(element.AxialStress, element.Element.Nodes())
+		)
+
+		forces[i] = make([]float64, len(expanded)/2)
+		for j := range forces[i] {
+			forces[i][j] = 0.5 * area * (expanded[2*j].Value + expanded[2*j+1].Value)
+		}
+	}
+
+	return forces
+}
+
+// negated returns a matrix with the same terms as the given one, but with the opposite sign.
+func negated(matrix mat.ReadOnlyMatrix) mat.ReadOnlyMatrix {
+	result := mat.MakeSparse(matrix.Rows(), matrix.Cols())
+
+	for row := 0; row < matrix.Rows(); row++ {
+		for _, col := range matrix.NonZeroIndicesAtRow(row) {
+			result.SetValue(row, col, -matrix.Value(row, col))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,240 @@
+package process
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+// CombineSolutions computes the solution of each of the load combinations by linear
+// superposition of the load cases solutions: each solution value is the sum of the values of
+// the combination's load cases, multiplied by their factors.
+//
+// Panics if a combination includes a load case without solution.
+func CombineSolutions(solutions []*Solution, combinations []*load.Combination) []*Solution {
+	var (
+		solutionsByCase = make(map[string]*Solution, len(solutions))
+		combined        = make([]*Solution, len(combinations))
+	)
+
+	for _, solution := range solutions {
+		solutionsByCase[solution.LoadCase] = solution
+	}
+
+	for i, combination := range combinations {
+		combined[i] = combineSolution(combination, solutionsByCase)
+	}
+
+	return combined
+}
+
+func combineSolution(combination *load.Combination, solutionsByCase map[string]*Solution) *Solution {
+	var (
+		caseSolutions = make([]*Solution, len(combination.Terms))
+		factors       = make([]float64, len(combination.Terms))
+	)
+
+	for i, term := range combination.Terms {
+		solution, exists := solutionsByCase[term.LoadCase]
+		if !exists {
+			panic(fmt.Sprintf(
+				"Can't combine '%s': no solution for load case '%s'", combination.Name, term.LoadCase,
+			))
+		}
+
+		caseSolutions[i] = solution
+		factors[i] = term.Factor
+	}
+
+	var (
+		first         = caseSolutions[0]
+		elements      = make([]*ElementSolution, first.ElementCount())
+		displacements = first.GlobalDisplacements.Scaled(factors[0])
+	)
+
+	for i, solution := range caseSolutions[1:] {
+		displacements = displacements.Plus(solution.GlobalDisplacements.Scaled(factors[i+1]))
+	}
+
+	for j := range elements {
+		elementSolutions := make([]*ElementSolution, len(caseSolutions))
+		for i, solution := range caseSolutions {
+			elementSolutions[i] = solution.Elements[j]
+		}
+
+		elements[j] = combineElementSolutions(elementSolutions, factors)
+	}
+
+	solution := MakeSolution(first.Metadata, combination.Name, first.NodesById, elements, displacements)
+	solution.Combination = combination
+
+	return solution
+}
+
+// combineElementSolutions superposes the solutions of the same element in different load
+// cases, multiplied by the given factors.
+//
+// The solutions of all the load cases share the same sliced element, hence the displacements
+// are at the same nodes. The stresses and forces are expanded into the values at both ends of
+// each slice before adding them, as their discontinuities might not be at the same nodes. The
+// loads of the combined solution, both those of the bar and those of its sliced nodes, are those
+// of each load case, multiplied by their factors.
+func combineElementSolutions(elementSolutions []*ElementSolution, factors []float64) *ElementSolution {
+	var (
+		first   = elementSolutions[0]
+		element = combineElements(elementSolutions, factors)
+		nodes   = element.Nodes()
+	)
+
+	nodeValues := func(values func(es *ElementSolution) []PointSolutionValue) []PointSolutionValue {
+		combined := make([]PointSolutionValue, len(nodes))
+		for j, node := range nodes {
+			combined[j].T = node.T
+		}
+
+		for i, es := range elementSolutions {
+			for j, value := range values(es) {
+				combined[j].Value += factors[i] * value.Value
+			}
+		}
+
+		return combined
+	}
+
+	sliceValues := func(values func(es *ElementSolution) []PointSolutionValue) []PointSolutionValue {
+		var combined []PointSolutionValue
+
+		for i, es := range elementSolutions {
+			expanded := sliceEndValues(values(es), nodes)
+			if combined == nil {
+				combined = make([]PointSolutionValue, len(expanded))
+				for j, value := range expanded {
+					combined[j].T = value.T
+				}
+			}
+
+			for j, value := range expanded {
+				combined[j].Value += factors[i] * value.Value
+			}
+		}
+
+		return compactSliceEndValues(combined, first.maxDispError)
+	}
+
+	var (
+		concentratedLoads []*load.ConcentratedLoad
+		distributedLoads  []*load.DistributedLoad
+	)
+
+	for i, es := range elementSolutions {
+		for _, ld := range es.concentratedLoads {
+			concentratedLoads = append(
+				concentratedLoads,
+				load.MakeConcentrated(ld.Term, ld.IsInLocalCoords, ld.T, factors[i]*ld.Value),
+			)
+		}
+		for _, ld := range es.distributedLoads {
+			distributedLoads = append(
+				distributedLoads,
+				load.MakeDistributed(
+					ld.Term, ld.IsInLocalCoords, ld.StartT, factors[i]*ld.StartValue, ld.EndT, factors[i]*ld.EndValue,
+				),
+			)
+		}
+	}
+
+	return &ElementSolution{
+		Element: element,
+
+		GlobalXDispl: nodeValues(func(es *ElementSolution) []PointSolutionValue { return es.GlobalXDispl }),
+		GlobalYDispl: nodeValues(func(es *ElementSolution) []PointSolutionValue { return es.GlobalYDispl }),
+		GlobalZRot:   nodeValues(func(es *ElementSolution) []PointSolutionValue { return es.GlobalZRot }),
+
+		LocalXDispl: nodeValues(func(es *ElementSolution) []PointSolutionValue { return es.LocalXDispl }),
+		LocalYDispl: nodeValues(func(es *ElementSolution) []PointSolutionValue { return es.LocalYDispl }),
+		LocalZRot:   nodeValues(func(es *ElementSolution) []PointSolutionValue { return es.LocalZRot }),
+
+		AxialStress:   sliceValues(func(es *ElementSolution) []PointSolutionValue { return es.AxialStress }),
+		ShearForce:    sliceValues(func(es *ElementSolution) []PointSolutionValue { return es.ShearForce }),
+		BendingMoment: sliceValues(func(es *ElementSolution) []PointSolutionValue { return es.BendingMoment }),
+		BendingMomentTopFiberAxialStress: sliceValues(func(es *ElementSolution) []PointSolutionValue {
+			return es.BendingMomentTopFiberAxialStress
+		}),
+
+		concentratedLoads: concentratedLoads,
+		distributedLoads:  distributedLoads,
+
+		maxDispError: first.maxDispError,
+	}
+}
+
+// combineElements creates the sliced element of the combined solution: its nodes have the loads
+// of the element's nodes in each load case, multiplied by their factors, in the default case.
+func combineElements(elementSolutions []*ElementSolution, factors []float64) *preprocess.Element {
+	var (
+		first = elementSolutions[0].Element
+		nodes = make([]*preprocess.Node, first.NodesCount())
+	)
+
+	for j, node := range first.Nodes() {
+		nodes[j] = preprocess.MakeNodeWithDofs(node.T, node.Position, node.DegreesOfFreedomNum())
+
+		for i, es := range elementSolutions {
+			var (
+				factor   = factors[i]
+				caseNode = es.Element.NodeAt(j)
+				external = caseNode.LocalExternalLoad()
+				left     = caseNode.LocalLeftLoad()
+				right    = caseNode.LocalRightLoad()
+			)
+
+			nodes[j].AddLocalExternalLoad(
+				math.MakeTorsor(factor*external.Fx(), factor*external.Fy(), factor*external.Mz()),
+			)
+			nodes[j].AddLocalLeftLoad(factor*left.Fx(), factor*left.Fy(), factor*left.Mz())
+			nodes[j].AddLocalRightLoad(factor*right.Fx(), factor*right.Fy(), factor*right.Mz())
+		}
+	}
+
+	return preprocess.MakeElement(first.Element, nodes)
+}
+
+// sliceEndValues expands the values of a stress or force along a sliced element into the
+// values at the start and end of each slice.
+//
+// When an element solution is computed, the value at the start of a slice is omitted if it's
+// the same as the value at the end of the previous slice; the expanded values include both.
+func sliceEndValues(values []PointSolutionValue, nodes []*preprocess.Node) []PointSolutionValue {
+	var (
+		slicesCount = len(nodes) - 1
+		expanded    = make([]PointSolutionValue, 0, 2*slicesCount)
+		j           = 0
+	)
+
+	for i := 1; i <= slicesCount; i++ {
+		if i > 1 && j+1 < len(values) && values[j+1].T.Equals(nodes[i-1].T) {
+			j++
+		}
+
+		expanded = append(expanded, values[j])
+		j++
+		expanded = append(expanded, values[j])
+	}
+
+	return expanded
+}
+
+// compactSliceEndValues is the inverse of sliceEndValues: it removes the values at the start
+// of the slices which are the same as the value at the end of the previous slice.
+func compactSliceEndValues(values []PointSolutionValue, epsilon float64) []PointSolutionValue {
+	compacted := make([]PointSolutionValue, 0, len(values))
+
+	for i := 0; i < len(values); i += 2 {
+		compacted = appendIfNotSameAsLast(compacted, values[i], epsilon)
+		compacted = append(compacted, values[i+1])
+	}
+
+	return compacted
+}
@@ -0,0 +1,107 @@
+package process
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceEndValues(t *testing.T) {
+	var (
+		nodes = []*preprocess.Node{
+			preprocess.MakeUnloadedNode(nums.MinT, g2d.MakePoint(0, 0)),
+			preprocess.MakeUnloadedNode(nums.MakeTParam(0.25), g2d.MakePoint(25, 0)),
+			preprocess.MakeUnloadedNode(nums.HalfT, g2d.MakePoint(50, 0)),
+			preprocess.MakeUnloadedNode(nums.MaxT, g2d.MakePoint(100, 0)),
+		}
+		// Discontinuity at t = 0.5
+		values = []PointSolutionValue{
+			{nums.MinT, 1},
+			{nums.MakeTParam(0.25), 2},
+			{nums.HalfT, 3},
+			{nums.HalfT, 5},
+			{nums.MaxT, 6},
+		}
+		want = []PointSolutionValue{
+			{nums.MinT, 1},
+			{nums.MakeTParam(0.25), 2},
+			{nums.MakeTParam(0.25), 2},
+			{nums.HalfT, 3},
+			{nums.HalfT, 5},
+			{nums.MaxT, 6},
+		}
+	)
+
+	t.Run("expands the values at both ends of each slice", func(t *testing.T) {
+		assert.Equal(t, want, sliceEndValues(values, nodes))
+	})
+
+	t.Run("compacting the expanded values yields the original ones", func(t *testing.T) {
+		assert.Equal(t, values, compactSliceEndValues(want, 1e-5))
+	})
+}
+
+func TestCombinedSolutionNodeLoads(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		// The load cases load the supported node differently
+		loads = []*load.ConcentratedLoad{
+			load.MakeConcentrated(load.FY, true, nums.MinT, -1000).InCase("dead"),
+			load.MakeConcentrated(load.FY, true, nums.MaxT, -2000).InCase("dead"),
+			load.MakeConcentrated(load.FX, true, nums.MinT, 500).InCase("live"),
+			load.MakeConcentrated(load.MZ, true, nums.MinT, 300).InCase("live"),
+		}
+		fixedNode = structure.MakeNode("fixed", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		freeNode  = structure.MakeNode("free", g2d.MakePoint(100, 0), &structure.NilConstraint)
+		beam      = structure.MakeElementBuilder("beam").
+				WithStartNode(fixedNode, &structure.FullConstraint).
+				WithEndNode(freeNode, &structure.FullConstraint).
+				WithMaterial(structure.MakeUnitMaterial()).
+				WithSection(structure.MakeUnitSection()).
+				AddConcentratedLoads(loads).
+				Build()
+		str = structure.Make(
+			structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+			map[contracts.StrID]*structure.Node{"fixed": fixedNode, "free": freeNode},
+			[]*structure.Element{beam},
+		)
+		solutions = Solve(
+			preprocess.StructureModel(str, &preprocess.PreprocessOptions{}),
+			SolveOptions{SafeChecks: true, MaxDisplacementsError: 1e-5, Solver: DirectSolver},
+		)
+		dead, live = solutions[1], solutions[2]
+		combined   = CombineSolutions(solutions, []*load.Combination{load.MakeCombination(
+			"ULS",
+			load.CombinationTerm{Factor: 1.35, LoadCase: "dead"},
+			load.CombinationTerm{Factor: 1.5, LoadCase: "live"},
+		)})[0]
+	)
+
+	t.Run("the element's end node has the factored loads of the load cases", func(t *testing.T) {
+		got := combined.Elements[0].Element.NodeAt(0).LocalExternalLoad()
+
+		assert.InDelta(t, 1.5*500, got.Fx(), 1e-10)
+		assert.InDelta(t, 1.35*-1000, got.Fy(), 1e-10)
+		assert.InDelta(t, 1.5*300, got.Mz(), 1e-10)
+	})
+
+	t.Run("the support reaction is the factored sum of the load cases reactions", func(t *testing.T) {
+		var (
+			got      = combined.supportReaction(fixedNode)
+			deadReac = dead.supportReaction(fixedNode)
+			liveReac = live.supportReaction(fixedNode)
+		)
+
+		assert.InDelta(t, 1.35*deadReac.Fx()+1.5*liveReac.Fx(), got.Fx(), 1e-6)
+		assert.InDelta(t, 1.35*deadReac.Fy()+1.5*liveReac.Fy(), got.Fy(), 1e-6)
+		assert.InDelta(t, 1.35*deadReac.Mz()+1.5*liveReac.Mz(), got.Mz(), 1e-4)
+	})
+}
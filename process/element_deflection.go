@@ -0,0 +1,230 @@
+package process
+
+import (
+	gomath "math"
+
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+// deflectionSamples is the number of intervals each slice is divided into to look for the
+// positions where the deflection's slope changes its sign, which are then refined with
+// bisectionIterations bisections.
+const (
+	deflectionSamples   = 16
+	bisectionIterations = 60
+)
+
+// LocalDisplacementsAt returns the displacements {dx, dy} and the rotation rz at the position t
+// of the element, in the element's local reference frame.
+//
+// Between the nodes of the sliced element, the transverse displacement is interpolated with the
+// cubic Hermite shape functions from the displacements and rotations of the slice's nodes, plus
+// the deflection of the slice, with both ends fixed, under the distributed loads applied to it.
+// The axial displacement is interpolated linearly, plus the elongation of the fixed slice under
+// the axial loads. Both are the exact solution of a beam without shear deformation: when the
+// section has a shear area, the shear deflection between the nodes isn't included.
+func (es *ElementSolution) LocalDisplacementsAt(t nums.TParam) (dx, dy, rz float64) {
+	var (
+		i              = sliceContainingT(es.Element, t)
+		trail, lead    = es.Element.NodeAt(i), es.Element.NodeAt(i + 1)
+		sliceLength    = es.Element.LengthBetween(trail.T, lead.T)
+		x              = es.Element.LengthBetween(trail.T, t)
+		loads          = es.sliceDistributedLoads(i)
+		ea             = es.Material().YoungMod * es.Section().Area
+		ei             = es.Material().YoungMod * es.Section().IStrong
+		vA, vB         = es.LocalYDispl[i].Value, es.LocalYDispl[i+1].Value
+		thetaA, thetaB = es.LocalZRot[i].Value, es.LocalZRot[i+1].Value
+	)
+
+	dy, rz = hermiteInterpolation(vA, thetaA, vB, thetaB, sliceLength, x)
+
+	if len(loads) == 0 {
+		dx = es.LocalXDispl[i].Value + (es.LocalXDispl[i+1].Value-es.LocalXDispl[i].Value)*x/sliceLength
+		return
+	}
+
+	var (
+		startLoad, endLoad = loads[0], loads[1]
+		fixedDy, fixedRz   = fixedBeamDeflection(
+			startLoad.Fy()-(endLoad.Mz()-startLoad.Mz())/sliceLength,
+			(endLoad.Fy()-startLoad.Fy())/sliceLength,
+			ei,
+			sliceLength,
+			x,
+		)
+	)
+
+	dx = es.LocalXDispl[i].Value +
+		(es.LocalXDispl[i+1].Value-es.LocalXDispl[i].Value)*x/sliceLength +
+		fixedBarElongation(startLoad.Fx(), (endLoad.Fx()-startLoad.Fx())/sliceLength, ea, sliceLength, x)
+	dy += fixedDy
+	rz += fixedRz
+
+	return
+}
+
+// GlobalDisplacementsAt returns the displacements {dx, dy} and the rotation rz at the position t
+// of the element, in the global reference frame. See LocalDisplacementsAt.
+func (es *ElementSolution) GlobalDisplacementsAt(t nums.TParam) (dx, dy, rz float64) {
+	localDx, localDy, rz := es.LocalDisplacementsAt(t)
+	global := es.RefFrame().ProjectionsToGlobal(localDx, localDy)
+
+	return global.X(), global.Y(), rz
+}
+
+// MaxDeflection returns the local transverse displacement of the element with the largest
+// absolute value, and the position where it happens.
+//
+// The deflection is searched in the nodes and where its slope, the rotation, is zero between
+// them (see LocalDisplacementsAt), so there's no need to slice the element finer to find it.
+func (es *ElementSolution) MaxDeflection() PointSolutionValue {
+	var (
+		maxDeflection = es.LocalYDispl[0]
+		check         = func(t nums.TParam) {
+			if _, dy, _ := es.LocalDisplacementsAt(t); gomath.Abs(dy) > gomath.Abs(maxDeflection.Value) {
+				maxDeflection = PointSolutionValue{t, dy}
+			}
+		}
+		slope = func(t float64) float64 {
+			_, _, rz := es.LocalDisplacementsAt(nums.MakeTParam(t))
+			return rz
+		}
+	)
+
+	for i := 1; i < es.Element.NodesCount(); i++ {
+		var (
+			startT = es.Element.NodeAt(i - 1).T.Value()
+			endT   = es.Element.NodeAt(i).T.Value()
+			step   = (endT - startT) / deflectionSamples
+		)
+
+		check(es.Element.NodeAt(i).T)
+
+		for j := 0; j < deflectionSamples; j++ {
+			var (
+				a, b           = startT + float64(j)*step, startT + float64(j+1)*step
+				slopeA, slopeB = slope(a), slope(b)
+			)
+
+			if slopeA*slopeB < 0 {
+				check(nums.MakeTParam(bisectZero(slope, a, b, slopeA)))
+			}
+		}
+	}
+
+	return maxDeflection
+}
+
+// sliceDistributedLoads returns the sum of the distributed loads applied to the i-th slice of the
+// element, in the element's local reference frame, at the slice's start and end. The loads are
+// those used to compute the equivalent loads in the slice's nodes in the preprocessing.
+//
+// Returns nil if there are no distributed loads applied to the slice.
+func (es *ElementSolution) sliceDistributedLoads(i int) []*math.Torsor {
+	var (
+		trailT, leadT = es.Element.NodeAt(i).T, es.Element.NodeAt(i + 1).T
+		refFrame      = es.RefFrame()
+		start, end    = math.MakeTorsor(0, 0, 0), math.MakeTorsor(0, 0, 0)
+		isLoaded      = false
+	)
+
+	for _, ld := range es.distributedLoads {
+		if !trailT.IsLessThan(ld.EndT) || !ld.StartT.IsLessThan(leadT) {
+			continue
+		}
+
+		isLoaded = true
+		if ld.IsInLocalCoords {
+			start = start.Plus(ld.AsTorsorAt(trailT))
+			end = end.Plus(ld.AsTorsorAt(leadT))
+		} else {
+			start = start.Plus(ld.AsTorsorProjectedAt(trailT, refFrame))
+			end = end.Plus(ld.AsTorsorProjectedAt(leadT, refFrame))
+		}
+	}
+
+	if !isLoaded {
+		return nil
+	}
+
+	return []*math.Torsor{start, end}
+}
+
+// hermiteInterpolation returns the transverse displacement and rotation at the position x of a
+// slice of the given length, from the displacements and rotations of its ends, using the cubic
+// Hermite shape functions.
+func hermiteInterpolation(vA, thetaA, vB, thetaB, length, x float64) (v, theta float64) {
+	var (
+		s  = x / length
+		s2 = s * s
+		s3 = s2 * s
+	)
+
+	v = (1.0-3.0*s2+2.0*s3)*vA +
+		length*(s-2.0*s2+s3)*thetaA +
+		(3.0*s2-2.0*s3)*vB +
+		length*(s3-s2)*thetaB
+
+	theta = 6.0*(s2-s)/length*vA +
+		(1.0-4.0*s+3.0*s2)*thetaA +
+		6.0*(s-s2)/length*vB +
+		(3.0*s2-2.0*s)*thetaB
+
+	return
+}
+
+// fixedBeamDeflection returns the transverse displacement and rotation at the position x of a
+// beam of the given length and bending stiffness, with both ends fixed, under a transverse load
+// q(x) = q0 + k·x.
+//
+// The deflection is the solution of EI·d⁴v/dx⁴ = q(x), with zero displacement and rotation at both
+// ends: a particular solution plus the cubic and quadratic terms that satisfy the conditions at
+// the far end.
+func fixedBeamDeflection(q0, k, ei, length, x float64) (v, theta float64) {
+	var (
+		particular      = func(x float64) float64 { return (q0*x*x*x*x/24.0 + k*x*x*x*x*x/120.0) / ei }
+		particularSlope = func(x float64) float64 { return (q0*x*x*x/6.0 + k*x*x*x*x/24.0) / ei }
+		pL, slopeL      = particular(length), particularSlope(length)
+		c3              = (2.0*pL/length - slopeL) / (length * length)
+		c2              = (-pL - c3*length*length*length) / (length * length)
+	)
+
+	v = particular(x) + c2*x*x + c3*x*x*x
+	theta = particularSlope(x) + 2.0*c2*x + 3.0*c3*x*x
+
+	return
+}
+
+// fixedBarElongation returns the axial displacement at the position x of a bar of the given
+// length and axial stiffness, with both ends fixed, under an axial load p(x) = p0 + k·x.
+//
+// The displacement is the solution of EA·d²u/dx² = -p(x), with zero displacement at both ends.
+func fixedBarElongation(p0, k, ea, length, x float64) float64 {
+	particular := func(x float64) float64 { return -(p0*x*x/2.0 + k*x*x*x/6.0) / ea }
+
+	return particular(x) - x*particular(length)/length
+}
+
+// bisectZero finds the zero of the function between a and b, where it changes its sign, by
+// bisection. The value of the function at a is given.
+func bisectZero(f func(float64) float64, a, b, fa float64) float64 {
+	for i := 0; i < bisectionIterations; i++ {
+		var (
+			mid  = 0.5 * (a + b)
+			fMid = f(mid)
+		)
+
+		if fMid == 0 {
+			return mid
+		}
+
+		if (fa < 0) == (fMid < 0) {
+			a, fa = mid, fMid
+		} else {
+			b = mid
+		}
+	}
+
+	return 0.5 * (a + b)
+}
@@ -0,0 +1,296 @@
+package process
+
+import (
+	gomath "math"
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+// AxialStressAt returns the axial stress at the position t of the element, computed exactly
+// from the axial force at the element's start and the loads applied between its start and t.
+//
+// At the position of a concentrated load, the value is the one right after the load. See
+// BendingMomentAt.
+func (es *ElementSolution) AxialStressAt(t nums.TParam) float64 {
+	axial, _, _ := es.internalForcesAt(es.Length()*t.Value(), true)
+	return axial / es.Section().Area
+}
+
+// ShearForceAt returns the shear force at the position t of the element, computed exactly
+// from the shear force at the element's start and the loads applied between its start and t.
+//
+// At the position of a concentrated load, the value is the one right after the load. See
+// BendingMomentAt.
+func (es *ElementSolution) ShearForceAt(t nums.TParam) float64 {
+	_, shear, _ := es.internalForcesAt(es.Length()*t.Value(), true)
+	return shear
+}
+
+// BendingMomentAt returns the bending moment at the position t of the element.
+//
+// Unlike the BendingMoment values, which are only computed at the nodes of the sliced element,
+// the bending moment is computed at any position by equilibrium of the portion of the element
+// between its start and t: the forces at the element's start, given by the solution, plus the
+// distributed and concentrated loads applied in that portion. The loads are taken in their actual
+// positions, even when the preprocessing moved them to a close node.
+//
+// At the position of a concentrated load, the value is the one right after the load, except at
+// the element's ends, where the loads act on the structural nodes. The equilibrium is that of the
+// undeformed element, so second order effects aren't included.
+func (es *ElementSolution) BendingMomentAt(t nums.TParam) float64 {
+	_, _, bending := es.internalForcesAt(es.Length()*t.Value(), true)
+	return bending
+}
+
+// BendingMomentExtrema returns the minimum and maximum bending moments in the element, with the
+// positions where they happen.
+//
+// The bending moment is a polynomial between the positions where loads start, end or are applied,
+// so its extrema are in those positions, at either side of the concentrated loads, or where its
+// derivative, the shear force minus the distributed moment, is zero.
+func (es *ElementSolution) BendingMomentExtrema() (minimum, maximum PointSolutionValue) {
+	var (
+		length    = es.Length()
+		positions = es.loadDiscontinuities()
+		first     = true
+	)
+
+	check := func(x float64, afterLoads bool) {
+		_, _, bending := es.internalForcesAt(x, afterLoads)
+		value := PointSolutionValue{nums.MakeTParam(x / length), bending}
+
+		if first || bending < minimum.Value {
+			minimum = value
+		}
+		if first || bending > maximum.Value {
+			maximum = value
+		}
+
+		first = false
+	}
+
+	for i, x := range positions {
+		check(x, false)
+		check(x, true)
+
+		if i == 0 {
+			continue
+		}
+
+		for _, root := range es.zeroBendingSlopes(positions[i-1], x) {
+			check(root, true)
+		}
+	}
+
+	return
+}
+
+// loadDiscontinuities returns the sorted positions, measured as lengths from the element's start,
+// of the element's ends and the positions where its loads start, end or are applied.
+func (es *ElementSolution) loadDiscontinuities() []float64 {
+	var (
+		length    = es.Length()
+		tolerance = lengthTolerance * length
+		positions = []float64{0, length}
+	)
+
+	for _, ld := range es.concentratedLoads {
+		positions = append(positions, length*ld.T.Value())
+	}
+	for _, ld := range es.distributedLoads {
+		positions = append(positions, length*ld.StartT.Value(), length*ld.EndT.Value())
+	}
+
+	sort.Float64s(positions)
+
+	unique := positions[:1]
+	for _, x := range positions[1:] {
+		if x-unique[len(unique)-1] > tolerance {
+			unique = append(unique, x)
+		}
+	}
+
+	return unique
+}
+
+// zeroBendingSlopes returns the positions strictly between start and end, where there are no load
+// discontinuities, in which the bending moment's derivative is zero.
+//
+// The derivative is the shear force minus the distributed moment, a polynomial of at most the
+// second degree, so it's interpolated from its values at the start, middle and end of the interval
+// to find its roots.
+func (es *ElementSolution) zeroBendingSlopes(start, end float64) []float64 {
+	var (
+		half  = 0.5 * (end - start)
+		slope = func(x float64) float64 {
+			_, shear, _ := es.internalForcesAt(x, true)
+			return shear - es.distributedMomentAt(x)
+		}
+		y0, y1, y2 = slope(start + lengthTolerance*half), slope(start + half), slope(end - lengthTolerance*half)
+
+		// slope(start + half + half·r) = a·r² + b·r + c, with r in [-1, 1]
+		a     = 0.5*(y0+y2) - y1
+		b     = 0.5 * (y2 - y0)
+		c     = y1
+		roots []float64
+	)
+
+	if gomath.Abs(a) <= lengthTolerance*(gomath.Abs(b)+gomath.Abs(c)) {
+		if b != 0 {
+			roots = append(roots, -c/b)
+		}
+	} else if discriminant := b*b - 4*a*c; discriminant >= 0 {
+		sqrtDiscriminant := gomath.Sqrt(discriminant)
+		roots = append(roots, (-b-sqrtDiscriminant)/(2*a), (-b+sqrtDiscriminant)/(2*a))
+	}
+
+	var positions []float64
+	for _, r := range roots {
+		if r > -1 && r < 1 {
+			positions = append(positions, start+half+half*r)
+		}
+	}
+
+	return positions
+}
+
+// internalForcesAt computes the axial force, shear force and bending moment at the position x,
+// measured as a length from the element's start, by equilibrium of the portion of the element
+// between its start and x.
+//
+// The forces at the start of the element are those of the solution, and the loads are projected
+// into the element's local reference frame. The sign convention is that of the solution: a tensile
+// axial force, and a bending moment which tensions the bottom fibers, are positive, and the
+// derivative of the bending moment is the shear force minus the distributed moments.
+//
+// The concentrated loads at the element's ends act on the structural nodes, so they're excluded.
+// A concentrated load at x is included only if afterLoads is true.
+func (es *ElementSolution) internalForcesAt(x float64, afterLoads bool) (axial, shear, bending float64) {
+	var (
+		length    = es.Length()
+		tolerance = lengthTolerance * length
+		refFrame  = es.RefFrame()
+	)
+
+	axial = es.AxialStress[0].Value * es.Section().Area
+	shear = es.ShearForce[0].Value
+	bending = es.BendingMoment[0].Value + shear*x
+
+	for _, ld := range es.concentratedLoads {
+		var (
+			xLoad  = length * ld.T.Value()
+			torsor = localConcentratedLoadTorsor(ld, refFrame)
+		)
+
+		if ld.IsNodal() || xLoad > x+tolerance || (xLoad > x-tolerance && !afterLoads) {
+			continue
+		}
+
+		axial -= torsor.Fx()
+		shear += torsor.Fy()
+		bending += torsor.Fy()*(x-xLoad) - torsor.Mz()
+	}
+
+	for _, ld := range es.distributedLoads {
+		var (
+			xStart, xEnd = length * ld.StartT.Value(), length * ld.EndT.Value()
+			span         = gomath.Min(x, xEnd) - xStart
+		)
+
+		if span <= 0 || xEnd-xStart <= tolerance {
+			continue
+		}
+
+		var (
+			startLoad, endLoad = localDistributedLoadTorsors(ld, refFrame)
+			lever              = x - xStart
+		)
+
+		// Each component varies linearly as q(s) = q₀ + k·s, with s measured from the load's start.
+		resultant := func(q0, q1 float64) float64 {
+			k := (q1 - q0) / (xEnd - xStart)
+			return q0*span + 0.5*k*span*span
+		}
+		momentAboutX := func(q0, q1 float64) float64 {
+			k := (q1 - q0) / (xEnd - xStart)
+			return q0*(lever*span-0.5*span*span) + k*(0.5*lever*span*span-span*span*span/3.0)
+		}
+
+		axial -= resultant(startLoad.Fx(), endLoad.Fx())
+		shear += resultant(startLoad.Fy(), endLoad.Fy())
+		bending += momentAboutX(startLoad.Fy(), endLoad.Fy()) - resultant(startLoad.Mz(), endLoad.Mz())
+	}
+
+	return
+}
+
+// distributedMomentAt returns the sum of the distributed moments applied at the position x,
+// measured as a length from the element's start.
+func (es *ElementSolution) distributedMomentAt(x float64) float64 {
+	var (
+		t        = nums.MakeTParam(x / es.Length())
+		refFrame = es.RefFrame()
+		moment   = 0.0
+	)
+
+	for _, ld := range es.distributedLoads {
+		if ld.IsInLocalCoords {
+			moment += ld.AsTorsorAt(t).Mz()
+		} else {
+			moment += ld.AsTorsorProjectedAt(t, refFrame).Mz()
+		}
+	}
+
+	return moment
+}
+
+// lengthTolerance is the relative tolerance, to the element's length, used to compare positions
+// along the element.
+const lengthTolerance = 1e-10
+
+// localConcentratedLoadTorsor returns the concentrated load's torsor in the element's local
+// reference frame.
+func localConcentratedLoadTorsor(ld *load.ConcentratedLoad, refFrame *g2d.RefFrame) *math.Torsor {
+	if ld.IsInLocalCoords {
+		return ld.AsTorsor()
+	}
+
+	return ld.AsTorsorProjectedTo(refFrame)
+}
+
+// localDistributedLoadTorsors returns the distributed load's torsors at its start and end in the
+// element's local reference frame.
+func localDistributedLoadTorsors(ld *load.DistributedLoad, refFrame *g2d.RefFrame) (start, end *math.Torsor) {
+	if ld.IsInLocalCoords {
+		return ld.AsTorsorAt(ld.StartT), ld.AsTorsorAt(ld.EndT)
+	}
+
+	return ld.AsTorsorProjectedAt(ld.StartT, refFrame), ld.AsTorsorProjectedAt(ld.EndT, refFrame)
+}
+
+// elementLoadsInCase returns the loads applied to the original element which belong to the load
+// case of the preprocessed element's nodes.
+func elementLoadsInCase(element *preprocess.Element) (
+	concentratedLoads []*load.ConcentratedLoad,
+	distributedLoads []*load.DistributedLoad,
+) {
+	loadCase := element.NodeAt(0).LoadCase()
+
+	for _, ld := range element.ConcentratedLoads {
+		if ld.LoadCase == loadCase {
+			concentratedLoads = append(concentratedLoads, ld)
+		}
+	}
+	for _, ld := range element.DistributedLoads {
+		if ld.LoadCase == loadCase {
+			distributedLoads = append(distributedLoads, ld)
+		}
+	}
+
+	return
+}
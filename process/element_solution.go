@@ -3,6 +3,7 @@ package process
 import (
 	"github.com/angelsolaorbaiceta/inkfem/math"
 	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 	"github.com/angelsolaorbaiceta/inkgeom/g2d"
 	"github.com/angelsolaorbaiceta/inkmath/vec"
 )
@@ -27,6 +28,13 @@ type ElementSolution struct {
 	ShearForce                       []PointSolutionValue
 	BendingMoment                    []PointSolutionValue
 	BendingMomentTopFiberAxialStress []PointSolutionValue
+
+	// The loads applied to the element in the solution's load case, used to compute the exact
+	// stresses and forces between the nodes. See AxialStressAt, ShearForceAt and BendingMomentAt.
+	concentratedLoads []*load.ConcentratedLoad
+	distributedLoads  []*load.DistributedLoad
+
+	maxDispError float64
 }
 
 // MakeElementSolution creates a solution element with all solution values for the
@@ -44,6 +52,18 @@ type ElementSolution struct {
 func MakeElementSolution(
 	element *preprocess.Element,
 	globalDisp *GlobalDisplacementsVector,
+) *ElementSolution {
+	solution := makeDisplacedElementSolution(element, globalDisp)
+	solution.computeStresses(globalDisp.MaxError)
+
+	return solution
+}
+
+// makeDisplacedElementSolution creates a solution element with the displacements set from the
+// structure's global displacements, and no stresses.
+func makeDisplacedElementSolution(
+	element *preprocess.Element,
+	globalDisp *GlobalDisplacementsVector,
 ) *ElementSolution {
 	var (
 		nOfNodes          = element.NodesCount()
@@ -65,10 +85,12 @@ func MakeElementSolution(
 		ShearForce:                       make([]PointSolutionValue, 0, nOfSolutionValues),
 		BendingMoment:                    make([]PointSolutionValue, 0, nOfSolutionValues),
 		BendingMomentTopFiberAxialStress: make([]PointSolutionValue, 0, nOfSolutionValues),
+
+		maxDispError: globalDisp.MaxError,
 	}
 
+	solution.concentratedLoads, solution.distributedLoads = elementLoadsInCase(element)
 	solution.setDisplacements(globalDisp.Vector)
-	solution.computeStresses(globalDisp.MaxError)
 
 	return solution
 }
@@ -132,6 +154,9 @@ func (es *ElementSolution) setDisplacements(globalDisp vec.ReadOnlyVector) {
 // This method should be called after setDisplacements, as it requires the displacements
 // to compute the corresponding stresses and forces.
 //
+// The stiffness terms used to compute the shear force and bending moment include the shear
+// deformation when the element's section has a shear area, as in the stiffness matrix.
+//
 // It uses the maximum displacement error used for the displacements calculation to
 // compare the values of the solution values and avoid having the same force/stress value
 // in both sides of the node.
@@ -140,13 +165,11 @@ func (es *ElementSolution) computeStresses(maxDispError float64) {
 		trailNode, leadNode *preprocess.Node
 		youngMod            = es.Element.Material().YoungMod
 		iStrong             = es.Element.Section().IStrong
-		sStrong             = es.Element.Section().SStrong
-		section             = es.Section().Area
 		ei                  = youngMod * iStrong
 		nodesCount          = es.Element.NodesCount()
 
 		trailDx, leadDx, trailDy, leadDy, trailRz, leadRz float64
-		length, length2, length3, eil, eil2, eil3         float64
+		length, length2, length3, eil, eil2, eil3, phi    float64
 	)
 
 	for i := 1; i < nodesCount; i++ {
@@ -154,9 +177,10 @@ func (es *ElementSolution) computeStresses(maxDispError float64) {
 		length = es.Element.LengthBetween(trailNode.T, leadNode.T)
 		length2 = length * length
 		length3 = length2 * length
-		eil = ei / length
-		eil2 = ei / length2
-		eil3 = ei / length3
+		phi = es.Element.ShearDeformationFactor(length)
+		eil = ei / (length * (1.0 + phi))
+		eil2 = ei / (length2 * (1.0 + phi))
+		eil3 = ei / (length3 * (1.0 + phi))
 		trailDx = es.LocalXDispl[i-1].Value
 		leadDx = es.LocalXDispl[i].Value
 		trailDy = es.LocalYDispl[i-1].Value
@@ -165,60 +189,92 @@ func (es *ElementSolution) computeStresses(maxDispError float64) {
 		leadRz = es.LocalZRot[i].Value
 
 		/* <-- Axial --> */
-		var (
-			axial      = (leadDx - trailDx) * youngMod / length
-			trailAxial = axial + (trailNode.LocalLeftFx() / section)
-			leadAxial  = axial - (leadNode.LocalRightFx() / section)
-		)
-		es.AxialStress = appendIfNotSameAsLast(
-			es.AxialStress,
-			PointSolutionValue{trailNode.T, trailAxial},
-			maxDispError,
-		)
-		es.AxialStress = append(es.AxialStress, PointSolutionValue{leadNode.T, leadAxial})
+		axial := (leadDx - trailDx) * youngMod / length
 
 		/* <-- Shear --> */
 		var (
 			shearDispTerm = 12.0 * eil3 * (trailDy - leadDy)
 			shearRotTerm  = 6.0 * eil2 * (trailRz + leadRz)
-			shear         = shearDispTerm + shearRotTerm
-			trailShear    = shear - trailNode.LocalLeftFy()
-			leadShear     = shear + leadNode.LocalRightFy()
 		)
-		es.ShearForce = appendIfNotSameAsLast(
-			es.ShearForce,
-			PointSolutionValue{trailNode.T, trailShear},
-			maxDispError,
-		)
-		es.ShearForce = append(es.ShearForce, PointSolutionValue{leadNode.T, leadShear})
 
 		/* <-- Bending --> */
 		var (
 			bendStartDispTerm = 6.0 * eil2 * (leadDy - trailDy)
-			bendStartRotTerm  = 2.0 * eil * (leadRz + 2.0*trailRz)
+			bendStartRotTerm  = eil * ((2.0-phi)*leadRz + (4.0+phi)*trailRz)
 			bendEndDispTerm   = 6.0 * eil2 * (trailDy - leadDy)
-			bendEndRotTerm    = 2.0 * eil * (trailRz + 2.0*leadRz)
-			trailBending      = bendStartDispTerm - bendStartRotTerm + trailNode.LocalLeftMz()
-			leadBending       = bendEndDispTerm + bendEndRotTerm - leadNode.LocalRightMz()
-		)
-		es.BendingMoment = appendIfNotSameAsLast(
-			es.BendingMoment,
-			PointSolutionValue{trailNode.T, trailBending},
-			maxDispError,
+			bendEndRotTerm    = eil * ((2.0-phi)*trailRz + (4.0+phi)*leadRz)
 		)
-		es.BendingMomentTopFiberAxialStress = appendIfNotSameAsLast(
-			es.BendingMomentTopFiberAxialStress,
-			PointSolutionValue{trailNode.T, trailBending / sStrong},
+
+		es.appendSliceStresses(
+			trailNode,
+			leadNode,
+			axial,
+			shearDispTerm+shearRotTerm,
+			bendStartDispTerm-bendStartRotTerm,
+			bendEndDispTerm+bendEndRotTerm,
 			maxDispError,
 		)
-		es.BendingMoment = append(es.BendingMoment, PointSolutionValue{leadNode.T, leadBending})
-		es.BendingMomentTopFiberAxialStress = append(
-			es.BendingMomentTopFiberAxialStress,
-			PointSolutionValue{leadNode.T, leadBending / sStrong},
-		)
 	}
 }
 
+// appendSliceStresses appends the stresses at both ends of the slice between the given nodes,
+// given the axial stress, shear force and bending moments due to the slice's deformation. The
+// equivalent loads of the slice in its nodes are subtracted to obtain the actual values.
+func (es *ElementSolution) appendSliceStresses(
+	trailNode, leadNode *preprocess.Node,
+	axial, shear, trailBending, leadBending float64,
+	maxDispError float64,
+) {
+	var (
+		area    = es.Section().Area
+		sStrong = es.Section().SStrong
+	)
+
+	/* <-- Axial --> */
+	var (
+		trailAxial = axial + (trailNode.LocalLeftFx() / area)
+		leadAxial  = axial - (leadNode.LocalRightFx() / area)
+	)
+	es.AxialStress = appendIfNotSameAsLast(
+		es.AxialStress,
+		PointSolutionValue{trailNode.T, trailAxial},
+		maxDispError,
+	)
+	es.AxialStress = append(es.AxialStress, PointSolutionValue{leadNode.T, leadAxial})
+
+	/* <-- Shear --> */
+	var (
+		trailShear = shear - trailNode.LocalLeftFy()
+		leadShear  = shear + leadNode.LocalRightFy()
+	)
+	es.ShearForce = appendIfNotSameAsLast(
+		es.ShearForce,
+		PointSolutionValue{trailNode.T, trailShear},
+		maxDispError,
+	)
+	es.ShearForce = append(es.ShearForce, PointSolutionValue{leadNode.T, leadShear})
+
+	/* <-- Bending --> */
+	trailBending += trailNode.LocalLeftMz()
+	leadBending -= leadNode.LocalRightMz()
+
+	es.BendingMoment = appendIfNotSameAsLast(
+		es.BendingMoment,
+		PointSolutionValue{trailNode.T, trailBending},
+		maxDispError,
+	)
+	es.BendingMomentTopFiberAxialStress = appendIfNotSameAsLast(
+		es.BendingMomentTopFiberAxialStress,
+		PointSolutionValue{trailNode.T, trailBending / sStrong},
+		maxDispError,
+	)
+	es.BendingMoment = append(es.BendingMoment, PointSolutionValue{leadNode.T, leadBending})
+	es.BendingMomentTopFiberAxialStress = append(
+		es.BendingMomentTopFiberAxialStress,
+		PointSolutionValue{leadNode.T, leadBending / sStrong},
+	)
+}
+
 // GlobalStartTorsor returns the forces and moment torsor {fx, fy, mz} at the start node
 // in global coordinates.
 //
@@ -0,0 +1,128 @@
+package process
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+// EnvelopeValue is the maximum and minimum values at a T parameter position across all the
+// load combinations, together with the names of the combinations which govern them.
+type EnvelopeValue struct {
+	T              nums.TParam
+	Max            float64
+	MaxCombination string
+	Min            float64
+	MinCombination string
+}
+
+func (ev EnvelopeValue) String() string {
+	return fmt.Sprintf(
+		"%f : %f %s %f %s", ev.T.Value(), ev.Max, ev.MaxCombination, ev.Min, ev.MinCombination,
+	)
+}
+
+// Equals returns true if both envelope values are at the same T position, with the same values
+// compared using the given epsilon, and governed by the same combinations.
+func (ev EnvelopeValue) Equals(other EnvelopeValue, epsilon float64) bool {
+	return ev.T.Equals(other.T) &&
+		nums.FloatsEqualEps(ev.Max, other.Max, epsilon) &&
+		nums.FloatsEqualEps(ev.Min, other.Min, epsilon) &&
+		ev.MaxCombination == other.MaxCombination &&
+		ev.MinCombination == other.MinCombination
+}
+
+// ElementEnvelope is the envelope of the axial stress, shear force and bending moment of an
+// element across all the load combinations.
+type ElementEnvelope struct {
+	*preprocess.Element
+
+	AxialStress   []EnvelopeValue
+	ShearForce    []EnvelopeValue
+	BendingMoment []EnvelopeValue
+}
+
+// MakeEnvelopes computes the envelope of each element given the solutions of the load
+// combinations. Returns no envelopes if there are no combination solutions.
+func MakeEnvelopes(combinations []*Solution) []*ElementEnvelope {
+	if len(combinations) == 0 {
+		return nil
+	}
+
+	var (
+		names     = make([]string, len(combinations))
+		envelopes = make([]*ElementEnvelope, combinations[0].ElementCount())
+	)
+
+	for i, combination := range combinations {
+		names[i] = combination.LoadCase
+	}
+
+	for j := range envelopes {
+		elementSolutions := make([]*ElementSolution, len(combinations))
+		for i, combination := range combinations {
+			elementSolutions[i] = combination.Elements[j]
+		}
+
+		envelopes[j] = makeElementEnvelope(elementSolutions, names)
+	}
+
+	return envelopes
+}
+
+func makeElementEnvelope(elementSolutions []*ElementSolution, names []string) *ElementEnvelope {
+	var (
+		first = elementSolutions[0]
+		nodes = first.Element.Nodes()
+	)
+
+	envelopeOf := func(values func(es *ElementSolution) []PointSolutionValue) []EnvelopeValue {
+		var envelope []EnvelopeValue
+
+		for i, es := range elementSolutions {
+			expanded := sliceEndValues(values(es), nodes)
+			if envelope == nil {
+				envelope = make([]EnvelopeValue, len(expanded))
+				for j, value := range expanded {
+					envelope[j] = EnvelopeValue{value.T, value.Value, names[i], value.Value, names[i]}
+				}
+
+				continue
+			}
+
+			for j, value := range expanded {
+				if value.Value > envelope[j].Max {
+					envelope[j].Max, envelope[j].MaxCombination = value.Value, names[i]
+				}
+				if value.Value < envelope[j].Min {
+					envelope[j].Min, envelope[j].MinCombination = value.Value, names[i]
+				}
+			}
+		}
+
+		return compactEnvelopeValues(envelope, first.maxDispError)
+	}
+
+	return &ElementEnvelope{
+		Element:       first.Element,
+		AxialStress:   envelopeOf(func(es *ElementSolution) []PointSolutionValue { return es.AxialStress }),
+		ShearForce:    envelopeOf(func(es *ElementSolution) []PointSolutionValue { return es.ShearForce }),
+		BendingMoment: envelopeOf(func(es *ElementSolution) []PointSolutionValue { return es.BendingMoment }),
+	}
+}
+
+// compactEnvelopeValues removes the envelope values at the start of the slices which are the
+// same as the value at the end of the previous slice.
+func compactEnvelopeValues(values []EnvelopeValue, epsilon float64) []EnvelopeValue {
+	compacted := make([]EnvelopeValue, 0, len(values))
+
+	for i := 0; i < len(values); i += 2 {
+		if len(compacted) == 0 || !compacted[len(compacted)-1].Equals(values[i], epsilon) {
+			compacted = append(compacted, values[i])
+		}
+		compacted = append(compacted, values[i+1])
+	}
+
+	return compacted
+}
@@ -0,0 +1,247 @@
+package process
+
+import (
+	gomath "math"
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+)
+
+// DefaultEquilibriumTolerance is the largest relative imbalance of the solutions used when none
+// is given. See EquilibriumCheck.IsWithinTolerance.
+const DefaultEquilibriumTolerance = 1e-4
+
+// An EquilibriumCheck is the verification that the solution of a load case is in equilibrium.
+//
+// The sums of the applied loads and the reactions are torsors in global coordinates, with the
+// moment about the origin. The reactions are those the supports can take: only the components
+// of the constrained degrees of freedom, in the support's frame, and the forces of the springs.
+//
+// The residual is the norm of K·u - f, where K is the stiffness matrix, u the displacements and
+// f the loads of the system of equations solved. When the system of equations is solved more
+// than once, removing the tension-only and compression-only elements, it's the last solve's:
+// that of the active elements, which yields the solution's displacements.
+//
+// The imbalance of a structural node is the sum of the forces it exerts on the elements, minus
+// the loads applied to it and its reaction. The node with the largest imbalance is kept: the
+// first by id if there are several.
+//
+// The imbalances are relative to the sums of the magnitudes of the applied loads and reactions,
+// but never less than the forces and moments of the system's loads, which include those due to
+// the prescribed displacements. A structure can move without straining, like a statically
+// determinate one whose support settles, and have neither loads nor reactions to compare with.
+// The moments of the system's loads are converted to forces dividing by the longest element's
+// length, and the forces to moments about the origin multiplying by the distance of the farthest
+// node to it.
+type EquilibriumCheck struct {
+	AppliedLoads       *math.Torsor
+	Reactions          *math.Torsor
+	ResidualNorm       float64
+	LoadNorm           float64
+	MaxNodeImbalanceID contracts.StrID
+	MaxNodeImbalance   *math.Torsor
+	Tolerance          float64
+	forceScale         float64
+	momentScale        float64
+}
+
+// CheckEquilibrium verifies the equilibrium of the solution of a load case, given the
+// displacements from the system of equations it was computed from, and the tolerance for the
+// relative imbalances. See EquilibriumCheck.
+func CheckEquilibrium(
+	solution *Solution,
+	displacements *GlobalDisplacementsVector,
+	tolerance float64,
+) *EquilibriumCheck {
+	check := &EquilibriumCheck{
+		AppliedLoads:     math.MakeNilTorsor(),
+		Reactions:        math.MakeNilTorsor(),
+		ResidualNorm:     displacements.ResidualNorm,
+		LoadNorm:         displacements.LoadNorm,
+		MaxNodeImbalance: math.MakeNilTorsor(),
+		Tolerance:        tolerance,
+	}
+
+	for _, element := range solution.Elements {
+		refFrame := element.RefFrame()
+
+		for _, node := range element.Element.Nodes() {
+			check.addAppliedLoad(node.NetLocalTorsor().ProjectedToGlobal(refFrame), node.Position)
+		}
+	}
+
+	var (
+		nodes      = solution.GetAllNodes()
+		imbalances = make([]*math.Torsor, len(nodes))
+	)
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].GetID() < nodes[j].GetID() })
+
+	for i, node := range nodes {
+		reaction := solution.supportReaction(node)
+
+		check.addReaction(reaction, node.Position)
+		imbalances[i] = solution.elementForcesInNode(node.GetID(), true).Minus(reaction)
+	}
+
+	check.addSystemLoadsScales(solution, displacements)
+
+	maxImbalance := -1.0
+	for i, node := range nodes {
+		if imbalance := check.relativeImbalance(imbalances[i]); imbalance > maxImbalance {
+			maxImbalance = imbalance
+			check.MaxNodeImbalanceID = node.GetID()
+			check.MaxNodeImbalance = imbalances[i]
+		}
+	}
+
+	return check
+}
+
+// addAppliedLoad adds the load, applied in the given position, to the sum of the applied loads.
+func (check *EquilibriumCheck) addAppliedLoad(load *math.Torsor, position *g2d.Point) {
+	momentAboutOrigin := momentAboutOrigin(load, position)
+
+	check.AppliedLoads = check.AppliedLoads.Plus(math.MakeTorsor(load.Fx(), load.Fy(), momentAboutOrigin))
+	check.forceScale += gomath.Abs(load.Fx()) + gomath.Abs(load.Fy())
+	check.momentScale += gomath.Abs(momentAboutOrigin)
+}
+
+// addReaction adds the reaction, in the given position, to the sum of the reactions.
+func (check *EquilibriumCheck) addReaction(reaction *math.Torsor, position *g2d.Point) {
+	momentAboutOrigin := momentAboutOrigin(reaction, position)
+
+	check.Reactions = check.Reactions.Plus(math.MakeTorsor(reaction.Fx(), reaction.Fy(), momentAboutOrigin))
+	check.forceScale += gomath.Abs(reaction.Fx()) + gomath.Abs(reaction.Fy())
+	check.momentScale += gomath.Abs(momentAboutOrigin)
+}
+
+// addSystemLoadsScales raises the scales of the relative imbalances to those of the forces and
+// moments of the system's loads, if larger.
+func (check *EquilibriumCheck) addSystemLoadsScales(
+	solution *Solution,
+	displacements *GlobalDisplacementsVector,
+) {
+	var (
+		longestElement = 0.0
+		farthestNode   = 0.0
+		forces         = displacements.LoadForcesNorm
+		moments        = displacements.LoadMomentsNorm
+	)
+
+	for _, element := range solution.Elements {
+		longestElement = gomath.Max(longestElement, element.Length())
+	}
+	for _, node := range solution.GetAllNodes() {
+		farthestNode = gomath.Max(farthestNode, gomath.Hypot(node.Position.X(), node.Position.Y()))
+	}
+
+	if longestElement > 0 {
+		check.forceScale = gomath.Max(check.forceScale, forces+moments/longestElement)
+	}
+	check.momentScale = gomath.Max(check.momentScale, moments+forces*farthestNode)
+}
+
+// Imbalance returns the sum of the applied loads and the reactions, which is zero when the
+// structure is in equilibrium.
+func (check *EquilibriumCheck) Imbalance() *math.Torsor {
+	return check.AppliedLoads.Plus(check.Reactions)
+}
+
+// RelativeForceImbalance returns the magnitude of the force of the imbalance, relative to the
+// sum of the magnitudes of the applied forces and reactions. See EquilibriumCheck.
+func (check *EquilibriumCheck) RelativeForceImbalance() float64 {
+	imbalance := check.Imbalance()
+	return relativeTo(gomath.Hypot(imbalance.Fx(), imbalance.Fy()), check.forceScale)
+}
+
+// RelativeMomentImbalance returns the magnitude of the moment of the imbalance, relative to the
+// sum of the magnitudes of the applied loads and reactions moments about the origin. See
+// EquilibriumCheck.
+func (check *EquilibriumCheck) RelativeMomentImbalance() float64 {
+	return relativeTo(gomath.Abs(check.Imbalance().Mz()), check.momentScale)
+}
+
+// RelativeResidual returns the norm of the residual relative to the norm of the loads of the
+// system of equations.
+func (check *EquilibriumCheck) RelativeResidual() float64 {
+	return relativeTo(check.ResidualNorm, check.LoadNorm)
+}
+
+// RelativeMaxNodeImbalance returns the relative imbalance of the node with the largest one: the
+// largest of its force and moment, relative to the sums of the magnitudes of the applied loads
+// and reactions forces and moments, respectively.
+func (check *EquilibriumCheck) RelativeMaxNodeImbalance() float64 {
+	return check.relativeImbalance(check.MaxNodeImbalance)
+}
+
+// relativeImbalance returns the largest of the force and moment of the imbalance, relative to
+// the sums of the magnitudes of the applied loads and reactions forces and moments.
+func (check *EquilibriumCheck) relativeImbalance(imbalance *math.Torsor) float64 {
+	var (
+		force  = relativeTo(gomath.Hypot(imbalance.Fx(), imbalance.Fy()), check.forceScale)
+		moment = relativeTo(gomath.Abs(imbalance.Mz()), check.momentScale)
+	)
+
+	return gomath.Max(force, moment)
+}
+
+// IsWithinTolerance returns true if all the relative imbalances and the relative residual are
+// below the check's tolerance.
+func (check *EquilibriumCheck) IsWithinTolerance() bool {
+	return check.RelativeForceImbalance() <= check.Tolerance &&
+		check.RelativeMomentImbalance() <= check.Tolerance &&
+		check.RelativeResidual() <= check.Tolerance &&
+		check.RelativeMaxNodeImbalance() <= check.Tolerance
+}
+
+// supportReaction computes the reaction in the node, in global coordinates, keeping only the
+// components the support can take: those of the constrained degrees of freedom, in the support's
+// frame, and the forces of the springs. A node without external constraint has no reaction.
+func (solution *Solution) supportReaction(node *structure.Node) *math.Torsor {
+	if !node.IsExternallyConstrained() {
+		return math.MakeNilTorsor()
+	}
+
+	var (
+		constraint = node.ExternalConstraint
+		frame      = constraint.RefFrame()
+		local      = solution.elementForcesInNode(node.GetID(), true).ProjectedTo(frame)
+		fx, fy, mz = local.Fx(), local.Fy(), local.Mz()
+	)
+
+	if constraint.AllowsDispX() {
+		fx = 0
+	}
+	if constraint.AllowsDispY() {
+		fy = 0
+	}
+	if constraint.AllowsRotation() {
+		mz = 0
+	}
+
+	reaction := math.MakeTorsor(fx, fy, mz).ProjectedToGlobal(frame)
+	if constraint.HasSprings() {
+		reaction = springsReaction(reaction, constraint, solution.globalDisplacementsInNode(node.GetID()))
+	}
+
+	return reaction
+}
+
+// momentAboutOrigin returns the moment of the torsor, applied in the given position, about the
+// origin of coordinates.
+func momentAboutOrigin(torsor *math.Torsor, position *g2d.Point) float64 {
+	return torsor.Mz() + position.X()*torsor.Fy() - position.Y()*torsor.Fx()
+}
+
+// relativeTo returns the value divided by the scale, or the value itself if the scale is zero.
+func relativeTo(value, scale float64) float64 {
+	if scale == 0 {
+		return value
+	}
+
+	return value / scale
+}
@@ -0,0 +1,375 @@
+package process
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// InfluenceKind is the kind of quantity whose influence line is computed.
+type InfluenceKind string
+
+const (
+	// ReactionInfluence is a component, in global coordinates, of the reaction in a node.
+	ReactionInfluence InfluenceKind = "reaction"
+
+	// EndForceInfluence is a component, in the element's local coordinates, of the torsor at one of
+	// the ends of an element, with the sign convention of the element's end torsors.
+	EndForceInfluence InfluenceKind = "end"
+
+	// MomentInfluence is the bending moment of an element at a position T.
+	MomentInfluence InfluenceKind = "moment"
+)
+
+// influenceComponents are the names of the torsor components {fx, fy, mz}.
+var influenceComponents = [3]string{"fx", "fy", "mz"}
+
+// An InfluenceQuantity is a reaction, a force at the end of an element or a bending moment whose
+// influence line is computed. The node, element, end and position used depend on its kind. The
+// component is the index of the torsor's component: 0 for fx, 1 for fy and 2 for mz.
+type InfluenceQuantity struct {
+	Kind      InfluenceKind
+	NodeID    contracts.StrID
+	ElementID contracts.StrID
+	AtEnd     bool
+	T         nums.TParam
+	Component int
+}
+
+// ParseInfluenceQuantity returns the quantity with the given specification, one of:
+//
+//   - reaction:<node>:<fx|fy|mz>, a reaction in an externally constrained node,
+//   - end:<bar>:<start|end>:<fx|fy|mz>, a force at one of the ends of an element,
+//   - moment:<bar>:<t>, the bending moment of an element at the position t.
+func ParseInfluenceQuantity(spec string) (InfluenceQuantity, error) {
+	var (
+		parts    = strings.Split(spec, ":")
+		quantity = InfluenceQuantity{Kind: InfluenceKind(parts[0])}
+		err      error
+	)
+
+	switch quantity.Kind {
+	case ReactionInfluence:
+		if len(parts) != 3 {
+			return quantity, fmt.Errorf("malformed reaction quantity: %s. Use reaction:<node>:<fx|fy|mz>", spec)
+		}
+
+		quantity.NodeID = contracts.StrID(parts[1])
+		quantity.Component, err = parseInfluenceComponent(parts[2])
+
+	case EndForceInfluence:
+		if len(parts) != 4 || (parts[2] != "start" && parts[2] != "end") {
+			return quantity, fmt.Errorf(
+				"malformed bar end quantity: %s. Use end:<bar>:<start|end>:<fx|fy|mz>", spec,
+			)
+		}
+
+		quantity.ElementID = contracts.StrID(parts[1])
+		quantity.AtEnd = parts[2] == "end"
+		quantity.Component, err = parseInfluenceComponent(parts[3])
+
+	case MomentInfluence:
+		if len(parts) != 3 {
+			return quantity, fmt.Errorf("malformed moment quantity: %s. Use moment:<bar>:<t>", spec)
+		}
+
+		t, parseErr := strconv.ParseFloat(parts[2], 64)
+		if parseErr != nil || t < nums.MinT.Value() || t > nums.MaxT.Value() {
+			return quantity, fmt.Errorf("the position of a moment must be a number in [0, 1]: %s", parts[2])
+		}
+
+		quantity.ElementID = contracts.StrID(parts[1])
+		quantity.T = nums.MakeTParam(t)
+		quantity.Component = 2
+
+	default:
+		return quantity, fmt.Errorf("unknown influence quantity: %s. Use one of: reaction, end, moment", spec)
+	}
+
+	return quantity, err
+}
+
+func parseInfluenceComponent(name string) (int, error) {
+	for i, component := range influenceComponents {
+		if name == component {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown torsor component: %s. Use one of: fx, fy, mz", name)
+}
+
+// String returns the quantity's specification (see ParseInfluenceQuantity).
+func (q InfluenceQuantity) String() string {
+	switch q.Kind {
+	case ReactionInfluence:
+		return fmt.Sprintf("reaction:%s:%s", q.NodeID, influenceComponents[q.Component])
+	case EndForceInfluence:
+		end := "start"
+		if q.AtEnd {
+			end = "end"
+		}
+
+		return fmt.Sprintf("end:%s:%s:%s", q.ElementID, end, influenceComponents[q.Component])
+	default:
+		return fmt.Sprintf("moment:%s:%s", q.ElementID, strconv.FormatFloat(q.T.Value(), 'f', -1, 64))
+	}
+}
+
+// InfluenceOptions includes configuration parameters for the influence lines analysis: the path
+// of elements the unit load travels along, in order, and the quantities whose influence lines
+// are computed.
+type InfluenceOptions struct {
+	Path       []contracts.StrID
+	Quantities []InfluenceQuantity
+}
+
+// An InfluencePoint is a position of the unit load: a node of one of the path's sliced elements.
+// The position is the distance, along the path, from its first point.
+type InfluencePoint struct {
+	ElementID contracts.StrID
+	T         nums.TParam
+	Position  float64
+}
+
+// An InfluenceLine is the value of a quantity for the unit load in each of the path's points.
+type InfluenceLine struct {
+	Quantity InfluenceQuantity
+	Values   []float64
+}
+
+// InfluenceSolution is the result of the influence lines analysis of a structure: the points of
+// the path, and the influence line of each of the quantities.
+type InfluenceSolution struct {
+	Metadata structure.StrMetadata
+	Path     []contracts.StrID
+	Points   []*InfluencePoint
+	Lines    []*InfluenceLine
+}
+
+// SolveInfluenceLines computes the influence lines of the quantities given in the options: their
+// values as a unit load, acting downwards in the global Y direction, travels along the path. The
+// unit load is placed in the nodes of the path's sliced elements, which are traversed from their
+// start to their end node.
+//
+// The influence lines are computed using the Müller-Breslau principle. As every quantity is a
+// linear function of the displacements, Q = gᵀ·u, and the displacements are u = K⁻¹·f, the value
+// of the quantity for any load is Q = ψᵀ·f, being ψ = K⁻¹·g the displacements of the structure
+// loaded with g. Thus, the stiffness matrix is factorized once and each influence line is the
+// vertical displacement, with its sign changed, of the structure loaded with its quantity's
+// functional g.
+//
+// The reactions include the unit load when it's applied directly in the node, and the elements'
+// axial behavior limits are ignored: all the elements are active. Panics if the path or the
+// quantities refer to elements or nodes that don't exist, if a reaction's node isn't externally
+// constrained, or if the structure is a mechanism.
+func SolveInfluenceLines(str *preprocess.Structure, options InfluenceOptions) *InfluenceSolution {
+	if len(options.Path) == 0 {
+		panic("The influence lines need a path of bars for the unit load")
+	}
+
+	var (
+		points   = makeInfluencePoints(str, options.Path)
+		pathDofs = influencePointsDyDofs(str, points)
+		lines    = make([]*InfluenceLine, len(options.Quantities))
+	)
+
+	log.StartAssembleSysEqs()
+	sysMatrix := str.MakeStiffnessMatrix()
+	log.EndAssembleSysEqs(str.DofsCount())
+
+	log.StartFactorizeSysEqs()
+	factorization, err := math.MakeLDLFactorization(sysMatrix)
+	if err != nil {
+		panic(fmt.Sprintf("Can't compute the influence lines, the structure is a mechanism: %s", err))
+	}
+	log.EndFactorizeSysEqs(factorization.ProfileSize())
+
+	log.StartInfluenceLines()
+
+	for i, quantity := range options.Quantities {
+		var (
+			functional  = influenceFunctional(str, quantity)
+			globalDispl = str.GlobalDisplacements(factorization.Solve(str.MakeSystemVector(functional)))
+			values      = make([]float64, len(points))
+		)
+
+		for j, dof := range pathDofs {
+			values[j] = -globalDispl.Value(dof)
+		}
+
+		if quantity.Kind == ReactionInfluence && quantity.Component == 1 {
+			nodeDof := str.GetNodeById(quantity.NodeID).DyDegreeOfFreedomNum()
+			for j, dof := range pathDofs {
+				if dof == nodeDof {
+					values[j] += 1.0
+				}
+			}
+		}
+
+		lines[i] = &InfluenceLine{Quantity: quantity, Values: values}
+	}
+
+	log.EndInfluenceLines(len(lines), len(points))
+
+	return &InfluenceSolution{
+		Metadata: structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		},
+		Path:   options.Path,
+		Points: points,
+		Lines:  lines,
+	}
+}
+
+// makeInfluencePoints returns the nodes of the path's sliced elements. The first node of an
+// element is skipped when the element starts where the previous one ends.
+func makeInfluencePoints(str *preprocess.Structure, path []contracts.StrID) []*InfluencePoint {
+	var (
+		points        []*InfluencePoint
+		position      = 0.0
+		lastEndNodeID contracts.StrID
+	)
+
+	for i, id := range path {
+		element := str.GetElementById(id)
+
+		for j, node := range element.Nodes() {
+			if j == 0 && i > 0 && element.StartNodeID() == lastEndNodeID {
+				continue
+			}
+
+			points = append(points, &InfluencePoint{
+				ElementID: id,
+				T:         node.T,
+				Position:  position + element.LengthBetween(nums.MinT, node.T),
+			})
+		}
+
+		position += element.Length()
+		lastEndNodeID = element.EndNodeID()
+	}
+
+	return points
+}
+
+// influencePointsDyDofs returns the degree of freedom of the global Y displacement of each of the
+// path's points, where the unit load is applied.
+func influencePointsDyDofs(str *preprocess.Structure, points []*InfluencePoint) []int {
+	dofs := make([]int, len(points))
+
+	for i, point := range points {
+		element := str.GetElementById(point.ElementID)
+
+		for _, node := range element.Nodes() {
+			if node.T.Equals(point.T) {
+				dofs[i] = node.DegreesOfFreedomNum()[1]
+				break
+			}
+		}
+	}
+
+	return dofs
+}
+
+// influenceFunctional returns the vector g of the quantity, whose dot product with the global
+// displacements of the structure is the quantity's value, excluding the loads applied directly
+// in the node of a reaction.
+func influenceFunctional(str *preprocess.Structure, quantity InfluenceQuantity) vec.ReadOnlyVector {
+	functional := vec.Make(str.DofsCount())
+
+	switch quantity.Kind {
+	case ReactionInfluence:
+		node := str.GetNodeById(quantity.NodeID)
+		if !node.IsExternallyConstrained() {
+			panic(fmt.Sprintf(
+				"Can't compute the influence line of the reaction in node %s: it isn't externally constrained",
+				quantity.NodeID,
+			))
+		}
+
+		for _, element := range str.Elements() {
+			if element.StartNodeID() == quantity.NodeID {
+				addSliceRowToFunctional(functional, element, 0, quantity.Component, 1.0)
+			} else if element.EndNodeID() == quantity.NodeID {
+				addSliceRowToFunctional(functional, element, element.NodesCount()-2, 3+quantity.Component, 1.0)
+			}
+		}
+
+	case EndForceInfluence:
+		var (
+			element     = str.GetElementById(quantity.ElementID)
+			slice, row  = 0, 0
+			cos, sin    = element.RefFrame().Cos(), element.RefFrame().Sin()
+			projections = [3][3]float64{{cos, sin, 0}, {-sin, cos, 0}, {0, 0, 1}}
+		)
+
+		if quantity.AtEnd {
+			slice, row = element.NodesCount()-2, 3
+		}
+
+		for k, factor := range projections[quantity.Component] {
+			if factor != 0 {
+				addSliceRowToFunctional(functional, element, slice, row+k, factor)
+			}
+		}
+
+	case MomentInfluence:
+		var (
+			element = str.GetElementById(quantity.ElementID)
+			slice   = sliceContainingT(element, quantity.T)
+			trailT  = element.NodeAt(slice).T
+			leadT   = element.NodeAt(slice + 1).T
+			ratio   = trailT.DistanceTo(quantity.T) / trailT.DistanceTo(leadT)
+		)
+
+		addSliceRowToFunctional(functional, element, slice, 2, ratio-1.0)
+		addSliceRowToFunctional(functional, element, slice, 5, ratio)
+	}
+
+	return functional
+}
+
+// addSliceRowToFunctional adds the row of the global stiffness matrix of the element's slice,
+// multiplied by the factor, to the slice's degrees of freedom in the functional.
+func addSliceRowToFunctional(
+	functional vec.MutableVector,
+	element *preprocess.Element,
+	slice, row int,
+	factor float64,
+) {
+	var (
+		trailNode, leadNode = element.NodeAt(slice), element.NodeAt(slice + 1)
+		stiffMat            = element.StiffnessGlobalMat(trailNode.T, leadNode.T)
+		trailDofs           = trailNode.DegreesOfFreedomNum()
+		leadDofs            = leadNode.DegreesOfFreedomNum()
+		dofs                = [6]int{
+			trailDofs[0], trailDofs[1], trailDofs[2], leadDofs[0], leadDofs[1], leadDofs[2],
+		}
+	)
+
+	for k, dof := range dofs {
+		functional.SetValue(dof, functional.Value(dof)+factor*stiffMat.Value(row, k))
+	}
+}
+
+// sliceContainingT returns the index of the element's slice which contains the position T.
+func sliceContainingT(element *preprocess.Element, t nums.TParam) int {
+	for i := 1; i < element.NodesCount()-1; i++ {
+		if !element.NodeAt(i).T.IsLessThan(t) {
+			return i - 1
+		}
+	}
+
+	return element.NodesCount() - 2
+}
@@ -0,0 +1,155 @@
+package process
+
+import (
+	"fmt"
+	gomath "math"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// ModalOptions includes configuration parameters for the modal analysis.
+//
+// The mass matrix is the consistent one, unless Lumped is set. The Gravity converts the
+// materials' density, a weight per unit volume, into a mass per unit volume. When it's not set,
+// the density is taken as a mass per unit volume.
+type ModalOptions struct {
+	ModesCount int
+	Lumped     bool
+	Gravity    float64
+	MaxError   float64
+	MaxIter    int
+}
+
+// A VibrationMode is a natural vibration mode of the structure: its frequency, with its mode
+// shape, and how much of the structure's mass it mobilizes in each of the global directions.
+//
+// The participation factor, Γ = φᵀ·M·r / φᵀ·M·φ, refers to the mode shape as normalized in the
+// elements' mode shapes, being r the rigid translation in the direction. The mass ratio is the
+// mode's effective mass, (φᵀ·M·r)² / φᵀ·M·φ, relative to the structure's total mass in the
+// direction; the ratios of all the modes add up to one.
+type VibrationMode struct {
+	AngularFrequency     float64
+	Frequency            float64
+	Period               float64
+	ParticipationFactorX float64
+	ParticipationFactorY float64
+	MassRatioX           float64
+	MassRatioY           float64
+	Elements             []*ElementModeShape
+
+	// shape is the system's mode shape vector, normalized as the elements' mode shapes.
+	shape vec.ReadOnlyVector
+}
+
+// ModalSolution is the result of the modal analysis of a structure: its natural vibration modes,
+// sorted by increasing frequency, and the total mass in each of the global directions.
+type ModalSolution struct {
+	Metadata   structure.StrMetadata
+	TotalMassX float64
+	TotalMassY float64
+	Modes      []*VibrationMode
+}
+
+// SolveModal computes the lowest natural frequencies, and their vibration mode shapes, of the
+// structure by solving the generalized eigenvalue problem K·φ = ω²·M·φ, where K is the stiffness
+// matrix and M the mass matrix of the sliced elements and nodal masses.
+//
+// The masses of the constrained degrees of freedom don't take part in the vibration, and aren't
+// included in the total masses. Note that the elements which aren't sliced, like axial members,
+// are modeled with a single finite element, thus their own local vibration modes aren't
+// accurately captured.
+//
+// Panics if the structure has no mass, or the eigenvalue problem can't be solved.
+func SolveModal(str *preprocess.Structure, options ModalOptions) *ModalSolution {
+	gravity := options.Gravity
+	if gravity == 0 {
+		gravity = 1.0
+	}
+
+	var (
+		stiffness  = str.MakeStiffnessMatrix()
+		mass       = str.MakeMassMatrix(options.Lumped, gravity)
+		rigidX     = str.MakeRigidTranslationVector(1, 0)
+		rigidY     = str.MakeRigidTranslationVector(0, 1)
+		totalMassX = rigidX.Times(mass.TimesVector(rigidX))
+		totalMassY = rigidY.Times(mass.TimesVector(rigidY))
+		solver     = math.SubspaceEigenSolver{
+			Count:    options.ModesCount,
+			MaxError: options.MaxError,
+			MaxIter:  options.MaxIter,
+		}
+	)
+
+	if totalMassX == 0 && totalMassY == 0 {
+		panic("The structure has no mass: set the density of the materials or add nodal masses")
+	}
+
+	if solver.MaxError == 0 {
+		solver.MaxError = DefaultEigenMaxError
+	}
+	if solver.MaxIter == 0 {
+		solver.MaxIter = DefaultEigenMaxIter
+	}
+
+	log.StartSolveEigenproblem()
+	pairs, err := solver.Solve(stiffness, mass)
+	if err != nil {
+		panic(fmt.Sprintf("Can't solve the modal eigenvalue problem: %s", err))
+	}
+	log.EndSolveEigenproblem("modal", len(pairs))
+
+	modes := make([]*VibrationMode, len(pairs))
+	for i, pair := range pairs {
+		var (
+			omega   = gomath.Sqrt(pair.Value)
+			scale   = modeShapeScale(str, str.GlobalDisplacements(pair.Vector))
+			shape   = pair.Vector.Scaled(scale)
+			gammaX  = participationFactor(mass, shape, rigidX)
+			gammaY  = participationFactor(mass, shape, rigidY)
+			modMass = shape.Times(mass.TimesVector(shape))
+		)
+
+		modes[i] = &VibrationMode{
+			AngularFrequency:     omega,
+			Frequency:            omega / (2.0 * gomath.Pi),
+			Period:               2.0 * gomath.Pi / omega,
+			ParticipationFactorX: gammaX,
+			ParticipationFactorY: gammaY,
+			MassRatioX:           massRatio(gammaX*gammaX*modMass, totalMassX),
+			MassRatioY:           massRatio(gammaY*gammaY*modMass, totalMassY),
+			Elements:             makeModeShapes(str, pair.Vector),
+			shape:                shape,
+		}
+	}
+
+	return &ModalSolution{
+		Metadata: structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		},
+		TotalMassX: totalMassX,
+		TotalMassY: totalMassY,
+		Modes:      modes,
+	}
+}
+
+// participationFactor computes the factor Γ = φᵀ·M·r / φᵀ·M·φ of the mode shape φ in the
+// direction of the rigid translation r.
+func participationFactor(mass mat.ReadOnlyMatrix, shape, rigid vec.ReadOnlyVector) float64 {
+	massTimesShape := mass.TimesVector(shape)
+	return rigid.Times(massTimesShape) / shape.Times(massTimesShape)
+}
+
+func massRatio(effectiveMass, totalMass float64) float64 {
+	if totalMass == 0 {
+		return 0.0
+	}
+
+	return effectiveMass / totalMass
+}
@@ -0,0 +1,79 @@
+package process
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// ElementModeShape is the shape of a mode (of buckling, for instance) in a preprocessed element:
+// the global displacements of each of its nodes.
+//
+// Mode shapes have no magnitude, only the relative displacements are meaningful. They are
+// normalized so that the largest displacement in the structure is one.
+type ElementModeShape struct {
+	*preprocess.Element
+
+	GlobalXDispl []PointSolutionValue
+	GlobalYDispl []PointSolutionValue
+	GlobalZRot   []PointSolutionValue
+}
+
+// makeModeShapes computes the shape of the mode given by the system's eigenvector in each of the
+// elements of the structure. The eigenvector is projected to the global frame and normalized so
+// that the translation with the largest absolute value is one.
+func makeModeShapes(str *preprocess.Structure, eigenvector vec.ReadOnlyVector) []*ElementModeShape {
+	var (
+		globalDispl = str.GlobalDisplacements(eigenvector)
+		scale       = modeShapeScale(str, globalDispl)
+		shapes      = make([]*ElementModeShape, str.ElementsCount())
+	)
+
+	for i, element := range str.Elements() {
+		var (
+			nodesCount = element.NodesCount()
+			shape      = &ElementModeShape{
+				Element:      element,
+				GlobalXDispl: make([]PointSolutionValue, nodesCount),
+				GlobalYDispl: make([]PointSolutionValue, nodesCount),
+				GlobalZRot:   make([]PointSolutionValue, nodesCount),
+			}
+		)
+
+		for j, node := range element.Nodes() {
+			dofs := node.DegreesOfFreedomNum()
+
+			shape.GlobalXDispl[j] = PointSolutionValue{node.T, scale * globalDispl.Value(dofs[0])}
+			shape.GlobalYDispl[j] = PointSolutionValue{node.T, scale * globalDispl.Value(dofs[1])}
+			shape.GlobalZRot[j] = PointSolutionValue{node.T, scale * globalDispl.Value(dofs[2])}
+		}
+
+		shapes[i] = shape
+	}
+
+	return shapes
+}
+
+// modeShapeScale returns the factor that makes the translation with the largest absolute value
+// in the displacements vector be one.
+func modeShapeScale(str *preprocess.Structure, globalDispl vec.ReadOnlyVector) float64 {
+	largest := 0.0
+
+	for _, element := range str.Elements() {
+		for _, node := range element.Nodes() {
+			dofs := node.DegreesOfFreedomNum()
+			for _, value := range [2]float64{globalDispl.Value(dofs[0]), globalDispl.Value(dofs[1])} {
+				if math.Abs(value) > math.Abs(largest) {
+					largest = value
+				}
+			}
+		}
+	}
+
+	if largest == 0 {
+		return 1.0
+	}
+
+	return 1.0 / largest
+}
@@ -0,0 +1,120 @@
+package process
+
+import (
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// ReactionEnvelope is the maximum and minimum values of each of the components {fx, fy, mz} of
+// the reaction in a node, in global coordinates, together with the load cases which govern them.
+type ReactionEnvelope struct {
+	NodeID  contracts.StrID
+	Max     [3]float64
+	MaxCase [3]string
+	Min     [3]float64
+	MinCase [3]string
+}
+
+// MovingLoadSolution is the result of moving a load train along a path of elements: the
+// envelopes of the reactions and the elements' stresses across all the train's positions. The
+// governing load cases are those of the positions.
+type MovingLoadSolution struct {
+	Metadata  structure.StrMetadata
+	Path      []contracts.StrID
+	Train     *load.LoadTrain
+	Positions []structure.MovingLoadPosition
+	Reactions []*ReactionEnvelope
+	Envelopes []*ElementEnvelope
+}
+
+// SolveMovingLoad computes the envelopes of the structure's reactions and elements' stresses as
+// the load train moves along the path. The structure's loads in each of the train's positions
+// are those in the position's load case (see structure.AddMovingLoadCases).
+//
+// Only the positions' load cases are solved, all of them with the same stiffness matrix: the
+// direct solver factorizes it once. The reactions include the axles applied directly in the
+// supports, and the elements' axial behavior limits are ignored: all the elements are active.
+func SolveMovingLoad(
+	str *preprocess.Structure,
+	path []contracts.StrID,
+	train *load.LoadTrain,
+	positions []structure.MovingLoadPosition,
+	options SolveOptions,
+) *MovingLoadSolution {
+	var (
+		loadCases  = make([]string, len(positions))
+		sysVectors = make([]vec.ReadOnlyVector, len(positions))
+		solutions  = make([]*Solution, len(positions))
+		metadata   = structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		}
+	)
+
+	log.StartAssembleSysEqs()
+	sysMatrix := str.MakeStiffnessMatrix()
+	for i, position := range positions {
+		loadCases[i] = position.LoadCase
+		sysVectors[i] = str.MakeLoadVector(position.LoadCase)
+	}
+	log.EndAssembleSysEqs(str.DofsCount())
+
+	globalDispl := solveSystems(str, sysMatrix, sysVectors, loadCases, options)
+
+	for i, loadCase := range loadCases {
+		elements := makeElementSolutions(str, loadCase, globalDispl[i])
+		solutions[i] = MakeSolution(metadata, loadCase, str.NodesById, elements, globalDispl[i].Vector)
+	}
+
+	return &MovingLoadSolution{
+		Metadata:  metadata,
+		Path:      path,
+		Train:     train,
+		Positions: positions,
+		Reactions: makeReactionEnvelopes(str, solutions),
+		Envelopes: MakeEnvelopes(solutions),
+	}
+}
+
+// makeReactionEnvelopes computes the envelope of the reactions in each of the externally
+// constrained nodes, sorted by id, across the given solutions. The reactions include the loads
+// applied directly in the nodes.
+func makeReactionEnvelopes(str *preprocess.Structure, solutions []*Solution) []*ReactionEnvelope {
+	var envelopes []*ReactionEnvelope
+
+	for _, node := range str.GetAllNodes() {
+		if !node.IsExternallyConstrained() {
+			continue
+		}
+
+		envelope := &ReactionEnvelope{NodeID: node.GetID()}
+		for i, solution := range solutions {
+			var (
+				reaction   = solution.reactionInNodeWithLoads(node.GetID(), true)
+				components = [3]float64{reaction.Fx(), reaction.Fy(), reaction.Mz()}
+			)
+
+			for k, value := range components {
+				if i == 0 || value > envelope.Max[k] {
+					envelope.Max[k], envelope.MaxCase[k] = value, solution.LoadCase
+				}
+				if i == 0 || value < envelope.Min[k] {
+					envelope.Min[k], envelope.MinCase[k] = value, solution.LoadCase
+				}
+			}
+		}
+
+		envelopes = append(envelopes, envelope)
+	}
+
+	sort.Slice(envelopes, func(i, j int) bool { return envelopes[i].NodeID < envelopes[j].NodeID })
+
+	return envelopes
+}
@@ -0,0 +1,265 @@
+package process
+
+import (
+	"fmt"
+	gomath "math"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+const (
+	// DefaultNonlinearLoadSteps is the number of load increments of the nonlinear analysis used
+	// when none is given.
+	DefaultNonlinearLoadSteps = 10
+
+	// DefaultNonlinearMaxError is the maximum residual force, relative to the applied loads, for
+	// the Newton–Raphson iterations to be considered converged, used when none is given.
+	DefaultNonlinearMaxError = 1e-6
+
+	// DefaultNonlinearMaxIter is the maximum number of Newton–Raphson iterations in each load
+	// increment used when none is given.
+	DefaultNonlinearMaxIter = 30
+)
+
+// NonlinearOptions includes configuration parameters for the geometrically nonlinear analysis.
+//
+// The loads of the given load case, the default case if none is given, are applied in equal
+// increments. In each increment, the Newton–Raphson iterations run until the largest residual
+// force is below the maximum error relative to the largest applied load. The displacements of
+// the path nodes are recorded at the end of each increment.
+type NonlinearOptions struct {
+	SolveOptions
+	LoadCase  string
+	LoadSteps int
+	MaxError  float64
+	MaxIter   int
+	PathNodes []contracts.StrID
+}
+
+// A LoadPathPoint is the equilibrium state of the structure at the end of a load increment: the
+// factor applied to the loads, the Newton–Raphson iterations it took to converge and the global
+// displacements {dx, dy, rz} of the path nodes.
+type LoadPathPoint struct {
+	LoadFactor    float64
+	Iterations    int
+	Displacements map[contracts.StrID][3]float64
+}
+
+// NonlinearSolution is the result of the geometrically nonlinear analysis of a structure: the
+// solution in the final deformed state, and the load–displacement path of the chosen nodes.
+type NonlinearSolution struct {
+	*Solution
+	PathNodes []contracts.StrID
+	Path      []*LoadPathPoint
+}
+
+// SolveNonlinear computes the geometrically nonlinear solution of the structure subject to the
+// loads in the load case given in the options, accounting for large displacements and rotations.
+//
+// Each of the elements' slices is modeled with the corotational beam formulation (see
+// structure.Element.CorotationalState). The loads are applied in increments, and in each of them,
+// the equilibrium is found by Newton–Raphson iterations using the tangent stiffness matrix. The
+// loads keep their initial direction (they aren't follower loads), and the prescribed
+// displacements are applied in the same increments.
+//
+// The final solution's stresses are those of the slices in their deformed configuration, in the
+// frame of their chord.
+//
+// Panics if an increment doesn't converge, which can happen when the structure reaches a limit
+// point (snap-through or buckling) or the increments are too large.
+func SolveNonlinear(str *preprocess.Structure, options NonlinearOptions) *NonlinearSolution {
+	var (
+		loadCase                       = nonlinearLoadCase(options)
+		steps                          = options.LoadSteps
+		maxError                       = options.MaxError
+		maxIter                        = options.MaxIter
+		loads                          = str.MakeExternalLoadVector(loadCase)
+		loadsNorm                      = maxAbsTerm(loads)
+		sysDispl    vec.ReadOnlyVector = vec.Make(str.DofsCount())
+		globalDispl vec.ReadOnlyVector
+		path        = make([]*LoadPathPoint, 0, steps)
+	)
+
+	if steps == 0 {
+		steps = DefaultNonlinearLoadSteps
+	}
+	if maxError == 0 {
+		maxError = DefaultNonlinearMaxError
+	}
+	if maxIter == 0 {
+		maxIter = DefaultNonlinearMaxIter
+	}
+
+	for step := 1; step <= steps; step++ {
+		var (
+			factor     = float64(step) / float64(steps)
+			target     = loads.Scaled(factor)
+			iterations = -1
+		)
+
+		for iter := 0; iter <= maxIter; iter++ {
+			globalDispl = str.GlobalDisplacements(sysDispl)
+
+			var (
+				sysMatrix, forces = str.MakeTangentSystem(sysDispl, corotationalSliceState(globalDispl))
+				residual          = target.Minus(forces)
+				relativeResidual  = maxAbsTerm(residual) / gomath.Max(factor*loadsNorm, gomath.SmallestNonzeroFloat64)
+			)
+
+			log.NonlinearIteration(step, iter, relativeResidual)
+
+			if relativeResidual <= maxError {
+				iterations = iter
+				break
+			}
+
+			if iter < maxIter {
+				sysDispl = sysDispl.Plus(solveIncrement(sysMatrix, residual, loadCase, options.SolveOptions))
+			}
+		}
+
+		if iterations < 0 {
+			panic(fmt.Sprintf(
+				"Nonlinear analysis of \"%s\" didn't converge in the load increment %d (factor %f) after %d iterations",
+				loadCase, step, factor, maxIter,
+			))
+		}
+
+		log.EndNonlinearStep(step, factor, iterations)
+		path = append(path, makeLoadPathPoint(str, options.PathNodes, factor, iterations, globalDispl))
+	}
+
+	var (
+		displacements = &GlobalDisplacementsVector{Vector: globalDispl, MaxError: options.MaxDisplacementsError}
+		elements      = make([]*ElementSolution, str.ElementsCount())
+		metadata      = structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		}
+	)
+
+	log.StartComputeStresses()
+	for i, element := range str.Elements() {
+		elements[i] = makeCorotationalElementSolution(element.InLoadCase(loadCase), displacements)
+	}
+	log.EndComputeStresses(loadCase)
+
+	return &NonlinearSolution{
+		Solution:  MakeSolution(metadata, loadCase, str.NodesById, elements, globalDispl),
+		PathNodes: options.PathNodes,
+		Path:      path,
+	}
+}
+
+func nonlinearLoadCase(options NonlinearOptions) string {
+	if options.LoadCase == "" {
+		return load.DefaultCase
+	}
+
+	return options.LoadCase
+}
+
+// solveIncrement solves the tangent system of equations for the residual forces, using the
+// solver chosen in the options.
+func solveIncrement(
+	sysMatrix mat.ReadOnlyMatrix,
+	residual vec.ReadOnlyVector,
+	loadCase string,
+	options SolveOptions,
+) vec.ReadOnlyVector {
+	return solveSysEqs(sysMatrix, []vec.ReadOnlyVector{residual}, []string{loadCase}, options)[0].Vector
+}
+
+// corotationalSliceState returns the function that computes the corotational state of the
+// elements' slices for the given global displacements.
+func corotationalSliceState(globalDispl vec.ReadOnlyVector) preprocess.SliceStateFunc {
+	return func(element *preprocess.Element, slice int) (mat.ReadOnlyMatrix, [6]float64) {
+		state := sliceCorotationalState(element, slice, globalDispl)
+		return state.TangentStiffness, state.GlobalForces
+	}
+}
+
+// sliceCorotationalState computes the corotational state of the element's slice between the nodes
+// at the given index and the next one.
+func sliceCorotationalState(
+	element *preprocess.Element,
+	slice int,
+	globalDispl vec.ReadOnlyVector,
+) *structure.CorotationalState {
+	var (
+		trailNode, leadNode = element.NodeAt(slice), element.NodeAt(slice + 1)
+		trailDofs, leadDofs = trailNode.DegreesOfFreedomNum(), leadNode.DegreesOfFreedomNum()
+	)
+
+	return element.CorotationalState(trailNode.T, leadNode.T, [6]float64{
+		globalDispl.Value(trailDofs[0]), globalDispl.Value(trailDofs[1]), globalDispl.Value(trailDofs[2]),
+		globalDispl.Value(leadDofs[0]), globalDispl.Value(leadDofs[1]), globalDispl.Value(leadDofs[2]),
+	})
+}
+
+// makeCorotationalElementSolution creates a solution element with the displacements and the
+// stresses of each of its slices in their deformed configuration.
+func makeCorotationalElementSolution(
+	element *preprocess.Element,
+	globalDisp *GlobalDisplacementsVector,
+) *ElementSolution {
+	solution := makeDisplacedElementSolution(element, globalDisp)
+
+	for i := 1; i < element.NodesCount(); i++ {
+		state := sliceCorotationalState(element, i-1, globalDisp.Vector)
+
+		solution.appendSliceStresses(
+			element.NodeAt(i-1),
+			element.NodeAt(i),
+			state.AxialForce/element.Section().Area,
+			(state.StartMoment+state.EndMoment)/state.ChordLength,
+			-state.StartMoment,
+			state.EndMoment,
+			globalDisp.MaxError,
+		)
+	}
+
+	return solution
+}
+
+// makeLoadPathPoint collects the global displacements of the path nodes.
+func makeLoadPathPoint(
+	str *preprocess.Structure,
+	nodeIds []contracts.StrID,
+	factor float64,
+	iterations int,
+	globalDispl vec.ReadOnlyVector,
+) *LoadPathPoint {
+	point := &LoadPathPoint{
+		LoadFactor:    factor,
+		Iterations:    iterations,
+		Displacements: make(map[contracts.StrID][3]float64, len(nodeIds)),
+	}
+
+	for _, id := range nodeIds {
+		dofs := str.GetNodeById(id).DegreesOfFreedomNum()
+		point.Displacements[id] = [3]float64{
+			globalDispl.Value(dofs[0]),
+			globalDispl.Value(dofs[1]),
+			globalDispl.Value(dofs[2]),
+		}
+	}
+
+	return point
+}
+
+func maxAbsTerm(vector vec.ReadOnlyVector) float64 {
+	maxValue := 0.0
+	for i := 0; i < vector.Length(); i++ {
+		maxValue = gomath.Max(maxValue, gomath.Abs(vector.Value(i)))
+	}
+
+	return maxValue
+}
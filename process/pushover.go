@@ -0,0 +1,334 @@
+package process
+
+import (
+	"fmt"
+	gomath "math"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+const (
+	// DefaultPushoverShapeFactor is the ratio between the plastic and elastic section moduli used
+	// when none is given: the hinges form when the bending moment reaches the yield moment.
+	DefaultPushoverShapeFactor = 1.0
+
+	// mechanismFlexibilityRatio is the largest ratio between the displacements of the hinged and
+	// elastic structures, for the same loads, before the hinged structure is considered a
+	// mechanism. The roundoff errors can hide the zero pivots of a singular stiffness matrix, but
+	// not the huge displacements they yield.
+	mechanismFlexibilityRatio = 1e8
+
+	// negligibleMomentRatio is the ratio to the largest bending moment increment below which the
+	// increments are considered zero: the bending moment doesn't change at that slice end.
+	negligibleMomentRatio = 1e-9
+)
+
+// PushoverOptions includes configuration parameters for the plastic hinge analysis.
+//
+// The loads of the given load case, the default case if none is given, are the reference loads
+// which are increased proportionally. The plastic moment of a section is its shape factor times
+// its yield moment, the product of the material's yield strength and the section's strong axis
+// modulus. The displacements of the path nodes are recorded as each hinge forms.
+type PushoverOptions struct {
+	LoadCase    string
+	ShapeFactor float64
+	PathNodes   []contracts.StrID
+}
+
+// A PlasticHinge forms in an element, at the position T, when its bending moment reaches the
+// plastic moment under the loads multiplied by the load factor. The moment is that of the
+// element when the hinge forms, whose sign is that of the bending. The displacements are the
+// global displacements {dx, dy, rz} of the path nodes at that moment.
+type PlasticHinge struct {
+	ElementID     contracts.StrID
+	T             nums.TParam
+	LoadFactor    float64
+	Moment        float64
+	Displacements map[contracts.StrID][3]float64
+}
+
+// PushoverSolution is the result of the plastic hinge analysis of a structure: the hinges in the
+// order they form, and the load factor at which they turn the structure into a mechanism.
+type PushoverSolution struct {
+	Metadata       structure.StrMetadata
+	LoadCase       string
+	CollapseFactor float64
+	PathNodes      []contracts.StrID
+	Hinges         []*PlasticHinge
+}
+
+// SolvePushover computes the collapse load factor of the structure subject to the loads in the
+// load case given in the options, and the sequence of plastic hinges leading to it.
+//
+// The analysis goes from one event, the formation of a hinge, to the next one. In each event, the
+// structure with the hinges formed so far is solved for the reference loads, which yields the
+// increments of the bending moments per unit load factor. As the structure's behavior is linear
+// between events, the next hinge forms at the slice end whose moment reaches the plastic moment
+// with the smallest load factor increment. A hinge is modeled as a released rotation at the end
+// of a slice (see preprocess.Hinge), thus its moment stays constant from then on. The hinges are
+// assumed not to unload, and the interaction of the bending moment with the axial force isn't
+// considered.
+//
+// The analysis ends when the hinged structure is a mechanism: its stiffness matrix is singular.
+//
+// Panics if an element which isn't an axial member has no plastic moment, if the structure is a
+// mechanism before any hinge forms, or if the bending moments don't increase with the loads, so
+// no hinges can form.
+func SolvePushover(str *preprocess.Structure, options PushoverOptions) *PushoverSolution {
+	var (
+		loadCase           = pushoverLoadCase(options)
+		shapeFactor        = options.ShapeFactor
+		plasticMoments     = make(map[contracts.StrID]float64, str.ElementsCount())
+		moments            = make([][][2]float64, str.ElementsCount())
+		displacements      = make(map[contracts.StrID][3]float64, len(options.PathNodes))
+		hinges             []preprocess.Hinge
+		plasticHinges      []*PlasticHinge
+		factor             = 0.0
+		elasticFlexibility = 0.0
+	)
+
+	if shapeFactor == 0 {
+		shapeFactor = DefaultPushoverShapeFactor
+	}
+
+	for i, element := range str.Elements() {
+		moments[i] = make([][2]float64, element.NodesCount()-1)
+
+		if element.IsAxialMember() {
+			continue
+		}
+
+		plasticMoment := shapeFactor * element.Material().YieldStrength * element.Section().SStrong
+		if plasticMoment <= 0 {
+			panic(fmt.Sprintf(
+				"Element %s: the plastic hinges require the material's yield strength and the section's modulus",
+				element.GetID(),
+			))
+		}
+
+		plasticMoments[element.GetID()] = plasticMoment
+	}
+
+	for _, id := range options.PathNodes {
+		displacements[id] = [3]float64{}
+	}
+
+	log.StartPushover()
+
+	for {
+		sysMatrix, sysVector := str.MakeHingedSystem(hinges, loadCase)
+
+		factorization, err := math.MakeLDLFactorization(sysMatrix)
+		if err != nil || factorization.NegativePivotsCount() > 0 {
+			break
+		}
+
+		var (
+			globalDispl = str.GlobalDisplacements(factorization.Solve(sysVector))
+			flexibility = maxAbsTerm(globalDispl)
+		)
+
+		if len(hinges) == 0 {
+			elasticFlexibility = flexibility
+		} else if flexibility > mechanismFlexibilityRatio*elasticFlexibility {
+			break
+		}
+
+		var (
+			increments  = hingedSliceMoments(str, loadCase, hinges, globalDispl)
+			hinge, step = nextPlasticHinge(str, plasticMoments, moments, increments, hinges)
+		)
+
+		if step < 0 {
+			panic(fmt.Sprintf(
+				"Pushover analysis of \"%s\": the bending moments don't increase with the loads, no more hinges can form",
+				loadCase,
+			))
+		}
+
+		var (
+			elementIndex = elementIndexOf(str, hinge.ElementID)
+			element      = str.Elements()[elementIndex]
+			end          = 0
+		)
+
+		factor += step
+		for i := range moments {
+			for j := range moments[i] {
+				moments[i][j][0] += step * increments[i][j][0]
+				moments[i][j][1] += step * increments[i][j][1]
+			}
+		}
+
+		for _, id := range options.PathNodes {
+			var (
+				dofs  = str.GetNodeById(id).DegreesOfFreedomNum()
+				displ = displacements[id]
+			)
+
+			for k, dof := range dofs {
+				displ[k] += step * globalDispl.Value(dof)
+			}
+
+			displacements[id] = displ
+		}
+
+		if hinge.AtEnd {
+			end = 1
+		}
+
+		plasticHinge := &PlasticHinge{
+			ElementID:     hinge.ElementID,
+			T:             element.NodeAt(hinge.NodeIndex()).T,
+			LoadFactor:    factor,
+			Moment:        moments[elementIndex][hinge.Slice][end],
+			Displacements: make(map[contracts.StrID][3]float64, len(displacements)),
+		}
+		for id, displ := range displacements {
+			plasticHinge.Displacements[id] = displ
+		}
+
+		hinges = append(hinges, hinge)
+		plasticHinges = append(plasticHinges, plasticHinge)
+		log.PushoverHinge(len(plasticHinges), string(hinge.ElementID), plasticHinge.T.Value(), factor)
+	}
+
+	if len(plasticHinges) == 0 {
+		panic(fmt.Sprintf("Pushover analysis of \"%s\": the structure is a mechanism", loadCase))
+	}
+
+	log.EndPushover(len(plasticHinges), factor)
+
+	return &PushoverSolution{
+		Metadata: structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		},
+		LoadCase:       loadCase,
+		CollapseFactor: factor,
+		PathNodes:      options.PathNodes,
+		Hinges:         plasticHinges,
+	}
+}
+
+func pushoverLoadCase(options PushoverOptions) string {
+	if options.LoadCase == "" {
+		return load.DefaultCase
+	}
+
+	return options.LoadCase
+}
+
+// hingedSliceMoments computes the bending moments at the start and end of each of the elements'
+// slices, given the global displacements of the structure with the hinges, for the loads in the
+// load case. The moments are computed as in the element solutions (see MakeElementSolution).
+func hingedSliceMoments(
+	str *preprocess.Structure,
+	loadCase string,
+	hinges []preprocess.Hinge,
+	globalDispl vec.ReadOnlyVector,
+) [][][2]float64 {
+	moments := make([][][2]float64, str.ElementsCount())
+
+	for i, element := range str.Elements() {
+		element = element.InLoadCase(loadCase)
+		moments[i] = make([][2]float64, element.NodesCount()-1)
+
+		for j := range moments[i] {
+			var (
+				trailNode, leadNode = element.NodeAt(j), element.NodeAt(j + 1)
+				stiffMat            = element.StiffnessGlobalMat(trailNode.T, leadNode.T)
+				dofs                = str.HingedSliceDofs(element, j, hinges)
+				trailMoment         = 0.0
+				leadMoment          = 0.0
+			)
+
+			for k, dof := range dofs {
+				trailMoment += stiffMat.Value(2, k) * globalDispl.Value(dof)
+				leadMoment += stiffMat.Value(5, k) * globalDispl.Value(dof)
+			}
+
+			moments[i][j] = [2]float64{
+				-trailMoment + trailNode.LocalLeftMz(),
+				leadMoment - leadNode.LocalRightMz(),
+			}
+		}
+	}
+
+	return moments
+}
+
+// nextPlasticHinge finds the slice end, without a hinge, whose bending moment reaches the plastic
+// moment with the smallest load factor increment, given the current moments and their increments
+// per unit load factor. Returns the hinge at that slice end and the load factor increment, which
+// is negative if no slice end can reach its plastic moment.
+func nextPlasticHinge(
+	str *preprocess.Structure,
+	plasticMoments map[contracts.StrID]float64,
+	moments, increments [][][2]float64,
+	hinges []preprocess.Hinge,
+) (preprocess.Hinge, float64) {
+	var (
+		isHinged     = make(map[preprocess.Hinge]bool, len(hinges))
+		maxIncrement = 0.0
+		next         preprocess.Hinge
+		minStep      = -1.0
+	)
+
+	for _, hinge := range hinges {
+		isHinged[hinge] = true
+	}
+
+	for i := range increments {
+		for j := range increments[i] {
+			maxIncrement = gomath.Max(maxIncrement, gomath.Abs(increments[i][j][0]))
+			maxIncrement = gomath.Max(maxIncrement, gomath.Abs(increments[i][j][1]))
+		}
+	}
+
+	for i, element := range str.Elements() {
+		plasticMoment, canYield := plasticMoments[element.GetID()]
+		if !canYield {
+			continue
+		}
+
+		for j := range increments[i] {
+			for end := 0; end < 2; end++ {
+				var (
+					hinge     = preprocess.Hinge{ElementID: element.GetID(), Slice: j, AtEnd: end == 1}
+					increment = increments[i][j][end]
+					target    = gomath.Copysign(plasticMoment, increment)
+				)
+
+				if isHinged[hinge] || gomath.Abs(increment) <= negligibleMomentRatio*maxIncrement {
+					continue
+				}
+
+				step := gomath.Max((target-moments[i][j][end])/increment, 0.0)
+				if minStep < 0 || step < minStep {
+					next, minStep = hinge, step
+				}
+			}
+		}
+	}
+
+	return next, minStep
+}
+
+func elementIndexOf(str *preprocess.Structure, id contracts.StrID) int {
+	for i, element := range str.Elements() {
+		if element.GetID() == id {
+			return i
+		}
+	}
+
+	panic(fmt.Sprintf("Can't find element with id %s", id))
+}
@@ -0,0 +1,175 @@
+package process
+
+import (
+	"fmt"
+	gomath "math"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+// A MomentAmplification compares the largest bending moment, in absolute value, in an element
+// in the first-order and second-order solutions.
+type MomentAmplification struct {
+	ElementID   contracts.StrID
+	FirstOrder  float64
+	SecondOrder float64
+}
+
+// Factor is the ratio between the second-order and first-order largest bending moments. An
+// element without bending moments in the first-order solution has a factor of one.
+func (amplification MomentAmplification) Factor() float64 {
+	if amplification.FirstOrder == 0 {
+		return 1.0
+	}
+
+	return amplification.SecondOrder / amplification.FirstOrder
+}
+
+// MomentAmplifications computes the amplification of the bending moments in each of the elements
+// of the second-order solution with respect to the first-order solution of the same load case.
+//
+// Panics if the solutions don't have the same number of elements.
+func MomentAmplifications(firstOrder, secondOrder *Solution) []MomentAmplification {
+	if firstOrder.ElementCount() != secondOrder.ElementCount() {
+		panic(fmt.Sprintf(
+			"Can't compare solutions with %d and %d elements",
+			firstOrder.ElementCount(), secondOrder.ElementCount(),
+		))
+	}
+
+	amplifications := make([]MomentAmplification, firstOrder.ElementCount())
+	for i, element := range secondOrder.Elements {
+		amplifications[i] = MomentAmplification{
+			ElementID:   element.GetID(),
+			FirstOrder:  maxAbsValue(firstOrder.Elements[i].BendingMoment),
+			SecondOrder: maxAbsValue(element.BendingMoment),
+		}
+	}
+
+	return amplifications
+}
+
+// solveSecondOrder computes the P-Delta solution of the elements for the loads in the load case,
+// starting from their first-order solution, and the structure's displacements they're computed
+// from.
+//
+// In each iteration, the geometric stiffness terms of the sliced elements, subject to the axial
+// forces of the previous iteration, are added to the stiffness matrix, and the system of
+// equations is solved again. The iteration stops when the relative change of the displacements
+// is below the maximum error in the options.
+//
+// The element's stresses are computed using their elastic stiffness and the second-order
+// displacements, and the geometric stiffness terms of the prescribed displacements are neglected.
+// Panics if the displacements don't converge. When the axial forces exceed the structure's
+// critical buckling load, the iteration might converge to a meaningless solution: the safe checks
+// in the options detect this case, at the cost of factorizing the stiffness matrix.
+func solveSecondOrder(
+	str *preprocess.Structure,
+	loadCase string,
+	firstOrderDispl *GlobalDisplacementsVector,
+	firstOrder []*ElementSolution,
+	options SolveOptions,
+) ([]*ElementSolution, *GlobalDisplacementsVector) {
+	var (
+		maxError   = options.SecondOrderMaxError
+		maxIter    = options.SecondOrderMaxIter
+		sysVectors = []vec.ReadOnlyVector{str.MakeLoadVector(loadCase)}
+		loadCases  = []string{loadCase}
+		elements   = firstOrder
+		prevDispl  = firstOrderDispl.Vector
+	)
+
+	if maxError == 0 {
+		maxError = DefaultSecondOrderMaxError
+	}
+	if maxIter == 0 {
+		maxIter = DefaultSecondOrderMaxIter
+	}
+
+	for iter := 1; iter <= maxIter; iter++ {
+		log.StartAssembleSysEqs()
+		sysMatrix := str.MakeSecondOrderStiffnessMatrix(sliceAxialForces(elements))
+		log.EndAssembleSysEqs(str.DofsCount())
+
+		if options.SafeChecks {
+			ensureIsStable(sysMatrix, loadCase)
+		}
+
+		displ := solveSystems(str, sysMatrix, sysVectors, loadCases, options)[0]
+		elements = makeElementSolutions(str, loadCase, displ)
+
+		change := relativeChange(displ.Vector, prevDispl)
+		log.SecondOrderProgress(loadCase, iter, change)
+
+		if change <= maxError {
+			log.EndSecondOrder(loadCase, iter)
+			return elements, displ
+		}
+
+		prevDispl = displ.Vector
+	}
+
+	panic(fmt.Sprintf(
+		"Second-order analysis of \"%s\" didn't converge after %d iterations", loadCase, maxIter,
+	))
+}
+
+// ensureIsStable panics if the second-order stiffness matrix isn't positive definite, which
+// means that the axial forces exceed the structure's critical buckling load.
+func ensureIsStable(sysMatrix mat.ReadOnlyMatrix, loadCase string) {
+	factorization, err := math.MakeLDLFactorization(sysMatrix)
+	if err != nil || factorization.NegativePivotsCount() > 0 {
+		panic(fmt.Sprintf(
+			"Second-order analysis of \"%s\": the axial forces exceed the critical buckling load", loadCase,
+		))
+	}
+}
+
+// makeElementSolutions computes the solution of each of the structure's elements for the loads in
+// the load case, given the structure's global displacements.
+func makeElementSolutions(
+	str *preprocess.Structure,
+	loadCase string,
+	globalDispl *GlobalDisplacementsVector,
+) []*ElementSolution {
+	elementSolutions := make([]*ElementSolution, str.ElementsCount())
+
+	log.StartComputeStresses()
+	for j, element := range str.Elements() {
+		elementSolutions[j] = MakeElementSolution(element.InLoadCase(loadCase), globalDispl)
+	}
+	log.EndComputeStresses(loadCase)
+
+	return elementSolutions
+}
+
+// relativeChange computes the largest absolute difference between the terms of the vectors,
+// relative to the largest absolute term of the current vector.
+func relativeChange(current, previous vec.ReadOnlyVector) float64 {
+	var maxDiff, maxValue float64
+
+	for i := 0; i < current.Length(); i++ {
+		maxDiff = gomath.Max(maxDiff, gomath.Abs(current.Value(i)-previous.Value(i)))
+		maxValue = gomath.Max(maxValue, gomath.Abs(current.Value(i)))
+	}
+
+	if maxValue == 0 {
+		return maxDiff
+	}
+
+	return maxDiff / maxValue
+}
+
+func maxAbsValue(values []PointSolutionValue) float64 {
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = gomath.Max(maxValue, gomath.Abs(value.Value))
+	}
+
+	return maxValue
+}
@@ -3,26 +3,53 @@ package process
 import (
 	"github.com/angelsolaorbaiceta/inkfem/contracts"
 	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
 )
 
-// Solution is the group of all element solutions with the structure metadata.
+// Solution is the group of all element solutions with the structure metadata, for the loads
+// in a load case.
+//
+// The solution of a load combination has the combination's name as load case, and includes
+// the combination definition.
+//
+// The inactive elements are the tension-only and compression-only elements removed from the
+// structure to solve the load case, sorted by id. Their solutions have no stresses.
+//
+// The solutions of the load cases include the verification of their equilibrium. See
+// CheckEquilibrium.
+//
+// The global displacements are those of the structure's degrees of freedom, in global
+// coordinates. The springs of the supports react to the displacements of the nodes, which aren't
+// those of the ends of the elements when these have links.
 type Solution struct {
-	Metadata structure.StrMetadata
+	Metadata    structure.StrMetadata
+	LoadCase    string
+	Combination *load.Combination
 	structure.NodesById
-	Elements []*ElementSolution
+	Elements            []*ElementSolution
+	GlobalDisplacements vec.ReadOnlyVector
+	InactiveElements    []contracts.StrID
+	Equilibrium         *EquilibriumCheck
 }
 
-// MakeSolution creates a new solution with the given structure metadata, nodes and elements.
+// MakeSolution creates a new solution with the given structure metadata, load case, nodes,
+// elements and global displacements.
 func MakeSolution(
 	metadata structure.StrMetadata,
+	loadCase string,
 	nodesById structure.NodesById,
 	elements []*ElementSolution,
+	globalDisplacements vec.ReadOnlyVector,
 ) *Solution {
 	return &Solution{
-		Metadata:  metadata,
-		NodesById: nodesById,
-		Elements:  elements,
+		Metadata:            metadata,
+		LoadCase:            loadCase,
+		NodesById:           nodesById,
+		Elements:            elements,
+		GlobalDisplacements: globalDisplacements,
 	}
 }
 
@@ -45,28 +72,113 @@ func (solution *Solution) NodeReactions() map[contracts.StrID]*math.Torsor {
 	return nodeReactions
 }
 
+// InclinedNodeReactions returns the map of nodes with an inclined external constraint with
+// their reaction projected in the support's local frame.
+func (solution *Solution) InclinedNodeReactions() map[contracts.StrID]*math.Torsor {
+	nodeReactions := make(map[contracts.StrID]*math.Torsor)
+
+	for _, node := range solution.GetAllNodes() {
+		if constraint := node.ExternalConstraint; constraint.IsInclined() {
+			nodeReactions[node.GetID()] = solution.reactionInNode(node.GetID()).ProjectedTo(constraint.RefFrame())
+		}
+	}
+
+	return nodeReactions
+}
+
 // ReactionInNode computes the reaction torsor {fx, fy, mz} in the node with the passed in ID
 // in global coordinates.
 //
 // If the node isn't externally constrained, the reaction will always be a nil torsor {0, 0, 0}.
 // If the structure contains no node with the given ID, it'll panic.
 func (solution *Solution) reactionInNode(nodeId contracts.StrID) *math.Torsor {
-	var (
-		node     = solution.GetNodeById(nodeId)
-		reaction = math.MakeNilTorsor()
-	)
+	return solution.reactionInNodeWithLoads(nodeId, false)
+}
 
+// reactionInNodeWithLoads computes the reaction torsor in the node, as reactionInNode does. If
+// requested, the loads applied to the ends of the elements in the node are subtracted from
+// their end torsors: the support takes them directly, without straining the elements.
+func (solution *Solution) reactionInNodeWithLoads(
+	nodeId contracts.StrID,
+	includeNodeLoads bool,
+) *math.Torsor {
+	node := solution.GetNodeById(nodeId)
 	if !node.IsExternallyConstrained() {
-		return reaction
+		return math.MakeNilTorsor()
 	}
 
+	reaction := solution.elementForcesInNode(nodeId, includeNodeLoads)
+
+	if constraint := node.ExternalConstraint; constraint.HasSprings() {
+		reaction = springsReaction(reaction, constraint, solution.globalDisplacementsInNode(nodeId))
+	}
+
+	return reaction
+}
+
+// elementForcesInNode sums the torsors at the ends of the elements in the node with the passed in
+// ID, in global coordinates: the forces the node exerts on the elements. If requested, the loads
+// applied to the ends of the elements in the node are subtracted from their end torsors.
+func (solution *Solution) elementForcesInNode(nodeId contracts.StrID, includeNodeLoads bool) *math.Torsor {
+	forces := math.MakeNilTorsor()
+
 	for _, element := range solution.Elements {
+		var endNode *preprocess.Node
+
 		if element.StartNodeID() == nodeId {
-			reaction = reaction.Plus(element.GlobalStartTorsor())
+			forces = forces.Plus(element.GlobalStartTorsor())
+			endNode = element.Element.NodeAt(0)
 		} else if element.EndNodeID() == nodeId {
-			reaction = reaction.Plus(element.GlobalEndTorsor())
+			forces = forces.Plus(element.GlobalEndTorsor())
+			endNode = element.Element.NodeAt(element.Element.NodesCount() - 1)
+		} else {
+			continue
+		}
+
+		if includeNodeLoads {
+			forces = forces.Minus(endNode.LocalExternalLoad().ProjectedToGlobal(element.RefFrame()))
 		}
 	}
 
-	return reaction
+	return forces
+}
+
+// springsReaction replaces the components of the reaction in the degrees of freedom with a
+// spring by the spring's reaction: minus the stiffness times the displacement. The springs act
+// in the support's local frame.
+func springsReaction(
+	reaction *math.Torsor,
+	constraint *structure.Constraint,
+	displacements [3]float64,
+) *math.Torsor {
+	var (
+		frame      = constraint.RefFrame()
+		local      = reaction.ProjectedTo(frame)
+		localDispl = frame.ProjectProjections(displacements[0], displacements[1])
+		fx, fy, mz = local.Fx(), local.Fy(), local.Mz()
+	)
+
+	if stiffness := constraint.SpringDispX(); stiffness != 0 {
+		fx = -stiffness * localDispl.X()
+	}
+	if stiffness := constraint.SpringDispY(); stiffness != 0 {
+		fy = -stiffness * localDispl.Y()
+	}
+	if stiffness := constraint.SpringRotation(); stiffness != 0 {
+		mz = -stiffness * displacements[2]
+	}
+
+	return math.MakeTorsor(fx, fy, mz).ProjectedToGlobal(frame)
+}
+
+// globalDisplacementsInNode returns the displacements {dx, dy, rz} of the node with the passed
+// in ID in global coordinates.
+func (solution *Solution) globalDisplacementsInNode(nodeId contracts.StrID) [3]float64 {
+	dofs := solution.GetNodeById(nodeId).DegreesOfFreedomNum()
+
+	return [3]float64{
+		solution.GlobalDisplacements.Value(dofs[0]),
+		solution.GlobalDisplacements.Value(dofs[1]),
+		solution.GlobalDisplacements.Value(dofs[2]),
+	}
 }
@@ -2,6 +2,7 @@ package process
 
 import (
 	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
 	"github.com/angelsolaorbaiceta/inkfem/log"
 	"github.com/angelsolaorbaiceta/inkfem/preprocess"
 	"github.com/angelsolaorbaiceta/inkfem/structure"
@@ -10,21 +11,115 @@ import (
 // Solve assembles the system of equations for the structure and solves it using the
 // Preconditioned Conjugate Gradient numerical procedure and sets the bars local stresses,
 // forces and moments.
-func Solve(str *preprocess.Structure, options SolveOptions) *Solution {
+//
+// The structure is solved for each of its load cases, reusing the same stiffness matrix.
+// Returns a solution for each load case, sorted alphabetically with the default case first.
+//
+// If the second-order analysis is set in the options, each of the load cases is then solved
+// including the P-Delta effects, and the amplification of the bending moments with respect to
+// the first-order solution is logged. Note that the combinations of second-order solutions are
+// an approximation, as the superposition principle doesn't hold.
+//
+// If the structure has tension-only or compression-only elements, each of the load cases is
+// solved again, removing the elements whose axial forces aren't allowed, until the set of
+// inactive elements is stable. The combinations of these solutions are an approximation too.
+// Panics if these elements aren't axial members, or the second-order analysis is set.
+//
+// The equilibrium of each of the solutions is verified and logged. See CheckEquilibrium. In the
+// second-order analysis, it's the first-order solution's: the second-order solution is in
+// equilibrium in the deformed geometry.
+func Solve(str *preprocess.Structure, options SolveOptions) []*Solution {
+	hasLimits := hasAxialBehaviorLimits(str)
+	if hasLimits {
+		ensureAxialBehaviorLimitsAreSupported(str)
+
+		if options.SecondOrder {
+			panic("The second-order analysis doesn't support tension-only or compression-only bars")
+		}
+	}
+
 	var (
-		globalDispl      = computeGlobalDisplacements(str, options)
-		elementSolutions = make([]*ElementSolution, str.ElementsCount())
-		metadata         = structure.StrMetadata{
+		loadCases   = str.LoadCases()
+		globalDispl = computeGlobalDisplacements(str, loadCases, options)
+		solutions   = make([]*Solution, len(loadCases))
+		metadata    = structure.StrMetadata{
 			MajorVersion: build.Info.MajorVersion,
 			MinorVersion: build.Info.MinorVersion,
 		}
 	)
 
-	log.StartComputeStresses()
-	for i, element := range str.Elements() {
-		elementSolutions[i] = MakeElementSolution(element, globalDispl)
+	for i, loadCase := range loadCases {
+		var (
+			elementSolutions = makeElementSolutions(str, loadCase, globalDispl[i])
+			solvedDispl      = globalDispl[i]
+			inactive         []contracts.StrID
+		)
+
+		if hasLimits {
+			elementSolutions, inactive, solvedDispl = solveActiveSet(
+				str, loadCase, elementSolutions, globalDispl[i], options,
+			)
+		}
+
+		solutions[i] = MakeSolution(metadata, loadCase, str.NodesById, elementSolutions, solvedDispl.Vector)
+		solutions[i].InactiveElements = inactive
+		solutions[i].Equilibrium = CheckEquilibrium(solutions[i], solvedDispl, equilibriumTolerance(options))
+		logEquilibrium(loadCase, solutions[i].Equilibrium)
+
+		if options.SecondOrder {
+			var (
+				firstOrder                 = solutions[i]
+				secondOrderElements, displ = solveSecondOrder(
+					str, loadCase, globalDispl[i], elementSolutions, options,
+				)
+				secondOrder = MakeSolution(metadata, loadCase, str.NodesById, secondOrderElements, displ.Vector)
+			)
+
+			logMaxMomentAmplification(loadCase, MomentAmplifications(firstOrder, secondOrder))
+			secondOrder.Equilibrium = firstOrder.Equilibrium
+			solutions[i] = secondOrder
+		}
+	}
+
+	return solutions
+}
+
+// logMaxMomentAmplification logs the largest amplification of the bending moments in the load
+// case, and the element where it happens.
+func logMaxMomentAmplification(loadCase string, amplifications []MomentAmplification) {
+	if len(amplifications) == 0 {
+		return
+	}
+
+	largest := amplifications[0]
+	for _, amplification := range amplifications[1:] {
+		if amplification.Factor() > largest.Factor() {
+			largest = amplification
+		}
+	}
+
+	log.SecondOrderAmplification(loadCase, string(largest.ElementID), largest.Factor())
+}
+
+// equilibriumTolerance returns the tolerance of the equilibrium verification in the options, or
+// the default one if not set.
+func equilibriumTolerance(options SolveOptions) float64 {
+	if options.EquilibriumTolerance > 0 {
+		return options.EquilibriumTolerance
 	}
-	log.EndComputeStresses()
 
-	return MakeSolution(metadata, str.NodesById, elementSolutions)
+	return DefaultEquilibriumTolerance
+}
+
+// logEquilibrium logs the result of the verification of the equilibrium of the load case.
+func logEquilibrium(loadCase string, check *EquilibriumCheck) {
+	log.Equilibrium(
+		loadCase,
+		check.RelativeForceImbalance(),
+		check.RelativeMomentImbalance(),
+		check.RelativeResidual(),
+		string(check.MaxNodeImbalanceID),
+		check.RelativeMaxNodeImbalance(),
+		check.IsWithinTolerance(),
+	)
 }
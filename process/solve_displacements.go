@@ -1,7 +1,11 @@
 package process
 
 import (
+	"fmt"
+	gomath "math"
+
 	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/math"
 	"github.com/angelsolaorbaiceta/inkfem/preprocess"
 	"github.com/angelsolaorbaiceta/inkmath/lineq"
 	"github.com/angelsolaorbaiceta/inkmath/mat"
@@ -10,67 +14,255 @@ import (
 
 // A GlobalDisplacementsVector is the solution of the structure's system of equations, that yield
 // the structure's global displacements.
-// It includes the error upper bound of the displacements calculation.
+// It includes the error upper bound of the displacements calculation and, when solved from the
+// system of equations, the norms of its residual vector (K·u - f) and load vector (f). The norms
+// of the load vector's forces and moments, the terms of the translation and rotation degrees of
+// freedom, are also kept.
 type GlobalDisplacementsVector struct {
-	MaxError float64
-	Vector   vec.ReadOnlyVector
+	MaxError        float64
+	Vector          vec.ReadOnlyVector
+	ResidualNorm    float64
+	LoadNorm        float64
+	LoadForcesNorm  float64
+	LoadMomentsNorm float64
 }
 
-// computeGlobalDisplacements computes the structure's global displacements given the
-// preprocessed structure.
+// computeGlobalDisplacements computes the structure's global displacements for each of the
+// given load cases, in the same order.
 //
 // The process involves generating the structure's system of equations and solving it using the
-// Preconditioned Conjugate Gradiend numerical procedure.
+// solver chosen in the options: the Preconditioned Conjugate Gradient numerical procedure or the
+// direct LDLᵀ factorization of the system matrix. The stiffness matrix is shared by all the load
+// cases, so it's assembled, and factorized or preconditioned, only once.
+//
+// The displacements of the nodes with an inclined external constraint are solved in the
+// support's local frame, and then projected to the global frame.
 func computeGlobalDisplacements(
 	structure *preprocess.Structure,
+	loadCases []string,
 	options SolveOptions,
-) *GlobalDisplacementsVector {
+) []*GlobalDisplacementsVector {
 	log.StartAssembleSysEqs()
-	sysMatrix, sysVector := structure.MakeSystemOfEquations()
-	log.EndAssembleSysEqs(sysVector.Length())
+	sysMatrix := structure.MakeStiffnessMatrix()
+	sysVectors := make([]vec.ReadOnlyVector, len(loadCases))
+	for i, loadCase := range loadCases {
+		sysVectors[i] = structure.MakeLoadVector(loadCase)
+	}
+	log.EndAssembleSysEqs(structure.DofsCount())
 
-	log.StartSolveSysEqs()
+	return solveSystems(structure, sysMatrix, sysVectors, loadCases, options)
+}
 
+// solveSystems solves the system of equations with the given matrix for each of the load
+// vectors, using the solver chosen in the options, and projects the resulting displacements to
+// the global frame. The residual of each solution is computed before the projection.
+func solveSystems(
+	structure *preprocess.Structure,
+	sysMatrix mat.ReadOnlyMatrix,
+	sysVectors []vec.ReadOnlyVector,
+	loadCases []string,
+	options SolveOptions,
+) []*GlobalDisplacementsVector {
 	var (
-		progressChan = make(chan lineq.IterativeSolverProgress)
-		solutionChan = make(chan *lineq.Solution)
-		solver       = lineq.PreconditionedConjugateGradientSolver{
-			MaxError:       options.MaxDisplacementsError,
-			MaxIter:        sysVector.Length(),
-			Preconditioner: computePreconditioner(sysMatrix),
-			ProgressChan:   progressChan,
-		}
+		displacements = solveSysEqs(sysMatrix, sysVectors, loadCases, options)
+		rotationDofs  = rotationDofs(structure)
 	)
 
-	if options.SafeChecks && !solver.CanSolve(sysMatrix, sysVector) {
+	for i, displacement := range displacements {
+		displacement.ResidualNorm = sysMatrix.TimesVector(displacement.Vector).Minus(sysVectors[i]).Norm()
+		displacement.LoadNorm = sysVectors[i].Norm()
+		displacement.LoadForcesNorm, displacement.LoadMomentsNorm = forcesAndMomentsNorms(sysVectors[i], rotationDofs)
+		displacement.Vector = structure.GlobalDisplacements(displacement.Vector)
+	}
+
+	return displacements
+}
+
+// rotationDofs returns whether each of the structure's degrees of freedom is a rotation.
+func rotationDofs(structure *preprocess.Structure) []bool {
+	isRotation := make([]bool, structure.DofsCount())
+
+	for _, node := range structure.GetAllNodes() {
+		isRotation[node.DegreesOfFreedomNum()[2]] = true
+	}
+	for _, element := range structure.Elements() {
+		for _, node := range element.Nodes() {
+			isRotation[node.DegreesOfFreedomNum()[2]] = true
+		}
+	}
+
+	return isRotation
+}
+
+// forcesAndMomentsNorms computes the norms of the vector's terms in the translation degrees of
+// freedom, the forces, and in the rotation ones, the moments.
+func forcesAndMomentsNorms(vector vec.ReadOnlyVector, isRotation []bool) (forces, moments float64) {
+	for i := 0; i < vector.Length(); i++ {
+		if value := vector.Value(i); isRotation[i] {
+			moments += value * value
+		} else {
+			forces += value * value
+		}
+	}
+
+	return gomath.Sqrt(forces), gomath.Sqrt(moments)
+}
+
+// solveSysEqs solves the system of equations with the given matrix for each of the load vectors,
+// using the solver chosen in the options. The solutions aren't projected to the global frame.
+func solveSysEqs(
+	sysMatrix mat.ReadOnlyMatrix,
+	sysVectors []vec.ReadOnlyVector,
+	loadCases []string,
+	options SolveOptions,
+) []*GlobalDisplacementsVector {
+	if options.Solver == DirectSolver {
+		return solveWithFactorization(sysMatrix, sysVectors, loadCases, options)
+	}
+
+	return solveWithPCG(sysMatrix, sysVectors, loadCases, options)
+}
+
+// solveWithPCG solves the system of equations for each of the load vectors using the
+// Preconditioned Conjugate Gradient numerical procedure. The solution's precision is given by
+// the maximum displacements error in the options.
+func solveWithPCG(
+	sysMatrix mat.ReadOnlyMatrix,
+	sysVectors []vec.ReadOnlyVector,
+	loadCases []string,
+	options SolveOptions,
+) []*GlobalDisplacementsVector {
+	if options.SafeChecks && !canSolveWithPCG(sysMatrix, sysVectors[0]) {
+		panic("Solver can't solve system!")
+	}
+
+	log.StartComputePreconditioner()
+	preconditioner, name := computePreconditioner(sysMatrix, options)
+	log.EndComputePreconditioner(name)
+
+	displacements := make([]*GlobalDisplacementsVector, len(sysVectors))
+
+	for i, sysVector := range sysVectors {
+		log.StartSolveSysEqs()
+
+		var (
+			progressChan = make(chan lineq.IterativeSolverProgress)
+			solutionChan = make(chan *lineq.Solution)
+			solver       = math.PCGSolver{
+				MaxError:       options.MaxDisplacementsError,
+				MaxIter:        sysVector.Length(),
+				Preconditioner: preconditioner,
+				ProgressChan:   progressChan,
+			}
+		)
+
+		go func(sysVector vec.ReadOnlyVector) {
+			solutionChan <- solver.Solve(sysMatrix, sysVector)
+			close(solutionChan)
+		}(sysVector)
+
+		logProgress(progressChan)
+		globalDispSolution := <-solutionChan
+
+		log.EndSolveSysEqs(loadCases[i], globalDispSolution.IterCount, globalDispSolution.MinError)
+
+		displacements[i] = &GlobalDisplacementsVector{
+			Vector:   globalDispSolution.Solution,
+			MaxError: options.MaxDisplacementsError,
+		}
+	}
+
+	return displacements
+}
+
+// solveWithFactorization solves the system of equations for each of the load vectors by
+// computing the LDLᵀ factorization of the system matrix and then applying forward and backward
+// substitution.
+//
+// The displacements are accurate to machine precision. The maximum displacements error in the
+// options is kept as the solution's error bound, as it's used to compare the values of the
+// stresses in both sides of the sliced element nodes.
+func solveWithFactorization(
+	sysMatrix mat.ReadOnlyMatrix,
+	sysVectors []vec.ReadOnlyVector,
+	loadCases []string,
+	options SolveOptions,
+) []*GlobalDisplacementsVector {
+	if options.SafeChecks && !mat.IsSymmetric(sysMatrix) {
 		panic("Solver can't solve system!")
 	}
 
-	go func() {
-		solutionChan <- solver.Solve(sysMatrix, sysVector)
-		close(solutionChan)
-	}()
+	log.StartFactorizeSysEqs()
+	factorization, err := math.MakeLDLFactorization(sysMatrix)
+	if err != nil {
+		panic(fmt.Sprintf("Can't solve the system of equations: %s", err))
+	}
+	log.EndFactorizeSysEqs(factorization.ProfileSize())
 
-	logProgress(progressChan)
-	globalDispSolution := <-solutionChan
+	displacements := make([]*GlobalDisplacementsVector, len(sysVectors))
 
-	log.EndSolveSysEqs(globalDispSolution.IterCount, globalDispSolution.MinError)
+	for i, sysVector := range sysVectors {
+		log.StartSolveSysEqs()
+		vector := factorization.Solve(sysVector)
+		log.EndDirectSolveSysEqs(loadCases[i], maxResidual(sysMatrix, vector, sysVector))
 
-	return &GlobalDisplacementsVector{
-		Vector:   globalDispSolution.Solution,
-		MaxError: options.MaxDisplacementsError,
+		displacements[i] = &GlobalDisplacementsVector{
+			Vector:   vector,
+			MaxError: options.MaxDisplacementsError,
+		}
 	}
+
+	return displacements
+}
+
+// canSolveWithPCG returns whether the Conjugate Gradient is suitable for solving the given
+// system of equations: the matrix has to be square, symmetric and of the same size as the vector.
+func canSolveWithPCG(sysMatrix mat.ReadOnlyMatrix, sysVector vec.ReadOnlyVector) bool {
+	return mat.IsSquare(sysMatrix) &&
+		sysMatrix.Rows() == sysVector.Length() &&
+		mat.IsSymmetric(sysMatrix)
 }
 
-// computePreconditioner computes the preconditioner of the system matrix for the conjugate
-// gradient method to converge faster.
-func computePreconditioner(sysMat mat.ReadOnlyMatrix) mat.ReadOnlyMatrix {
-	precond := mat.MakeSparse(sysMat.Rows(), sysMat.Cols())
-	for i := 0; i < sysMat.Rows(); i++ {
-		precond.SetValue(i, i, 1.0/sysMat.Value(i, i))
+// computePreconditioner computes the preconditioner of the system matrix, chosen in the options,
+// for the conjugate gradient method to converge faster. Returns the preconditioner and a
+// description of it.
+func computePreconditioner(
+	sysMatrix mat.ReadOnlyMatrix,
+	options SolveOptions,
+) (math.Preconditioner, string) {
+	switch options.Preconditioner {
+	case IncompleteCholeskyPreconditioner:
+		ic := math.MakeIncompleteCholesky(sysMatrix, options.ICFillInThreshold)
+		return ic, fmt.Sprintf(
+			"incomplete Cholesky (threshold = %g, %d non-zeros, shift = %g)",
+			options.ICFillInThreshold, ic.NonZeroCount(), ic.Shift(),
+		)
+
+	case SSORPreconditioner:
+		omega := options.SSORRelaxation
+		if omega == 0 {
+			omega = DefaultSSORRelaxation
+		}
+
+		return math.MakeSSORPreconditioner(sysMatrix, omega), fmt.Sprintf("SSOR (ω = %g)", omega)
+
+	default:
+		return math.MakeJacobiPreconditioner(sysMatrix), "Jacobi"
+	}
+}
+
+// maxResidual computes the maximum absolute value of the residual vector: A·x - b.
+func maxResidual(a mat.ReadOnlyMatrix, x, b vec.ReadOnlyVector) float64 {
+	var (
+		residual = a.TimesVector(x).Minus(b)
+		maxValue = 0.0
+	)
+
+	for i := 0; i < residual.Length(); i++ {
+		maxValue = gomath.Max(maxValue, gomath.Abs(residual.Value(i)))
 	}
 
-	return precond
+	return maxValue
 }
 
 func logProgress(ch <-chan lineq.IterativeSolverProgress) {
@@ -1,8 +1,95 @@
 package process
 
+import "fmt"
+
+// SolverType is the numerical procedure used to solve the structure's system of equations.
+type SolverType string
+
+const (
+	// PCGSolver is the iterative Preconditioned Conjugate Gradient solver. Its accuracy depends
+	// on the maximum displacements error.
+	PCGSolver SolverType = "pcg"
+
+	// DirectSolver solves the system by factorizing the stiffness matrix (LDLᵀ decomposition).
+	// It yields displacements accurate to machine precision.
+	DirectSolver SolverType = "direct"
+)
+
+// ParseSolverType returns the solver type with the given name.
+func ParseSolverType(name string) (SolverType, error) {
+	switch name {
+	case string(PCGSolver):
+		return PCGSolver, nil
+	case string(DirectSolver):
+		return DirectSolver, nil
+	default:
+		return "", fmt.Errorf("unknown solver: \"%s\"", name)
+	}
+}
+
+// PreconditionerType is the preconditioner used by the Preconditioned Conjugate Gradient solver.
+type PreconditionerType string
+
+const (
+	// JacobiPreconditioner uses the diagonal of the system matrix.
+	JacobiPreconditioner PreconditionerType = "jacobi"
+
+	// IncompleteCholeskyPreconditioner uses an incomplete LDLᵀ factorization of the system matrix.
+	// Fill-in terms are kept according to the ICFillInThreshold: IC(0) when zero.
+	IncompleteCholeskyPreconditioner PreconditionerType = "ic"
+
+	// SSORPreconditioner uses the Symmetric Successive Over-Relaxation of the system matrix, with
+	// the SSORRelaxation factor.
+	SSORPreconditioner PreconditionerType = "ssor"
+)
+
+// DefaultSSORRelaxation is the SSOR relaxation factor used when none is given.
+const DefaultSSORRelaxation = 1.0
+
+// ParsePreconditionerType returns the preconditioner type with the given name.
+func ParsePreconditionerType(name string) (PreconditionerType, error) {
+	switch name {
+	case string(JacobiPreconditioner):
+		return JacobiPreconditioner, nil
+	case string(IncompleteCholeskyPreconditioner):
+		return IncompleteCholeskyPreconditioner, nil
+	case string(SSORPreconditioner):
+		return SSORPreconditioner, nil
+	default:
+		return "", fmt.Errorf("unknown preconditioner: \"%s\"", name)
+	}
+}
+
+const (
+	// DefaultSecondOrderMaxError is the maximum relative change of the displacements between two
+	// iterations of the second-order analysis used when none is given.
+	DefaultSecondOrderMaxError = 1e-6
+
+	// DefaultSecondOrderMaxIter is the maximum number of iterations of the second-order analysis
+	// used when none is given.
+	DefaultSecondOrderMaxIter = 50
+)
+
 // SolveOptions includes configuration parameters for structural solving process.
+//
+// When no Solver is set, the Preconditioned Conjugate Gradient is used, and when no
+// Preconditioner is set, the Jacobi preconditioner.
+//
+// If SecondOrder is set, the P-Delta effects are included in the analysis, iterating until the
+// relative change of the displacements is below SecondOrderMaxError.
+//
+// The equilibrium of the solutions is verified with the EquilibriumTolerance, or the
+// DefaultEquilibriumTolerance when not set.
 type SolveOptions struct {
 	OutputPath            string
 	SafeChecks            bool
 	MaxDisplacementsError float64
+	Solver                SolverType
+	Preconditioner        PreconditionerType
+	ICFillInThreshold     float64
+	SSORRelaxation        float64
+	SecondOrder           bool
+	SecondOrderMaxError   float64
+	SecondOrderMaxIter    int
+	EquilibriumTolerance  float64
 }
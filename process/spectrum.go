@@ -0,0 +1,348 @@
+package process
+
+import (
+	"fmt"
+	gomath "math"
+	"sort"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+// DefaultSpectrumDampingRatio is the damping ratio of the modes used by the CQC rule when none is
+// given, that of the usual design spectra.
+const DefaultSpectrumDampingRatio = 0.05
+
+// SpectrumDirection is the global direction of the seismic ground motion.
+type SpectrumDirection string
+
+const (
+	SpectrumX SpectrumDirection = "x"
+	SpectrumY SpectrumDirection = "y"
+)
+
+// ParseSpectrumDirection returns the direction of the ground motion with the given name.
+func ParseSpectrumDirection(name string) (SpectrumDirection, error) {
+	switch direction := SpectrumDirection(name); direction {
+	case SpectrumX, SpectrumY:
+		return direction, nil
+	default:
+		return "", fmt.Errorf("unknown spectrum direction: %s. Use one of: x, y", name)
+	}
+}
+
+// CombinationRule is the rule used to combine the peak responses of the vibration modes.
+type CombinationRule string
+
+const (
+	// SRSSRule is the square root of the sum of the squares of the modal responses, suitable when
+	// the modes' frequencies are well separated.
+	SRSSRule CombinationRule = "srss"
+
+	// CQCRule is the complete quadratic combination of the modal responses, which accounts for the
+	// correlation of the modes with close frequencies.
+	CQCRule CombinationRule = "cqc"
+)
+
+// SpectrumOptions includes configuration parameters for the response spectrum analysis.
+//
+// The modal options configure the modal analysis whose modes are combined. The spectral
+// accelerations are multiplied by the acceleration scale, which converts the spectrum units (like
+// fractions of the gravity acceleration) into those of the structure. The damping ratio is used
+// to correlate the modes in the CQC rule.
+type SpectrumOptions struct {
+	ModalOptions
+	Spectrum          *load.ResponseSpectrum
+	Direction         SpectrumDirection
+	AccelerationScale float64
+	DampingRatio      float64
+	MaxError          float64
+}
+
+// SpectrumModeResponse is the peak response of a vibration mode to the spectrum: its spectral
+// acceleration, and the factor which multiplies the mode shape to obtain the peak displacements,
+// Γ·Sa/ω². The participation factor and mass ratio are those in the ground motion direction.
+type SpectrumModeResponse struct {
+	*VibrationMode
+	ParticipationFactor  float64
+	MassRatio            float64
+	SpectralAcceleration float64
+	DisplacementFactor   float64
+}
+
+// SpectrumCombination is the combination of the modal responses using a rule: the envelopes of
+// the reactions and the elements' stresses.
+//
+// The combined responses have no sign, thus the envelopes are symmetric: the maximum is the
+// combined value and the minimum, its opposite. The reactions are the combined values, {fx, fy,
+// mz}, of the externally constrained nodes.
+type SpectrumCombination struct {
+	Rule          CombinationRule
+	ReactionNodes []contracts.StrID
+	Reactions     map[contracts.StrID][3]float64
+	Envelopes     []*ElementEnvelope
+}
+
+// SpectrumSolution is the result of the response spectrum analysis of a structure: the peak
+// response of each of the vibration modes and their combinations using the SRSS and CQC rules.
+type SpectrumSolution struct {
+	Metadata     structure.StrMetadata
+	Direction    SpectrumDirection
+	Modes        []*SpectrumModeResponse
+	Combinations []*SpectrumCombination
+}
+
+// MassRatio is the sum of the mass ratios of the modes in the direction of the ground motion:
+// the fraction of the structure's mass that the combined modes account for.
+func (solution *SpectrumSolution) MassRatio() float64 {
+	ratio := 0.0
+	for _, mode := range solution.Modes {
+		ratio += mode.MassRatio
+	}
+
+	return ratio
+}
+
+// SolveSpectrum computes the seismic response of the structure to the design spectrum in the
+// direction given in the options.
+//
+// The structure's vibration modes are computed by a modal analysis (see SolveModal). The peak
+// response of each mode is its shape multiplied by Γ·Sa(T)/ω², being Γ the mode's participation
+// factor in the direction and Sa(T) the spectral acceleration for the mode's period. The peak
+// responses of the modes, their reactions and stresses, are combined with both the SRSS and CQC
+// rules.
+//
+// Panics if there's no spectrum, or the modal analysis can't be done.
+func SolveSpectrum(str *preprocess.Structure, options SpectrumOptions) *SpectrumSolution {
+	if options.Spectrum == nil {
+		panic("The response spectrum analysis needs a spectrum")
+	}
+	if options.Direction == "" {
+		options.Direction = SpectrumX
+	}
+
+	var (
+		modal         = SolveModal(str, options.ModalOptions)
+		scale         = options.AccelerationScale
+		dampingRatio  = options.DampingRatio
+		maxError      = options.MaxError
+		modes         = make([]*SpectrumModeResponse, len(modal.Modes))
+		modeSolutions = make([]*Solution, len(modal.Modes))
+	)
+
+	if scale == 0 {
+		scale = 1.0
+	}
+	if dampingRatio == 0 {
+		dampingRatio = DefaultSpectrumDampingRatio
+	}
+	if maxError == 0 {
+		maxError = DefaultTimeHistoryMaxError
+	}
+
+	for i, mode := range modal.Modes {
+		var (
+			gamma, ratio = modeDirectionalParticipation(mode, options.Direction)
+			accel        = scale * options.Spectrum.AccelerationAt(mode.Period)
+			factor       = gamma * accel / (mode.AngularFrequency * mode.AngularFrequency)
+			displ        = &GlobalDisplacementsVector{
+				Vector:   str.GlobalDisplacements(mode.shape.Scaled(factor)),
+				MaxError: maxError,
+			}
+		)
+
+		modes[i] = &SpectrumModeResponse{
+			VibrationMode:        mode,
+			ParticipationFactor:  gamma,
+			MassRatio:            ratio,
+			SpectralAcceleration: accel,
+			DisplacementFactor:   factor,
+		}
+
+		elements := make([]*ElementSolution, str.ElementsCount())
+		for j, element := range str.Elements() {
+			elements[j] = MakeElementSolution(element.InLoadCase(unloadedCase), displ)
+		}
+
+		modeSolutions[i] = MakeSolution(
+			modal.Metadata, fmt.Sprintf("mode_%d", i+1), str.NodesById, elements, displ.Vector,
+		)
+	}
+
+	var (
+		omegas      = make([]float64, len(modes))
+		correlation = make([][]float64, len(modes))
+		identity    = make([][]float64, len(modes))
+	)
+
+	for i, mode := range modes {
+		omegas[i] = mode.AngularFrequency
+	}
+	for i := range modes {
+		correlation[i] = make([]float64, len(modes))
+		identity[i] = make([]float64, len(modes))
+		identity[i][i] = 1.0
+
+		for j := range modes {
+			correlation[i][j] = CQCCorrelation(dampingRatio, omegas[i], omegas[j])
+		}
+	}
+
+	return &SpectrumSolution{
+		Metadata: structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		},
+		Direction: options.Direction,
+		Modes:     modes,
+		Combinations: []*SpectrumCombination{
+			combineModeSolutions(str, SRSSRule, modeSolutions, identity),
+			combineModeSolutions(str, CQCRule, modeSolutions, correlation),
+		},
+	}
+}
+
+// CQCCorrelation computes the correlation coefficient of two modes with the given angular
+// frequencies and the same damping ratio, used by the CQC rule:
+//
+//	ρ = 8·ζ²·(1 + r)·r^(3/2) / ((1 - r²)² + 4·ζ²·r·(1 + r)²), with r = ωj / ωi.
+func CQCCorrelation(dampingRatio, omegaI, omegaJ float64) float64 {
+	var (
+		r       = omegaJ / omegaI
+		zeta2   = dampingRatio * dampingRatio
+		oneMinR = 1.0 - r*r
+	)
+
+	return 8.0 * zeta2 * (1.0 + r) * gomath.Pow(r, 1.5) /
+		(oneMinR*oneMinR + 4.0*zeta2*r*(1.0+r)*(1.0+r))
+}
+
+// combineModeSolutions combines the reactions and stresses of the modes' peak responses using
+// the given correlation coefficients: the combined value is sqrt(Σi Σj ρij·ri·rj). The SRSS
+// rule is a combination where the coefficients are the identity.
+func combineModeSolutions(
+	str *preprocess.Structure,
+	rule CombinationRule,
+	modeSolutions []*Solution,
+	correlation [][]float64,
+) *SpectrumCombination {
+	combination := &SpectrumCombination{
+		Rule:      rule,
+		Reactions: make(map[contracts.StrID][3]float64),
+		Envelopes: make([]*ElementEnvelope, str.ElementsCount()),
+	}
+
+	if len(modeSolutions) == 0 {
+		return combination
+	}
+
+	for _, node := range str.GetAllNodes() {
+		if node.IsExternallyConstrained() {
+			combination.ReactionNodes = append(combination.ReactionNodes, node.GetID())
+		}
+	}
+	sort.Slice(combination.ReactionNodes, func(i, j int) bool {
+		return combination.ReactionNodes[i] < combination.ReactionNodes[j]
+	})
+
+	modeReactions := make([]map[contracts.StrID][3]float64, len(modeSolutions))
+	for i, solution := range modeSolutions {
+		modeReactions[i] = make(map[contracts.StrID][3]float64)
+		for id, reaction := range solution.NodeReactions() {
+			modeReactions[i][id] = [3]float64{reaction.Fx(), reaction.Fy(), reaction.Mz()}
+		}
+	}
+
+	for _, id := range combination.ReactionNodes {
+		var combined [3]float64
+		for k := range combined {
+			combined[k] = combineModalValues(correlation, func(mode int) float64 {
+				return modeReactions[mode][id][k]
+			})
+		}
+
+		combination.Reactions[id] = combined
+	}
+
+	for j := range combination.Envelopes {
+		elementSolutions := make([]*ElementSolution, len(modeSolutions))
+		for i, solution := range modeSolutions {
+			elementSolutions[i] = solution.Elements[j]
+		}
+
+		combination.Envelopes[j] = combineElementModeSolutions(elementSolutions, rule, correlation)
+	}
+
+	return combination
+}
+
+// combineElementModeSolutions combines the stresses of an element in the modes' peak responses
+// into a symmetric envelope.
+func combineElementModeSolutions(
+	elementSolutions []*ElementSolution,
+	rule CombinationRule,
+	correlation [][]float64,
+) *ElementEnvelope {
+	var (
+		first = elementSolutions[0]
+		nodes = first.Element.Nodes()
+	)
+
+	envelopeOf := func(values func(es *ElementSolution) []PointSolutionValue) []EnvelopeValue {
+		expanded := make([][]PointSolutionValue, len(elementSolutions))
+		for i, es := range elementSolutions {
+			expanded[i] = sliceEndValues(values(es), nodes)
+		}
+
+		envelope := make([]EnvelopeValue, len(expanded[0]))
+		for k := range envelope {
+			combined := combineModalValues(correlation, func(mode int) float64 {
+				return expanded[mode][k].Value
+			})
+
+			envelope[k] = EnvelopeValue{expanded[0][k].T, combined, string(rule), -combined, string(rule)}
+		}
+
+		return compactEnvelopeValues(envelope, first.maxDispError)
+	}
+
+	return &ElementEnvelope{
+		Element:       first.Element,
+		AxialStress:   envelopeOf(func(es *ElementSolution) []PointSolutionValue { return es.AxialStress }),
+		ShearForce:    envelopeOf(func(es *ElementSolution) []PointSolutionValue { return es.ShearForce }),
+		BendingMoment: envelopeOf(func(es *ElementSolution) []PointSolutionValue { return es.BendingMoment }),
+	}
+}
+
+// combineModalValues computes sqrt(Σi Σj ρij·ri·rj), being ri the value of the i-th mode.
+func combineModalValues(correlation [][]float64, valueOf func(mode int) float64) float64 {
+	var (
+		values = make([]float64, len(correlation))
+		sum    = 0.0
+	)
+
+	for i := range values {
+		values[i] = valueOf(i)
+	}
+
+	for i := range values {
+		for j := range values {
+			sum += correlation[i][j] * values[i] * values[j]
+		}
+	}
+
+	return gomath.Sqrt(gomath.Max(sum, 0.0))
+}
+
+// modeDirectionalParticipation returns the participation factor and mass ratio of the mode in the
+// given direction.
+func modeDirectionalParticipation(mode *VibrationMode, direction SpectrumDirection) (float64, float64) {
+	if direction == SpectrumY {
+		return mode.ParticipationFactorY, mode.MassRatioY
+	}
+
+	return mode.ParticipationFactorX, mode.MassRatioX
+}
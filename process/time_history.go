@@ -0,0 +1,515 @@
+package process
+
+import (
+	"fmt"
+	gomath "math"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/log"
+	"github.com/angelsolaorbaiceta/inkfem/math"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+	"github.com/angelsolaorbaiceta/inkmath/vec"
+)
+
+const (
+	// DefaultTimeHistoryMaxError is the error used to compare the stresses of the time history
+	// analysis in both sides of the sliced element nodes when none is given.
+	DefaultTimeHistoryMaxError = 1e-5
+
+	// unloadedCase is the name of a load case without loads, used to compute the element stresses
+	// due only to the displacements.
+	unloadedCase = ""
+)
+
+// TimeHistoryOptions includes configuration parameters for the linear time-history analysis.
+//
+// The structure is excited by the loads in the load case multiplied by the load function, by the
+// ground accelerations in the global X and Y directions, or by both. At least one of the time
+// functions must be given.
+//
+// The equations of motion are integrated with the HHT-α method, in steps of the given time step
+// up to the duration, or the longest time function if no duration is given. With the default
+// α = 0, it's the Newmark-β method. When the β and γ parameters aren't given, they're derived
+// from α: β = (1 - α)² / 4 and γ = 1/2 - α, which for α = 0 is the unconditionally stable
+// average acceleration method.
+//
+// The mass matrix is assembled as in the modal analysis (see ModalOptions). The Rayleigh damping
+// matrix, C = a·M + b·K, yields the damping ratio at the two damping frequencies (angular, in
+// rad/s), which default to those of the first two vibration modes.
+//
+// The displacements of the chosen nodes, the reactions of those which are externally constrained
+// and the end forces of the chosen bars are recorded at each time step.
+type TimeHistoryOptions struct {
+	LoadCase            string
+	LoadFunction        *load.TimeFunction
+	GroundAccelerationX *load.TimeFunction
+	GroundAccelerationY *load.TimeFunction
+
+	TimeStep float64
+	Duration float64
+	Alpha    float64
+	Beta     float64
+	Gamma    float64
+
+	Lumped             bool
+	Gravity            float64
+	DampingRatio       float64
+	DampingFrequencies [2]float64
+
+	Nodes    []contracts.StrID
+	Bars     []contracts.StrID
+	MaxError float64
+}
+
+// A TimeHistoryStep is the state of the structure at a time of the time-history analysis.
+//
+// The displacements are the global {dx, dy, rz} of the chosen nodes, relative to the ground, and
+// the reactions, the global {fx, fy, mz} of the constrained ones. The forces of the chosen bars
+// are the axial force, shear force and bending moment at their start followed by those at their
+// end, {N, V, M, N, V, M}, with the same sign convention as the solution's stresses.
+type TimeHistoryStep struct {
+	Time          float64
+	Displacements map[contracts.StrID][3]float64
+	Reactions     map[contracts.StrID][3]float64
+	Forces        map[contracts.StrID][6]float64
+}
+
+// TimeHistorySolution is the result of the linear time-history analysis of a structure: the time
+// series of the displacements, reactions and forces of the chosen nodes and bars.
+type TimeHistorySolution struct {
+	Metadata      structure.StrMetadata
+	LoadCase      string
+	TimeStep      float64
+	Nodes         []contracts.StrID
+	ReactionNodes []contracts.StrID
+	Bars          []contracts.StrID
+	Steps         []*TimeHistoryStep
+}
+
+// RayleighCoefficients computes the factors of the mass and stiffness matrices, a and b, of the
+// Rayleigh damping matrix C = a·M + b·K, which yields the given damping ratio at the two angular
+// frequencies.
+func RayleighCoefficients(dampingRatio, omegaI, omegaJ float64) (massCoeff, stiffCoeff float64) {
+	massCoeff = 2.0 * dampingRatio * omegaI * omegaJ / (omegaI + omegaJ)
+	stiffCoeff = 2.0 * dampingRatio / (omegaI + omegaJ)
+
+	return massCoeff, stiffCoeff
+}
+
+// SolveTimeHistory integrates the linear equations of motion of the structure, M·a + C·v + K·u =
+// F(t), for the excitation given in the options, starting at rest.
+//
+// The displacements are relative to the ground: the ground accelerations excite the structure
+// with the inertia forces -M·r·ag(t), being r the rigid translation of the structure, and the
+// supports don't move. The prescribed displacements are, thus, ignored. The stresses include those
+// due to the loads applied to the elements, but not the inertia forces of their mass between the
+// sliced element nodes.
+//
+// Panics if the options aren't valid, or the loads at the start of the analysis can't be
+// balanced by the mass.
+func SolveTimeHistory(str *preprocess.Structure, options TimeHistoryOptions) *TimeHistorySolution {
+	ensureValidTimeHistoryOptions(options)
+
+	var (
+		alpha, beta, gamma = integrationParameters(options)
+		dt                 = options.TimeStep
+		stepsCount         = int(gomath.Ceil(timeHistoryDuration(options)/dt - 1e-9))
+		gravity            = options.Gravity
+	)
+
+	if gravity == 0 {
+		gravity = 1.0
+	}
+
+	var (
+		stiffness = str.MakeStiffnessMatrix()
+		mass      = str.MakeMassMatrix(options.Lumped, gravity)
+		damping   = makeDampingMatrix(str, stiffness, mass, options)
+		forceAt   = makeForceFunction(str, mass, options)
+
+		a0 = 1.0 / (beta * dt * dt)
+		a1 = gamma / (beta * dt)
+		a2 = 1.0 / (beta * dt)
+		a3 = 1.0/(2.0*beta) - 1.0
+		a4 = 1.0 - gamma/beta
+		a5 = dt * (1.0 - gamma/(2.0*beta))
+
+		effectiveStiffness = linearCombination(
+			[]mat.ReadOnlyMatrix{stiffness, mass, damping},
+			[]float64{1.0 + alpha, a0, (1.0 + alpha) * a1},
+		)
+	)
+
+	factorization, err := math.MakeLDLFactorization(effectiveStiffness)
+	if err != nil {
+		panic(fmt.Sprintf("Can't factorize the effective stiffness matrix: %s", err))
+	}
+
+	var (
+		displ    vec.ReadOnlyVector = vec.Make(str.DofsCount())
+		velocity vec.ReadOnlyVector = vec.Make(str.DofsCount())
+		accel                       = initialAccelerations(mass, forceAt(0.0))
+		force                       = forceAt(0.0)
+		recorder                    = makeTimeHistoryRecorder(str, options)
+		steps                       = make([]*TimeHistoryStep, 0, stepsCount+1)
+	)
+
+	log.StartTimeHistory()
+	steps = append(steps, recorder.record(0.0, displ, options))
+
+	for n := 1; n <= stepsCount; n++ {
+		var (
+			time      = float64(n) * dt
+			nextForce = forceAt(time)
+
+			// HHT-α: the loads and the elastic and damping forces are evaluated at t + (1 + α)·dt
+			hhtForce      = nextForce.Scaled(1.0 + alpha).Minus(force.Scaled(alpha))
+			prevForces    = stiffness.TimesVector(displ).Plus(damping.TimesVector(velocity)).Scaled(alpha)
+			inertiaTerms  = mass.TimesVector(displ.Scaled(a0).Plus(velocity.Scaled(a2)).Plus(accel.Scaled(a3)))
+			dampingTerms  = damping.TimesVector(displ.Scaled(a1).Minus(velocity.Scaled(a4)).Minus(accel.Scaled(a5)))
+			effectiveLoad = hhtForce.Plus(prevForces).Plus(inertiaTerms).Plus(dampingTerms.Scaled(1.0 + alpha))
+
+			nextDispl = factorization.Solve(effectiveLoad)
+			nextAccel = nextDispl.Minus(displ).Scaled(a0).Minus(velocity.Scaled(a2)).Minus(accel.Scaled(a3))
+		)
+
+		velocity = velocity.Plus(accel.Scaled(dt * (1.0 - gamma))).Plus(nextAccel.Scaled(dt * gamma))
+		displ, accel, force = nextDispl, nextAccel, nextForce
+
+		steps = append(steps, recorder.record(time, displ, options))
+	}
+	log.EndTimeHistory(stepsCount, dt)
+
+	return &TimeHistorySolution{
+		Metadata: structure.StrMetadata{
+			MajorVersion: build.Info.MajorVersion,
+			MinorVersion: build.Info.MinorVersion,
+		},
+		LoadCase:      recorder.loadCase,
+		TimeStep:      dt,
+		Nodes:         options.Nodes,
+		ReactionNodes: recorder.reactionNodes,
+		Bars:          options.Bars,
+		Steps:         steps,
+	}
+}
+
+func ensureValidTimeHistoryOptions(options TimeHistoryOptions) {
+	if options.LoadFunction == nil && options.GroundAccelerationX == nil && options.GroundAccelerationY == nil {
+		panic("The time-history analysis needs a load function or a ground acceleration record")
+	}
+	if options.TimeStep <= 0 {
+		panic(fmt.Sprintf("The time step must be positive, got %f", options.TimeStep))
+	}
+	if options.Alpha < -1.0/3.0 || options.Alpha > 0 {
+		panic(fmt.Sprintf("The HHT α parameter must be between -1/3 and 0, got %f", options.Alpha))
+	}
+	if options.Beta < 0 || options.Gamma < 0 {
+		panic("The Newmark β and γ parameters can't be negative")
+	}
+	if options.DampingRatio < 0 {
+		panic(fmt.Sprintf("The damping ratio can't be negative, got %f", options.DampingRatio))
+	}
+}
+
+// integrationParameters returns the α, β and γ parameters of the HHT-α method, deriving those
+// which aren't given in the options from α.
+func integrationParameters(options TimeHistoryOptions) (alpha, beta, gamma float64) {
+	alpha, beta, gamma = options.Alpha, options.Beta, options.Gamma
+
+	if beta == 0 {
+		beta = (1.0 - alpha) * (1.0 - alpha) / 4.0
+	}
+	if gamma == 0 {
+		gamma = 0.5 - alpha
+	}
+
+	return alpha, beta, gamma
+}
+
+// timeHistoryDuration is the duration given in the options, or the longest of the time functions
+// if none is given.
+func timeHistoryDuration(options TimeHistoryOptions) float64 {
+	if options.Duration > 0 {
+		return options.Duration
+	}
+
+	duration := 0.0
+	for _, function := range []*load.TimeFunction{
+		options.LoadFunction, options.GroundAccelerationX, options.GroundAccelerationY,
+	} {
+		if function != nil {
+			duration = gomath.Max(duration, function.Duration())
+		}
+	}
+
+	return duration
+}
+
+// makeDampingMatrix assembles the Rayleigh damping matrix. When no damping frequencies are given,
+// those of the first two vibration modes are used.
+func makeDampingMatrix(
+	str *preprocess.Structure,
+	stiffness, mass mat.ReadOnlyMatrix,
+	options TimeHistoryOptions,
+) mat.ReadOnlyMatrix {
+	if options.DampingRatio == 0 {
+		return mat.MakeSparse(stiffness.Rows(), stiffness.Cols())
+	}
+
+	omegaI, omegaJ := options.DampingFrequencies[0], options.DampingFrequencies[1]
+	if omegaI == 0 || omegaJ == 0 {
+		modes := SolveModal(str, ModalOptions{ModesCount: 2, Lumped: options.Lumped, Gravity: options.Gravity}).Modes
+		if len(modes) == 0 {
+			panic("Can't compute the damping frequencies: the structure has no vibration modes")
+		}
+
+		omegaI, omegaJ = modes[0].AngularFrequency, modes[len(modes)-1].AngularFrequency
+	}
+
+	massCoeff, stiffCoeff := RayleighCoefficients(options.DampingRatio, omegaI, omegaJ)
+
+	return linearCombination([]mat.ReadOnlyMatrix{mass, stiffness}, []float64{massCoeff, stiffCoeff})
+}
+
+// makeForceFunction returns the function that computes the external forces vector at a given time:
+// the loads of the load case times the load function, and the inertia forces of the ground
+// accelerations.
+func makeForceFunction(
+	str *preprocess.Structure,
+	mass mat.ReadOnlyMatrix,
+	options TimeHistoryOptions,
+) func(time float64) vec.ReadOnlyVector {
+	var (
+		loads    vec.ReadOnlyVector
+		inertiaX = mass.TimesVector(str.MakeRigidTranslationVector(1, 0))
+		inertiaY = mass.TimesVector(str.MakeRigidTranslationVector(0, 1))
+	)
+
+	if options.LoadFunction != nil {
+		loads = str.MakeDynamicLoadVector(timeHistoryLoadCase(options))
+	}
+
+	return func(time float64) vec.ReadOnlyVector {
+		var force vec.ReadOnlyVector = vec.Make(str.DofsCount())
+
+		if options.LoadFunction != nil {
+			force = force.Plus(loads.Scaled(options.LoadFunction.ValueAt(time)))
+		}
+		if options.GroundAccelerationX != nil {
+			force = force.Minus(inertiaX.Scaled(options.GroundAccelerationX.ValueAt(time)))
+		}
+		if options.GroundAccelerationY != nil {
+			force = force.Minus(inertiaY.Scaled(options.GroundAccelerationY.ValueAt(time)))
+		}
+
+		return force
+	}
+}
+
+func timeHistoryLoadCase(options TimeHistoryOptions) string {
+	if options.LoadCase == "" {
+		return load.DefaultCase
+	}
+
+	return options.LoadCase
+}
+
+// initialAccelerations computes the accelerations at the start of the analysis, where the
+// structure is at rest, solving M·a = F(0). The degrees of freedom without mass, including the
+// constrained ones, start without acceleration.
+//
+// Panics if the mass matrix can't be factorized.
+func initialAccelerations(mass mat.ReadOnlyMatrix, force vec.ReadOnlyVector) vec.ReadOnlyVector {
+	if maxAbsTerm(force) == 0 {
+		return vec.Make(force.Length())
+	}
+
+	var (
+		massMatrix = linearCombination([]mat.ReadOnlyMatrix{mass}, []float64{1.0})
+		rhs        = force.Clone().AsMutable()
+	)
+
+	for dof := 0; dof < mass.Rows(); dof++ {
+		if len(mass.NonZeroIndicesAtRow(dof)) == 0 {
+			massMatrix.SetValue(dof, dof, 1.0)
+			rhs.SetZero(dof)
+		}
+	}
+
+	factorization, err := math.MakeLDLFactorization(massMatrix)
+	if err != nil {
+		panic(fmt.Sprintf("Can't compute the initial accelerations: %s", err))
+	}
+
+	return factorization.Solve(rhs)
+}
+
+// linearCombination returns the sum of the matrices multiplied by their factors.
+func linearCombination(matrices []mat.ReadOnlyMatrix, factors []float64) mat.MutableMatrix {
+	result := mat.MakeSparse(matrices[0].Rows(), matrices[0].Cols())
+
+	for i, matrix := range matrices {
+		if factors[i] == 0 {
+			continue
+		}
+
+		for row := 0; row < matrix.Rows(); row++ {
+			for _, col := range matrix.NonZeroIndicesAtRow(row) {
+				result.AddToValue(row, col, factors[i]*matrix.Value(row, col))
+			}
+		}
+	}
+
+	return result
+}
+
+// timeHistoryRecorder collects the displacements, reactions and forces of the chosen nodes and
+// bars at each time step.
+type timeHistoryRecorder struct {
+	str           *preprocess.Structure
+	loadCase      string
+	loadFunction  *load.TimeFunction
+	reactionNodes []contracts.StrID
+	bars          map[contracts.StrID]bool
+	elements      []*preprocess.Element
+	loadsOnly     []*ElementSolution
+	maxError      float64
+}
+
+// makeTimeHistoryRecorder creates a recorder for the nodes and bars in the options. The recorded
+// elements are the chosen bars and those connected to the chosen constrained nodes, whose end
+// forces are needed to compute the reactions.
+func makeTimeHistoryRecorder(str *preprocess.Structure, options TimeHistoryOptions) *timeHistoryRecorder {
+	recorder := &timeHistoryRecorder{
+		str:          str,
+		loadFunction: options.LoadFunction,
+		bars:         make(map[contracts.StrID]bool, len(options.Bars)),
+		maxError:     options.MaxError,
+	}
+
+	if recorder.maxError == 0 {
+		recorder.maxError = DefaultTimeHistoryMaxError
+	}
+	if options.LoadFunction != nil {
+		recorder.loadCase = timeHistoryLoadCase(options)
+	}
+
+	for _, id := range options.Nodes {
+		if str.GetNodeById(id).IsExternallyConstrained() {
+			recorder.reactionNodes = append(recorder.reactionNodes, id)
+		}
+	}
+
+	isRecorded := make(map[contracts.StrID]bool)
+	for _, id := range options.Bars {
+		recorder.bars[str.GetElementById(id).GetID()] = true
+		isRecorded[id] = true
+	}
+	for _, id := range recorder.reactionNodes {
+		for _, element := range str.Elements() {
+			if element.StartNodeID() == id || element.EndNodeID() == id {
+				isRecorded[element.GetID()] = true
+			}
+		}
+	}
+
+	zeroDispl := &GlobalDisplacementsVector{Vector: vec.Make(str.DofsCount()), MaxError: recorder.maxError}
+	for _, element := range str.Elements() {
+		if !isRecorded[element.GetID()] {
+			continue
+		}
+
+		recorder.elements = append(recorder.elements, element)
+		if recorder.loadCase != "" {
+			recorder.loadsOnly = append(
+				recorder.loadsOnly,
+				MakeElementSolution(element.InLoadCase(recorder.loadCase), zeroDispl),
+			)
+		}
+	}
+
+	return recorder
+}
+
+// record computes the state of the recorded nodes and elements for the system's displacements at
+// the given time.
+//
+// The element solutions are linear in the displacements and the loads, thus the solution due to
+// the displacements is superposed to the solution due to the element loads, multiplied by the
+// load function's value.
+func (r *timeHistoryRecorder) record(
+	time float64,
+	sysDispl vec.ReadOnlyVector,
+	options TimeHistoryOptions,
+) *TimeHistoryStep {
+	var (
+		globalDispl = r.str.GlobalDisplacements(sysDispl)
+		displ       = &GlobalDisplacementsVector{Vector: globalDispl, MaxError: r.maxError}
+		elements    = make([]*ElementSolution, len(r.elements))
+		step        = &TimeHistoryStep{
+			Time:          time,
+			Displacements: make(map[contracts.StrID][3]float64, len(options.Nodes)),
+			Reactions:     make(map[contracts.StrID][3]float64, len(r.reactionNodes)),
+			Forces:        make(map[contracts.StrID][6]float64, len(options.Bars)),
+		}
+	)
+
+	for i, element := range r.elements {
+		elements[i] = MakeElementSolution(element.InLoadCase(unloadedCase), displ)
+
+		if r.loadCase != "" {
+			elements[i] = combineElementSolutions(
+				[]*ElementSolution{elements[i], r.loadsOnly[i]},
+				[]float64{1.0, r.loadFunction.ValueAt(time)},
+			)
+		}
+	}
+
+	for _, id := range options.Nodes {
+		dofs := r.str.GetNodeById(id).DegreesOfFreedomNum()
+		step.Displacements[id] = [3]float64{
+			globalDispl.Value(dofs[0]),
+			globalDispl.Value(dofs[1]),
+			globalDispl.Value(dofs[2]),
+		}
+	}
+
+	solution := MakeSolution(structure.StrMetadata{}, r.loadCase, r.str.NodesById, elements, globalDispl)
+	for _, id := range r.reactionNodes {
+		reaction := solution.reactionInNode(id)
+		step.Reactions[id] = [3]float64{reaction.Fx(), reaction.Fy(), reaction.Mz()}
+	}
+
+	for _, element := range elements {
+		if r.bars[element.GetID()] {
+			step.Forces[element.GetID()] = elementEndForces(element)
+		}
+	}
+
+	return step
+}
+
+// elementEndForces returns the axial force, shear force and bending moment at the start and end
+// of the element.
+func elementEndForces(element *ElementSolution) [6]float64 {
+	var (
+		area         = element.Section().Area
+		axialIndex   = len(element.AxialStress) - 1
+		shearIndex   = len(element.ShearForce) - 1
+		bendingIndex = len(element.BendingMoment) - 1
+	)
+
+	return [6]float64{
+		element.AxialStress[0].Value * area,
+		element.ShearForce[0].Value,
+		element.BendingMoment[0].Value,
+		element.AxialStress[axialIndex].Value * area,
+		element.ShearForce[shearIndex].Value,
+		element.BendingMoment[bendingIndex].Value,
+	}
+}
@@ -0,0 +1,33 @@
+package structure
+
+// AxialBehavior is the sign of the axial forces an element can take.
+//
+// A tension-only element, like a cable or a slender bracing rod, goes slack in compression, and
+// a compression-only element, like a contact strut, separates in tension. Either way, the element
+// is inactive: it's removed from the structure until the displacements of its ends would make it
+// work again.
+type AxialBehavior string
+
+const (
+	// TensionAndCompression is the behavior of the usual elements, linear in both directions.
+	TensionAndCompression AxialBehavior = ""
+
+	// TensionOnly elements can't take compression forces.
+	TensionOnly AxialBehavior = "tension"
+
+	// CompressionOnly elements can't take tension forces.
+	CompressionOnly AxialBehavior = "compression"
+)
+
+// AllowsAxialForce returns whether an element with this behavior can take the given axial force,
+// positive in tension. The forces whose absolute value is below the tolerance are always allowed.
+func (behavior AxialBehavior) AllowsAxialForce(force, tolerance float64) bool {
+	switch behavior {
+	case TensionOnly:
+		return force >= -tolerance
+	case CompressionOnly:
+		return force <= tolerance
+	default:
+		return true
+	}
+}
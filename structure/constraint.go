@@ -1,27 +1,48 @@
 package structure
 
-import "bytes"
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+)
 
 var (
 	// NilConstraint is a constraint where all DOF are free.
-	NilConstraint = Constraint{false, false, false}
+	NilConstraint = Constraint{isDxConstr: false, isDyConstr: false, isRzConst: false}
 
 	// DispConstraint is a constraint where the displacement DOFs are constrained.
-	DispConstraint = Constraint{true, true, false}
+	DispConstraint = Constraint{isDxConstr: true, isDyConstr: true, isRzConst: false}
 
 	// DispYConstraint is a constraint where the displacement in the y direction is constrained.
-	DispYConstraint = Constraint{false, true, false}
+	DispYConstraint = Constraint{isDxConstr: false, isDyConstr: true, isRzConst: false}
 
 	// FullConstraint is a constraint where all the DOFs are constrained.
-	FullConstraint = Constraint{true, true, true}
+	FullConstraint = Constraint{isDxConstr: true, isDyConstr: true, isRzConst: true}
 )
 
 // A Constraint represents a condition on displacements and rotations.
 //
+// The constrained degrees of freedom have a prescribed value, which is zero unless otherwise
+// stated. Non-zero prescribed values are used to model the settlements and imposed rotations of
+// the supports, and only make sense in the external constraints of the nodes.
+//
+// The free degrees of freedom of an external constraint can have an elastic spring, which
+// models a flexible support, such as a foundation on soil. The spring adds its stiffness to the
+// degree of freedom, and its reaction is the stiffness times the displacement.
+//
+// An external constraint can be inclined, as a roller on a sloped abutment. The degrees of
+// freedom of an inclined constraint, its prescribed values and springs refer to the support's
+// local frame, whose x-axis is rotated by the constraint's angle from the global x-axis.
+//
 // Constraints are immutable, and therefore can be shared among the elements that use them.
 // Use the `MakeConstraint` factory function to get an existing instance of a constraint.
 type Constraint struct {
 	isDxConstr, isDyConstr, isRzConst bool
+	dxValue, dyValue, rzValue         float64
+	dxSpring, dySpring, rzSpring      float64
+	angle                             float64
 }
 
 // MakeConstraint creates a new constraint with the given degrees of freedom constrained of free.
@@ -37,8 +58,66 @@ func MakeConstraint(isDxConstr, isDyConstr, isRzConst bool) *Constraint {
 		return &FullConstraint
 
 	default:
-		return &Constraint{isDxConstr, isDyConstr, isRzConst}
+		return &Constraint{isDxConstr: isDxConstr, isDyConstr: isDyConstr, isRzConst: isRzConst}
+	}
+}
+
+// MakePrescribedConstraint creates a new constraint with the given degrees of freedom constrained
+// or free, where the constrained ones have the given prescribed values. The prescribed values of
+// the free degrees of freedom are ignored.
+//
+// If all the prescribed values are zero, an existing instance of the constraint is returned, as
+// in MakeConstraint.
+func MakePrescribedConstraint(
+	isDxConstr, isDyConstr, isRzConst bool,
+	dxValue, dyValue, rzValue float64,
+) *Constraint {
+	if !isDxConstr {
+		dxValue = 0
+	}
+	if !isDyConstr {
+		dyValue = 0
+	}
+	if !isRzConst {
+		rzValue = 0
+	}
+
+	if dxValue == 0 && dyValue == 0 && rzValue == 0 {
+		return MakeConstraint(isDxConstr, isDyConstr, isRzConst)
+	}
+
+	return &Constraint{
+		isDxConstr: isDxConstr,
+		isDyConstr: isDyConstr,
+		isRzConst:  isRzConst,
+		dxValue:    dxValue,
+		dyValue:    dyValue,
+		rzValue:    rzValue,
+	}
+}
+
+// WithSprings returns a copy of the constraint with elastic springs of the given stiffnesses in
+// its free degrees of freedom. A zero stiffness means there is no spring.
+//
+// If all the stiffnesses are zero, the constraint is returned as is. Panics if a stiffness is
+// negative or a spring is added to a constrained degree of freedom.
+func (c *Constraint) WithSprings(dxSpring, dySpring, rzSpring float64) *Constraint {
+	if dxSpring < 0 || dySpring < 0 || rzSpring < 0 {
+		panic(fmt.Sprintf("Spring stiffnesses can't be negative: %v %v %v", dxSpring, dySpring, rzSpring))
+	}
+
+	if (c.isDxConstr && dxSpring != 0) || (c.isDyConstr && dySpring != 0) || (c.isRzConst && rzSpring != 0) {
+		panic("Springs can only be added to free degrees of freedom")
+	}
+
+	if dxSpring == 0 && dySpring == 0 && rzSpring == 0 {
+		return c
 	}
+
+	withSprings := *c
+	withSprings.dxSpring, withSprings.dySpring, withSprings.rzSpring = dxSpring, dySpring, rzSpring
+
+	return &withSprings
 }
 
 // AllowsRotation returns true is rotation degree of freedom is not constrained.
@@ -56,27 +135,144 @@ func (c Constraint) AllowsDispY() bool {
 	return !c.isDyConstr
 }
 
-// Equals tests whether this constraint equals other.
-func (c *Constraint) Equals(other *Constraint) bool {
+// PrescribedDispX returns the prescribed displacement in x, which is zero if the degree of
+// freedom is free.
+func (c Constraint) PrescribedDispX() float64 {
+	return c.dxValue
+}
+
+// PrescribedDispY returns the prescribed displacement in y, which is zero if the degree of
+// freedom is free.
+func (c Constraint) PrescribedDispY() float64 {
+	return c.dyValue
+}
+
+// PrescribedRotation returns the prescribed rotation, which is zero if the degree of freedom
+// is free.
+func (c Constraint) PrescribedRotation() float64 {
+	return c.rzValue
+}
+
+// WithAngle returns a copy of the constraint inclined the given angle, in degrees, measured
+// counterclockwise from the global x-axis to the support's local x-axis.
+//
+// If the angle is zero, the constraint is returned as is.
+func (c *Constraint) WithAngle(degrees float64) *Constraint {
+	if degrees == 0 {
+		return c
+	}
+
+	inclined := *c
+	inclined.angle = degrees
+
+	return &inclined
+}
+
+// Angle returns the angle, in degrees, from the global x-axis to the support's local x-axis.
+func (c Constraint) Angle() float64 {
+	return c.angle
+}
+
+// IsInclined returns true if the constraint's degrees of freedom refer to a local frame
+// rotated from the global one.
+func (c Constraint) IsInclined() bool {
+	return c.angle != 0
+}
+
+// RefFrame returns the support's local reference frame, where the degrees of freedom of the
+// constraint are defined. The frame is the global one if the constraint isn't inclined.
+func (c Constraint) RefFrame() *g2d.RefFrame {
+	rads := c.angle * math.Pi / 180.0
+	return g2d.MakeRefFrameWithIVersor(g2d.MakeVersor(math.Cos(rads), math.Sin(rads)))
+}
+
+// SpringDispX returns the stiffness of the spring in the x displacement, which is zero if
+// there is no spring.
+func (c Constraint) SpringDispX() float64 {
+	return c.dxSpring
+}
+
+// SpringDispY returns the stiffness of the spring in the y displacement, which is zero if
+// there is no spring.
+func (c Constraint) SpringDispY() float64 {
+	return c.dySpring
+}
+
+// SpringRotation returns the stiffness of the rotational spring, which is zero if there is
+// no spring.
+func (c Constraint) SpringRotation() float64 {
+	return c.rzSpring
+}
+
+// HasSprings returns true if any of the degrees of freedom has an elastic spring.
+func (c Constraint) HasSprings() bool {
+	return c.dxSpring != 0 || c.dySpring != 0 || c.rzSpring != 0
+}
+
+// HasPrescribedValues returns true if any of the constrained degrees of freedom has a non-zero
+// prescribed value.
+func (c Constraint) HasPrescribedValues() bool {
+	return c.dxValue != 0 || c.dyValue != 0 || c.rzValue != 0
+}
+
+// ConstrainsSameDofs tests whether this constraint and other constrain the same degrees of
+// freedom, regardless of their prescribed values.
+func (c *Constraint) ConstrainsSameDofs(other *Constraint) bool {
 	return c.isDxConstr == other.isDxConstr &&
 		c.isDyConstr == other.isDyConstr &&
 		c.isRzConst == other.isRzConst
 }
 
-// String representation of the constraint.
+// Equals tests whether this constraint equals other, including the prescribed values, springs
+// and angle.
+func (c *Constraint) Equals(other *Constraint) bool {
+	return c.ConstrainsSameDofs(other) &&
+		c.dxValue == other.dxValue &&
+		c.dyValue == other.dyValue &&
+		c.rzValue == other.rzValue &&
+		c.dxSpring == other.dxSpring &&
+		c.dySpring == other.dySpring &&
+		c.rzSpring == other.rzSpring &&
+		c.angle == other.angle
+}
+
+// String representation of the constraint, as in "{ dx dy=-0.5 krz=1000 angle=30 }", where the
+// springs are written with the name of the degree of freedom prefixed by "k".
 // Used in the serialization format.
 func (c Constraint) String() string {
 	var buffer bytes.Buffer
 	buffer.WriteString("{ ")
 
+	writeDof := func(name string, value float64) {
+		buffer.WriteString(name)
+		if value != 0 {
+			buffer.WriteString(fmt.Sprintf("=%v", value))
+		}
+		buffer.WriteString(" ")
+	}
+
 	if c.isDxConstr {
-		buffer.WriteString("dx ")
+		writeDof("dx", c.dxValue)
 	}
 	if c.isDyConstr {
-		buffer.WriteString("dy ")
+		writeDof("dy", c.dyValue)
 	}
 	if c.isRzConst {
-		buffer.WriteString("rz ")
+		writeDof("rz", c.rzValue)
+	}
+
+	writeSpring := func(name string, stiffness float64) {
+		if stiffness != 0 {
+			buffer.WriteString(fmt.Sprintf("k%s=%v ", name, stiffness))
+		}
+	}
+
+	writeSpring("dx", c.dxSpring)
+	writeSpring("dy", c.dySpring)
+	writeSpring("rz", c.rzSpring)
+
+	if c.angle != 0 {
+		buffer.WriteString(fmt.Sprintf("angle=%v ", c.angle))
 	}
 
 	buffer.WriteString("}")
@@ -13,20 +13,30 @@ import (
 // An Element represents a resistant element defined between two structural nodes, a section and
 // a material.
 //
-// An Element can have distributed and concentrated loads applied to it.
+// An Element can have distributed, concentrated and thermal loads applied to it.
+//
+// The links of the element with its start and end nodes are constraints: the constrained
+// degrees of freedom are rigidly connected to the node, and the free ones are released. A
+// released degree of freedom can have a spring, which makes the connection semi-rigid, like
+// a bolted steel connection. The translational springs of a link are in the element's local
+// frame, as the axial and shear stiffnesses of the connection.
+//
+// The axial behavior of an element limits the sign of the axial forces it can take: elements
+// can be tension-only, like cables, or compression-only. See AxialBehavior.
 //
 // To create an element, use the `ElementBuilder`.
 //
 // TODO: choose the bending axis
-// TODO: buckling analysis
 type Element struct {
 	id, startNodeID, endNodeID contracts.StrID
 	geometry                   *g2d.Segment
 	startLink, endLink         *Constraint
 	material                   *Material
 	section                    *Section
+	axialBehavior              AxialBehavior
 	ConcentratedLoads          []*load.ConcentratedLoad
 	DistributedLoads           []*load.DistributedLoad
+	ThermalLoads               []*load.ThermalLoad
 }
 
 func (e Element) Geometry() *g2d.Segment {
@@ -98,21 +108,60 @@ func (e Element) Section() *Section {
 	return e.section
 }
 
-// LoadsCount is the total number of concentrated and distributed loads applied to the element.
+// AxialBehavior returns the sign of the axial forces the element can take.
+func (e Element) AxialBehavior() AxialBehavior {
+	return e.axialBehavior
+}
+
+// HasAxialBehaviorLimit returns true if the element is either tension-only or compression-only.
+func (e Element) HasAxialBehaviorLimit() bool {
+	return e.axialBehavior != TensionAndCompression
+}
+
+// LoadsCount is the total number of concentrated, distributed and thermal loads applied to the
+// element.
 func (e Element) LoadsCount() int {
-	return len(e.ConcentratedLoads) + len(e.DistributedLoads)
+	return len(e.ConcentratedLoads) + len(e.DistributedLoads) + len(e.ThermalLoads)
 }
 
-// HasLoadsApplied returns true if any load, either concentrated of distributed,
+// HasLoadsApplied returns true if any load, either concentrated, distributed or thermal,
 // is applied to the element.
 func (e Element) HasLoadsApplied() bool {
 	return e.LoadsCount() > 0
 }
 
+// LoadCases returns the names of the load cases the element's loads belong to, sorted
+// alphabetically with the default case first.
+func (e Element) LoadCases() []string {
+	var (
+		loadCases = []string{}
+		seen      = make(map[string]bool)
+	)
+
+	addCase := func(loadCase string) {
+		if !seen[loadCase] {
+			seen[loadCase] = true
+			loadCases = append(loadCases, loadCase)
+		}
+	}
+
+	for _, ld := range e.ConcentratedLoads {
+		addCase(ld.LoadCase)
+	}
+	for _, ld := range e.DistributedLoads {
+		addCase(ld.LoadCase)
+	}
+	for _, ld := range e.ThermalLoads {
+		addCase(ld.LoadCase)
+	}
+
+	return load.SortLoadCases(loadCases)
+}
+
 // AddOwnWeight adds a distributed load to the element that represents the weight
 // of the element. The load's intensity per unit length is the product of the
 // material's density and the section's area. The load is applied in the negative
-// direction of the global Y axis.
+// direction of the global Y axis, and belongs to the default load case.
 func (e *Element) AddOwnWeight() {
 	var (
 		value = -e.material.Density * e.section.Area
@@ -122,9 +171,10 @@ func (e *Element) AddOwnWeight() {
 	e.DistributedLoads = append(e.DistributedLoads, load)
 }
 
-// IsAxialMember returns true if this element is pinned in both ends and, in case of having
-// loads applied, they are always in the end positions of the directrix and does not include
-// moments about Z, but just forces in X and Y directions.
+// IsAxialMember returns true if this element is pinned in both ends (the rotation is released
+// without a spring) and, in case of having loads applied, they are always in the end positions
+// of the directrix and does not include moments about Z, but just forces in X and Y directions.
+// A temperature gradient bends the element, but a uniform temperature change just elongates it.
 //
 // FIXME: is axial member a good name? a distributed Fx load would make this bar not an
 // axial member, which seems weird...
@@ -139,28 +189,71 @@ func (e Element) IsAxialMember() bool {
 		}
 	}
 
-	return e.startLink.AllowsRotation() && e.endLink.AllowsRotation()
+	for _, ld := range e.ThermalLoads {
+		if ld.Term == load.TemperatureGradient {
+			return false
+		}
+	}
+
+	return isPinnedLink(e.startLink) && isPinnedLink(e.endLink)
+}
+
+func isPinnedLink(link *Constraint) bool {
+	return link.AllowsRotation() && link.SpringRotation() == 0
+}
+
+// ShearDeformationFactor returns the factor φ = 12EI / (G·As·L²) for a slice of the element
+// with the given length, which is the ratio between its shear and bending flexibilities.
+//
+// When the element's section has no shear area, the shear deformation is neglected and the
+// factor is zero, which yields the Euler–Bernoulli beam stiffness terms.
+//
+// Panics if the section has a shear area but the material has no shear modulus.
+func (e Element) ShearDeformationFactor(length float64) float64 {
+	if !e.section.HasShearArea() {
+		return 0.0
+	}
+
+	if e.material.ShearMod <= 0.0 {
+		panic(fmt.Sprintf(
+			"Element %s: the shear deformation requires the material '%s' to have a shear modulus",
+			e.id,
+			e.material.Name,
+		))
+	}
+
+	var (
+		ei  = e.material.YoungMod * e.section.IStrong
+		gas = e.material.ShearMod * e.section.ShearArea
+	)
+
+	return 12.0 * ei / (gas * length * length)
 }
 
 // StiffnessGlobalMat generates the local stiffness matrix for the element and applies the rotation
 // defined by the elements' geometry reference frame.
 //
+// The bending terms include the shear deformation (Timoshenko beam theory) if the element's
+// section has a shear area. See ShearDeformationFactor.
+//
 // It returns the element's stiffness matrix in the global reference frame.
 func (e Element) StiffnessGlobalMat(startT, endT nums.TParam) mat.ReadOnlyMatrix {
 	var (
-		l    = e.geometry.LengthBetween(startT, endT)
-		c    = e.geometry.RefFrame().Cos()
-		s    = e.geometry.RefFrame().Sin()
-		ea   = e.material.YoungMod * e.section.Area
-		ei   = e.material.YoungMod * e.section.IStrong
-		c2   = c * c
-		s2   = s * s
-		cs   = c * s
-		eal  = ea / l
-		eil3 = 12.0 * ei / (l * l * l)
-		eil2 = 6.0 * ei / (l * l)
-		eil  = ei / l
-		k    = mat.MakeSquareDense(6)
+		l        = e.geometry.LengthBetween(startT, endT)
+		c        = e.geometry.RefFrame().Cos()
+		s        = e.geometry.RefFrame().Sin()
+		ea       = e.material.YoungMod * e.section.Area
+		ei       = e.material.YoungMod * e.section.IStrong
+		phi      = e.ShearDeformationFactor(l)
+		c2       = c * c
+		s2       = s * s
+		cs       = c * s
+		eal      = ea / l
+		eil3     = 12.0 * ei / (l * l * l * (1.0 + phi))
+		eil2     = 6.0 * ei / (l * l * (1.0 + phi))
+		bendNear = (4.0 + phi) * ei / (l * (1.0 + phi))
+		bendFar  = (2.0 - phi) * ei / (l * (1.0 + phi))
+		k        = mat.MakeSquareDense(6)
 	)
 
 	// First Row
@@ -182,10 +275,10 @@ func (e Element) StiffnessGlobalMat(startT, endT nums.TParam) mat.ReadOnlyMatrix
 	// Third Row
 	k.SetValue(2, 0, -s*eil2)
 	k.SetValue(2, 1, c*eil2)
-	k.SetValue(2, 2, 4.0*eil)
+	k.SetValue(2, 2, bendNear)
 	k.SetValue(2, 3, s*eil2)
 	k.SetValue(2, 4, -c*eil2)
-	k.SetValue(2, 5, 2.0*eil)
+	k.SetValue(2, 5, bendFar)
 
 	// Fourth Row
 	k.SetValue(3, 0, (-c2*eal - s2*eil3))
@@ -206,10 +299,104 @@ func (e Element) StiffnessGlobalMat(startT, endT nums.TParam) mat.ReadOnlyMatrix
 	// Sixth Row
 	k.SetValue(5, 0, -s*eil2)
 	k.SetValue(5, 1, c*eil2)
-	k.SetValue(5, 2, 2.0*eil)
+	k.SetValue(5, 2, bendFar)
 	k.SetValue(5, 3, s*eil2)
 	k.SetValue(5, 4, -c*eil2)
-	k.SetValue(5, 5, 4.0*eil)
+	k.SetValue(5, 5, bendNear)
+
+	return k
+}
+
+// GeometricStiffnessGlobalMat generates the geometric stiffness matrix of the element between
+// the given positions, subject to the given axial force (positive in tension), and applies the
+// rotation defined by the element's geometry reference frame.
+//
+// The geometric stiffness accounts for the change in the element's stiffness due to the axial
+// force acting on its deformed geometry: a compression force reduces the bending stiffness and a
+// tensile force increases it. The consistent matrix, derived from the cubic shape functions of the
+// bending displacements, is used.
+//
+// It returns the element's geometric stiffness matrix in the global reference frame.
+func (e Element) GeometricStiffnessGlobalMat(startT, endT nums.TParam, axialForce float64) mat.ReadOnlyMatrix {
+	var (
+		l     = e.geometry.LengthBetween(startT, endT)
+		local = [6][6]float64{}
+	)
+
+	// Local terms: only the transverse displacements and rotations are coupled
+	local[1] = [6]float64{0, 6.0 / 5.0, l / 10.0, 0, -6.0 / 5.0, l / 10.0}
+	local[2] = [6]float64{0, l / 10.0, 2.0 * l * l / 15.0, 0, -l / 10.0, -l * l / 30.0}
+	local[4] = [6]float64{0, -6.0 / 5.0, -l / 10.0, 0, 6.0 / 5.0, -l / 10.0}
+	local[5] = [6]float64{0, l / 10.0, -l * l / 30.0, 0, -l / 10.0, 2.0 * l * l / 15.0}
+
+	return e.localToGlobalMat(local, axialForce/l)
+}
+
+// MassGlobalMat generates the mass matrix of the element between the given positions and applies
+// the rotation defined by the element's geometry reference frame. The element's mass per unit
+// length is the product of the material's density and the section's area.
+//
+// The consistent mass matrix is derived from the same shape functions as the stiffness matrix:
+// linear for the axial displacements and cubic for the bending displacements. The lumped mass
+// matrix places half of the mass in each of the ends' translations, neglecting the rotational
+// inertia.
+//
+// It returns the element's mass matrix in the global reference frame.
+func (e Element) MassGlobalMat(startT, endT nums.TParam, lumped bool) mat.ReadOnlyMatrix {
+	var (
+		l     = e.geometry.LengthBetween(startT, endT)
+		mass  = e.material.Density * e.section.Area * l
+		local = [6][6]float64{}
+	)
+
+	if lumped {
+		local[0][0], local[1][1], local[3][3], local[4][4] = 0.5, 0.5, 0.5, 0.5
+		return e.localToGlobalMat(local, mass)
+	}
+
+	// Axial terms
+	local[0][0], local[0][3] = 140.0, 70.0
+	local[3][0], local[3][3] = 70.0, 140.0
+
+	// Bending terms
+	local[1] = [6]float64{0, 156.0, 22.0 * l, 0, 54.0, -13.0 * l}
+	local[2] = [6]float64{0, 22.0 * l, 4.0 * l * l, 0, 13.0 * l, -3.0 * l * l}
+	local[4] = [6]float64{0, 54.0, 13.0 * l, 0, 156.0, -22.0 * l}
+	local[5] = [6]float64{0, -13.0 * l, -3.0 * l * l, 0, -22.0 * l, 4.0 * l * l}
+
+	return e.localToGlobalMat(local, mass/420.0)
+}
+
+// localToGlobalMat computes the matrix Rᵀ · m · R, scaled by the given factor, where m is a
+// matrix in the element's local frame and R the rotation from the global to the local frame
+// projections of both ends.
+func (e Element) localToGlobalMat(local [6][6]float64, factor float64) mat.ReadOnlyMatrix {
+	var (
+		c      = e.geometry.RefFrame().Cos()
+		s      = e.geometry.RefFrame().Sin()
+		rotate = [6][6]float64{}
+		k      = mat.MakeSquareDense(6)
+	)
+
+	// Rotation from global to local projections, for both nodes
+	for _, offset := range [2]int{0, 3} {
+		rotate[offset][offset], rotate[offset][offset+1] = c, s
+		rotate[offset+1][offset], rotate[offset+1][offset+1] = -s, c
+		rotate[offset+2][offset+2] = 1.0
+	}
+
+	for row := 0; row < 6; row++ {
+		for col := 0; col < 6; col++ {
+			value := 0.0
+			for i := 0; i < 6; i++ {
+				for j := 0; j < 6; j++ {
+					value += rotate[i][row] * local[i][j] * rotate[j][col]
+				}
+			}
+
+			k.SetValue(row, col, factor*value)
+		}
+	}
 
 	return k
 }
@@ -222,12 +409,13 @@ func (e *Element) Equals(other *Element) bool {
 		e.startLink.Equals(other.startLink) &&
 		e.endLink.Equals(other.endLink) &&
 		e.material.Name == other.material.Name &&
-		e.section.Name == other.section.Name
+		e.section.Name == other.section.Name &&
+		e.axialBehavior == other.axialBehavior
 }
 
 // String representation of the bar.
 func (e Element) String() string {
-	return fmt.Sprintf(
+	str := fmt.Sprintf(
 		"%s: %s %s %s %s '%s' '%s'",
 		e.id,
 		e.startNodeID,
@@ -237,4 +425,10 @@ func (e Element) String() string {
 		e.material.Name,
 		e.section.Name,
 	)
+
+	if e.HasAxialBehaviorLimit() {
+		str += " " + string(e.axialBehavior)
+	}
+
+	return str
 }
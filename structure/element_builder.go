@@ -15,8 +15,10 @@ type ElementBuilder struct {
 	startLink, endLink *Constraint
 	material           *Material
 	section            *Section
+	axialBehavior      AxialBehavior
 	concentratedLoads  []*load.ConcentratedLoad
 	distributedLoads   []*load.DistributedLoad
+	thermalLoads       []*load.ThermalLoad
 }
 
 // MakeElementBuilder creates a new builder to construct an element (bar) using
@@ -58,6 +60,13 @@ func (builder *ElementBuilder) WithSection(section *Section) *ElementBuilder {
 	return builder
 }
 
+// WithAxialBehavior limits the sign of the axial forces the element can take: tension-only or
+// compression-only. Elements take both by default.
+func (builder *ElementBuilder) WithAxialBehavior(behavior AxialBehavior) *ElementBuilder {
+	builder.axialBehavior = behavior
+	return builder
+}
+
 func (builder *ElementBuilder) IncludeOwnWeightLoad() *ElementBuilder {
 	builder.ensureSectionAndMaterial()
 
@@ -90,6 +99,16 @@ func (builder *ElementBuilder) AddDistributedLoad(load *load.DistributedLoad) *E
 	return builder
 }
 
+func (builder *ElementBuilder) AddThermalLoads(loads []*load.ThermalLoad) *ElementBuilder {
+	builder.thermalLoads = append(builder.thermalLoads, loads...)
+	return builder
+}
+
+func (builder *ElementBuilder) AddThermalLoad(load *load.ThermalLoad) *ElementBuilder {
+	builder.thermalLoads = append(builder.thermalLoads, load)
+	return builder
+}
+
 func (builder ElementBuilder) Build() *Element {
 	builder.ensureNodesInfo()
 	builder.ensureSectionAndMaterial()
@@ -103,8 +122,10 @@ func (builder ElementBuilder) Build() *Element {
 		endLink:           builder.endLink,
 		material:          builder.material,
 		section:           builder.section,
+		axialBehavior:     builder.axialBehavior,
 		ConcentratedLoads: builder.concentratedLoads,
 		DistributedLoads:  builder.distributedLoads,
+		ThermalLoads:      builder.thermalLoads,
 	}
 }
 
@@ -0,0 +1,118 @@
+package structure
+
+import (
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/angelsolaorbaiceta/inkmath/mat"
+)
+
+// A CorotationalState is the state of a slice of an element in its deformed configuration,
+// according to the corotational formulation: the slice's rigid body motion is followed by a frame
+// attached to its chord, where the deformations are small and the linear beam theory applies.
+//
+// The local forces are referred to the chord's frame: the axial force (positive in tension) and
+// the moments at both ends of the slice (positive counterclockwise).
+type CorotationalState struct {
+	ChordLength float64
+	AxialForce  float64
+	StartMoment float64
+	EndMoment   float64
+
+	// GlobalForces are the slice's internal forces in the global frame, in the same order as the
+	// degrees of freedom: {fx, fy, mz} at the start node and {fx, fy, mz} at the end node.
+	GlobalForces [6]float64
+
+	// TangentStiffness is the slice's tangent stiffness matrix in the global frame.
+	TangentStiffness mat.ReadOnlyMatrix
+}
+
+// CorotationalState computes the state of the element's slice between the given positions, for
+// the given global displacements of its ends: {dx, dy, rz} at the start and {dx, dy, rz} at the
+// end, measured from the undeformed configuration.
+//
+// The rigid rotation of the slice is the angle between its current chord and the element's
+// reference frame. The local deformations, the chord's elongation and the rotations of the ends
+// relative to the chord, yield the local forces using the same stiffness terms as the linear
+// analysis, including the shear deformation if the section has a shear area.
+func (e Element) CorotationalState(startT, endT nums.TParam, displacements [6]float64) *CorotationalState {
+	var (
+		initialLength = e.geometry.LengthBetween(startT, endT)
+		start         = e.PointAt(startT)
+		end           = e.PointAt(endT)
+		chord         = g2d.MakeVector(
+			end.X()+displacements[3]-start.X()-displacements[0],
+			end.Y()+displacements[4]-start.Y()-displacements[1],
+		)
+		length      = chord.Length()
+		localChord  = e.RefFrame().ProjectVector(chord)
+		rigidRot    = math.Atan2(localChord.Y(), localChord.X())
+		chordFrame  = g2d.MakeRefFrameWithIVersor(chord)
+		c, s        = chordFrame.Cos(), chordFrame.Sin()
+		ea          = e.material.YoungMod * e.section.Area
+		ei          = e.material.YoungMod * e.section.IStrong
+		phi         = e.ShearDeformationFactor(initialLength)
+		bendNear    = (4.0 + phi) * ei / (initialLength * (1.0 + phi))
+		bendFar     = (2.0 - phi) * ei / (initialLength * (1.0 + phi))
+		startRot    = displacements[2] - rigidRot
+		endRot      = displacements[5] - rigidRot
+		axialForce  = ea * (length - initialLength) / initialLength
+		startMoment = bendNear*startRot + bendFar*endRot
+		endMoment   = bendFar*startRot + bendNear*endRot
+		localStiff  = [3][3]float64{
+			{ea / initialLength, 0, 0},
+			{0, bendNear, bendFar},
+			{0, bendFar, bendNear},
+		}
+
+		// r is the chord's direction and z its normal, both expanded to the slice's degrees of freedom
+		r = [6]float64{-c, -s, 0, c, s, 0}
+		z = [6]float64{s, -c, 0, -s, c, 0}
+
+		// b relates the variations of the global displacements and the local deformations
+		b = [3][6]float64{
+			r,
+			{-s / length, c / length, 1, s / length, -c / length, 0},
+			{-s / length, c / length, 0, s / length, -c / length, 1},
+		}
+		localForces = [3]float64{axialForce, startMoment, endMoment}
+		state       = &CorotationalState{
+			ChordLength: length,
+			AxialForce:  axialForce,
+			StartMoment: startMoment,
+			EndMoment:   endMoment,
+		}
+		k = mat.MakeSquareDense(6)
+	)
+
+	// Internal forces: f = Bᵀ · q
+	for i := 0; i < 6; i++ {
+		for j := 0; j < 3; j++ {
+			state.GlobalForces[i] += b[j][i] * localForces[j]
+		}
+	}
+
+	// Tangent stiffness: Bᵀ · k · B + z · zᵀ · N / L + (r · zᵀ + z · rᵀ) · (M1 + M2) / L²
+	var (
+		axialTerm   = axialForce / length
+		bendingTerm = (startMoment + endMoment) / (length * length)
+	)
+
+	for row := 0; row < 6; row++ {
+		for col := 0; col < 6; col++ {
+			value := axialTerm*z[row]*z[col] + bendingTerm*(r[row]*z[col]+z[row]*r[col])
+			for i := 0; i < 3; i++ {
+				for j := 0; j < 3; j++ {
+					value += b[i][row] * localStiff[i][j] * b[j][col]
+				}
+			}
+
+			k.SetValue(row, col, value)
+		}
+	}
+
+	state.TangentStiffness = k
+
+	return state
+}
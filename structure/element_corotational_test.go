@@ -0,0 +1,67 @@
+package structure
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+func TestCorotationalState(t *testing.T) {
+	t.Run("tangent stiffness in the undeformed configuration is the linear stiffness", func(t *testing.T) {
+		var (
+			element = makeElement()
+			state   = element.CorotationalState(nums.MinT, nums.MaxT, [6]float64{})
+			linear  = element.StiffnessGlobalMat(nums.MinT, nums.MaxT)
+		)
+
+		for row := 0; row < 6; row++ {
+			for col := 0; col < 6; col++ {
+				if got, want := state.TangentStiffness.Value(row, col), linear.Value(row, col); !nums.FloatsEqual(got, want) {
+					t.Errorf("Expected %f at (%d, %d), but got %f", want, row, col, got)
+				}
+			}
+		}
+
+		for i, force := range state.GlobalForces {
+			if !nums.FloatsEqual(force, 0.0) {
+				t.Errorf("Expected no force at %d, but got %f", i, force)
+			}
+		}
+	})
+
+	t.Run("rigid body rotation yields no forces", func(t *testing.T) {
+		var (
+			element  = makeElement()
+			angle    = math.Pi / 3.0
+			length   = element.Length()
+			endDispX = length*math.Cos(angle) - length
+			endDispY = length * math.Sin(angle)
+			state    = element.CorotationalState(
+				nums.MinT, nums.MaxT, [6]float64{0, 0, angle, endDispX, endDispY, angle},
+			)
+		)
+
+		if !nums.FloatsEqual(state.ChordLength, length) {
+			t.Errorf("Expected chord length %f, but got %f", length, state.ChordLength)
+		}
+
+		for i, force := range state.GlobalForces {
+			if !nums.FloatsEqual(force, 0.0) {
+				t.Errorf("Expected no force at %d, but got %f", i, force)
+			}
+		}
+	})
+
+	t.Run("axial force from the chord elongation", func(t *testing.T) {
+		var (
+			element = makeElement()
+			state   = element.CorotationalState(nums.MinT, nums.MaxT, [6]float64{0, 0, 0, 0.5, 0, 0})
+			want    = material.YoungMod * section.Area * 0.5 / element.Length()
+		)
+
+		if !nums.FloatsEqual(state.AxialForce, want) {
+			t.Errorf("Expected axial force %f, but got %f", want, state.AxialForce)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package structure
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/angelsolaorbaiceta/inkfem/structure/load"
@@ -21,8 +22,8 @@ var (
 	endPoint = g2d.MakePoint(100, 0)
 	endNode  = MakeNode(endNodeID, endPoint, &NilConstraint)
 
-	material = &Material{"material", 2, 3, 4, 5, 6, 7}
-	section  = &Section{"section", 2, 3, 4, 5, 6}
+	material = &Material{"material", 2, 3, 4, 5, 6, 7, 8}
+	section  = &Section{"section", 2, 3, 4, 5, 6, 7, 0}
 )
 
 func TestElementStartPoint(t *testing.T) {
@@ -62,6 +63,30 @@ func TestElementHasLoadsApplied(t *testing.T) {
 	})
 }
 
+func TestElementLoadCases(t *testing.T) {
+	t.Run("an element without loads has no load cases", func(t *testing.T) {
+		element := makeElement()
+
+		if got := element.LoadCases(); len(got) != 0 {
+			t.Errorf("Expected no load cases, got %v", got)
+		}
+	})
+
+	t.Run("load cases are sorted with the default first", func(t *testing.T) {
+		element := makeConcLoadedElement(
+			load.MakeConcentrated(load.FX, true, nums.MinT, 10).InCase("wind"),
+			load.MakeConcentrated(load.FX, true, nums.MaxT, 10),
+			load.MakeConcentrated(load.FY, true, nums.MaxT, 10).InCase("live"),
+			load.MakeConcentrated(load.FY, true, nums.MinT, 10).InCase("wind"),
+		)
+
+		want := []string{load.DefaultCase, "live", "wind"}
+		if got := element.LoadCases(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Want load cases %v, got %v", want, got)
+		}
+	})
+}
+
 func TestIncludeOwnWeightLoad(t *testing.T) {
 	var (
 		element       = makeElementWithOwnWeight()
@@ -131,6 +156,28 @@ func TestElementIsAxial(t *testing.T) {
 		}
 	})
 
+	t.Run("isn't axial if has a temperature gradient", func(t *testing.T) {
+		element := makeElement()
+		element.startLink = &DispConstraint
+		element.endLink = &DispConstraint
+		element.ThermalLoads = []*load.ThermalLoad{load.MakeThermal(load.TemperatureGradient, 20)}
+
+		if element.IsAxialMember() {
+			t.Error("Element shouln't be axial")
+		}
+	})
+
+	t.Run("is axial if pinned and has a uniform temperature change", func(t *testing.T) {
+		element := makeElement()
+		element.startLink = &DispConstraint
+		element.endLink = &DispConstraint
+		element.ThermalLoads = []*load.ThermalLoad{load.MakeThermal(load.UniformTemperature, 30)}
+
+		if !element.IsAxialMember() {
+			t.Error("Element should be axial")
+		}
+	})
+
 	t.Run("is axial if pinned and all loads are nodal and not MZ", func(t *testing.T) {
 		l := load.MakeConcentrated(load.FY, true, nums.MinT, 10)
 		element := makeConcLoadedElement(l)
@@ -255,6 +302,59 @@ func TestHorizontalElementGlobalStiffnessMatrix(t *testing.T) {
 	})
 }
 
+func TestTimoshenkoElementGlobalStiffnessMatrix(t *testing.T) {
+	var (
+		shearSection = &Section{"shear_section", 2, 3, 4, 5, 6, 7, 0.001}
+		element      = makeElement()
+	)
+	element.section = shearSection
+
+	var (
+		matrix = element.StiffnessGlobalMat(nums.MinT, nums.MaxT)
+		e      = material.YoungMod
+		i      = shearSection.IStrong
+		l      = element.Length()
+		phi    = 12.0 * e * i / (material.ShearMod * shearSection.ShearArea * l * l)
+	)
+
+	t.Run("shear deformation factor", func(t *testing.T) {
+		if got := element.ShearDeformationFactor(l); !nums.FloatsEqual(phi, got) {
+			t.Errorf("Expected factor to be %f, but got %f", phi, got)
+		}
+	})
+
+	t.Run("Fy -> Dy terms", func(t *testing.T) {
+		want := (12.0 * e * i) / (l * l * l * (1.0 + phi))
+
+		if got := matrix.Value(1, 1); !nums.FloatsEqual(want, got) {
+			t.Errorf("Expected term to be %f, but got %f", want, got)
+		}
+	})
+
+	t.Run("Fy -> Rz terms", func(t *testing.T) {
+		want := (6.0 * e * i) / (l * l * (1.0 + phi))
+
+		if got := matrix.Value(1, 2); !nums.FloatsEqual(want, got) {
+			t.Errorf("Expected term to be %f, but got %f", want, got)
+		}
+	})
+
+	t.Run("Mz -> Rz terms", func(t *testing.T) {
+		var (
+			wantNear = (4.0 + phi) * e * i / (l * (1.0 + phi))
+			wantFar  = (2.0 - phi) * e * i / (l * (1.0 + phi))
+		)
+
+		if got := matrix.Value(2, 2); !nums.FloatsEqual(wantNear, got) {
+			t.Errorf("Expected term to be %f, but got %f", wantNear, got)
+		}
+
+		if got := matrix.Value(2, 5); !nums.FloatsEqual(wantFar, got) {
+			t.Errorf("Expected term to be %f, but got %f", wantFar, got)
+		}
+	})
+}
+
 func makeElement() *Element {
 	return MakeElementBuilder(
 		elementID,
@@ -283,7 +383,7 @@ func makeElementWithOwnWeight() *Element {
 	).IncludeOwnWeightLoad().Build()
 }
 
-func makeConcLoadedElement(l *load.ConcentratedLoad) *Element {
+func makeConcLoadedElement(loads ...*load.ConcentratedLoad) *Element {
 	return MakeElementBuilder(
 		elementID,
 	).WithStartNode(
@@ -295,7 +395,7 @@ func makeConcLoadedElement(l *load.ConcentratedLoad) *Element {
 	).WithSection(
 		section,
 	).AddConcentratedLoads(
-		[]*load.ConcentratedLoad{l},
+		loads,
 	).Build()
 }
 
@@ -314,3 +414,46 @@ func makeDistLoadedElement(l *load.DistributedLoad) *Element {
 		[]*load.DistributedLoad{l},
 	).Build()
 }
+
+func TestElementMassGlobalMatrix(t *testing.T) {
+	var (
+		element = makeElement()
+		mass    = material.Density * section.Area * element.Length()
+	)
+
+	t.Run("consistent mass matrix keeps the total translational mass", func(t *testing.T) {
+		matrix := element.MassGlobalMat(nums.MinT, nums.MaxT, false)
+
+		for _, dofs := range [][2]int{{0, 3}, {1, 4}} {
+			total := 0.0
+			for _, i := range dofs {
+				for _, j := range dofs {
+					total += matrix.Value(i, j)
+				}
+			}
+
+			if !nums.FloatsEqual(mass, total) {
+				t.Errorf("Expected total mass to be %f, but got %f", mass, total)
+			}
+		}
+
+		if want, got := 13.0*mass/35.0, matrix.Value(1, 1); !nums.FloatsEqual(want, got) {
+			t.Errorf("Expected term to be %f, but got %f", want, got)
+		}
+	})
+
+	t.Run("lumped mass matrix", func(t *testing.T) {
+		matrix := element.MassGlobalMat(nums.MinT, nums.MaxT, true)
+
+		for i := 0; i < 6; i++ {
+			want := 0.5 * mass
+			if i == 2 || i == 5 {
+				want = 0.0
+			}
+
+			if got := matrix.Value(i, i); !nums.FloatsEqual(want, got) {
+				t.Errorf("Expected term (%d, %d) to be %f, but got %f", i, i, want, got)
+			}
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
 )
 
 type ElementsSeq struct {
@@ -86,3 +87,28 @@ func (el *ElementsSeq) LoadsCount() int {
 
 	return count
 }
+
+// LoadCases returns the names of the load cases the loads of the elements belong to, sorted
+// alphabetically with the default case first. If no element has loads applied, only the
+// default case is returned.
+func (el *ElementsSeq) LoadCases() []string {
+	var (
+		loadCases = []string{}
+		seen      = make(map[string]bool)
+	)
+
+	for _, element := range el.elements {
+		for _, loadCase := range element.LoadCases() {
+			if !seen[loadCase] {
+				seen[loadCase] = true
+				loadCases = append(loadCases, loadCase)
+			}
+		}
+	}
+
+	if len(loadCases) == 0 {
+		return []string{load.DefaultCase}
+	}
+
+	return load.SortLoadCases(loadCases)
+}
@@ -8,11 +8,13 @@ import (
 )
 
 // A ConcentratedLoad is a load applied in a specific point.
+// It belongs to a load case, which is the default one unless otherwise stated.
 type ConcentratedLoad struct {
 	Term            Term
 	IsInLocalCoords bool
 	T               nums.TParam
 	Value           float64
+	LoadCase        string
 }
 
 // MakeConcentrated creates a concentrated load for the given term (FX, FY or MZ) which may be defined
@@ -25,7 +27,18 @@ func MakeConcentrated(
 	t nums.TParam,
 	value float64,
 ) *ConcentratedLoad {
-	return &ConcentratedLoad{term, isInLocalCoords, t, value}
+	return &ConcentratedLoad{term, isInLocalCoords, t, value, DefaultCase}
+}
+
+// InCase sets the load case the load belongs to and returns the load.
+func (load *ConcentratedLoad) InCase(loadCase string) *ConcentratedLoad {
+	load.LoadCase = loadCase
+	return load
+}
+
+// IsInDefaultCase returns true if the load belongs to the default load case.
+func (load *ConcentratedLoad) IsInDefaultCase() bool {
+	return load.LoadCase == DefaultCase
 }
 
 // IsNodal returns true if the load is applied in extreme values of T.
@@ -73,7 +86,8 @@ func (load *ConcentratedLoad) Equals(other *ConcentratedLoad) bool {
 	return load.Term == other.Term &&
 		load.IsInLocalCoords == other.IsInLocalCoords &&
 		load.T.Equals(other.T) &&
-		nums.FloatsEqual(load.Value, other.Value)
+		nums.FloatsEqual(load.Value, other.Value) &&
+		load.LoadCase == other.LoadCase
 }
 
 func ConcentratedLoadsEqual(a, b []*ConcentratedLoad) bool {
@@ -13,11 +13,14 @@ import (
 // - a term of application, which in 2D can be: Force in X, Force in Y or Moment about Z
 // - a projection frame, which can be local to the element to which load is applied or global
 // - start/end position and value
+//
+// The load belongs to a load case, which is the default one unless otherwise stated.
 type DistributedLoad struct {
 	Term                 Term
 	IsInLocalCoords      bool
 	StartT, EndT         nums.TParam
 	StartValue, EndValue float64
+	LoadCase             string
 }
 
 // MakeDistributed creates a distributed load for the given term (FX, FY, MZ) which may be defined
@@ -32,7 +35,18 @@ func MakeDistributed(
 	endT nums.TParam,
 	endValue float64,
 ) *DistributedLoad {
-	return &DistributedLoad{term, isInLocalCoords, startT, endT, startValue, endValue}
+	return &DistributedLoad{term, isInLocalCoords, startT, endT, startValue, endValue, DefaultCase}
+}
+
+// InCase sets the load case the load belongs to and returns the load.
+func (load *DistributedLoad) InCase(loadCase string) *DistributedLoad {
+	load.LoadCase = loadCase
+	return load
+}
+
+// IsInDefaultCase returns true if the load belongs to the default load case.
+func (load *DistributedLoad) IsInDefaultCase() bool {
+	return load.LoadCase == DefaultCase
 }
 
 // ValueAt returns the value of the load at a given t Parameter value.
@@ -82,7 +96,8 @@ func (load *DistributedLoad) Equals(other *DistributedLoad) bool {
 		load.StartT.Equals(other.StartT) &&
 		nums.FloatsEqual(load.StartValue, other.StartValue) &&
 		load.EndT.Equals(other.EndT) &&
-		nums.FloatsEqual(load.EndValue, other.EndValue)
+		nums.FloatsEqual(load.EndValue, other.EndValue) &&
+		load.LoadCase == other.LoadCase
 }
 
 // AsEquation returns a single variable linear equation representing the
@@ -0,0 +1,19 @@
+package load
+
+import "sort"
+
+// DefaultCase is the load case of the loads which aren't explicitly assigned to one.
+const DefaultCase = "default"
+
+// SortLoadCases sorts the load case names alphabetically, with the default case first.
+func SortLoadCases(loadCases []string) []string {
+	sort.Slice(loadCases, func(i, j int) bool {
+		if loadCases[i] == DefaultCase || loadCases[j] == DefaultCase {
+			return loadCases[i] == DefaultCase && loadCases[j] != DefaultCase
+		}
+
+		return loadCases[i] < loadCases[j]
+	})
+
+	return loadCases
+}
@@ -0,0 +1,64 @@
+package load
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+// A CombinationTerm is a load case multiplied by a factor in a load combination.
+type CombinationTerm struct {
+	Factor   float64
+	LoadCase string
+}
+
+// A Combination is a named linear combination of load cases, where the loads in each case are
+// multiplied by a factor. For example: "ULS1 -> 1.35 dead + 1.5 live".
+type Combination struct {
+	Name  string
+	Terms []CombinationTerm
+}
+
+// MakeCombination creates a load combination with the given name and terms.
+func MakeCombination(name string, terms ...CombinationTerm) *Combination {
+	return &Combination{Name: name, Terms: terms}
+}
+
+// LoadCases returns the names of the load cases included in the combination, in the order
+// they are defined.
+func (c *Combination) LoadCases() []string {
+	loadCases := make([]string, len(c.Terms))
+	for i, term := range c.Terms {
+		loadCases[i] = term.LoadCase
+	}
+
+	return loadCases
+}
+
+// Equals returns true if both combinations have the same name and terms, in the same order.
+func (c *Combination) Equals(other *Combination) bool {
+	if c.Name != other.Name || len(c.Terms) != len(other.Terms) {
+		return false
+	}
+
+	for i, term := range c.Terms {
+		if term.LoadCase != other.Terms[i].LoadCase || !nums.FloatsEqual(term.Factor, other.Terms[i].Factor) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String representation of the combination terms, as in "1.35 dead + 1.5 live".
+// This method is used for serialization, thus if the format is changed, the definition,
+// preprocessed and solution file formats might be affected.
+func (c *Combination) String() string {
+	terms := make([]string, len(c.Terms))
+	for i, term := range c.Terms {
+		terms[i] = fmt.Sprintf("%g %s", term.Factor, term.LoadCase)
+	}
+
+	return strings.Join(terms, " + ")
+}
@@ -0,0 +1,60 @@
+package load
+
+import "fmt"
+
+// A LoadTrain is a set of concentrated loads, like the axles of a vehicle or the wheels of a
+// crane, which move together keeping the distances between them. The loads act downwards, in the
+// global Y direction.
+//
+// The first axle leads the train: the offset of each axle is its distance behind the first one.
+type LoadTrain struct {
+	loads   []float64
+	offsets []float64
+}
+
+// MakeLoadTrain creates a load train with the given axle loads and the spacings between each
+// consecutive pair of axles.
+//
+// Panics if there are no axles, the number of spacings isn't one less than the number of axles,
+// or a spacing is negative.
+func MakeLoadTrain(loads, spacings []float64) *LoadTrain {
+	if len(loads) == 0 {
+		panic("A load train needs at least one axle")
+	}
+	if len(spacings) != len(loads)-1 {
+		panic(fmt.Sprintf(
+			"A load train with %d axles needs %d spacings, got %d", len(loads), len(loads)-1, len(spacings),
+		))
+	}
+
+	offsets := make([]float64, len(loads))
+	for i, spacing := range spacings {
+		if spacing < 0 {
+			panic(fmt.Sprintf("The spacings of a load train can't be negative, got %f", spacing))
+		}
+
+		offsets[i+1] = offsets[i] + spacing
+	}
+
+	return &LoadTrain{loads: loads, offsets: offsets}
+}
+
+// AxlesCount is the number of axles, or concentrated loads, of the train.
+func (train *LoadTrain) AxlesCount() int {
+	return len(train.loads)
+}
+
+// AxleLoad is the load of the axle at the given index.
+func (train *LoadTrain) AxleLoad(i int) float64 {
+	return train.loads[i]
+}
+
+// AxleOffset is the distance of the axle at the given index behind the first one.
+func (train *LoadTrain) AxleOffset(i int) float64 {
+	return train.offsets[i]
+}
+
+// Length is the distance between the first and last axles.
+func (train *LoadTrain) Length() float64 {
+	return train.offsets[len(train.offsets)-1]
+}
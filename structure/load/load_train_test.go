@@ -0,0 +1,32 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTrain(t *testing.T) {
+	train := MakeLoadTrain([]float64{100.0, 150.0, 150.0}, []float64{300.0, 120.0})
+
+	t.Run("the axles' offsets accumulate the spacings", func(t *testing.T) {
+		assert.Equal(t, 3, train.AxlesCount())
+		assert.Equal(t, 0.0, train.AxleOffset(0))
+		assert.Equal(t, 300.0, train.AxleOffset(1))
+		assert.Equal(t, 420.0, train.AxleOffset(2))
+		assert.Equal(t, 150.0, train.AxleLoad(2))
+	})
+
+	t.Run("the length is the last axle's offset", func(t *testing.T) {
+		assert.Equal(t, 420.0, train.Length())
+	})
+
+	t.Run("needs a spacing between each pair of axles", func(t *testing.T) {
+		assert.Panics(t, func() { MakeLoadTrain([]float64{100.0, 150.0}, []float64{}) })
+		assert.Panics(t, func() { MakeLoadTrain([]float64{}, []float64{}) })
+	})
+
+	t.Run("spacings can't be negative", func(t *testing.T) {
+		assert.Panics(t, func() { MakeLoadTrain([]float64{100.0, 150.0}, []float64{-1.0}) })
+	})
+}
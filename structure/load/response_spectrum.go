@@ -0,0 +1,74 @@
+package load
+
+import "fmt"
+
+// A ResponseSpectrum is a design spectrum for the seismic analysis: the spectral acceleration as a
+// function of the vibration period. It's defined by points, (period, acceleration) pairs, and
+// linearly interpolated between them.
+//
+// The acceleration for the periods outside the points is that of the closest point.
+type ResponseSpectrum struct {
+	periods       []float64
+	accelerations []float64
+}
+
+// MakeResponseSpectrum creates a response spectrum with the given points' periods and spectral
+// accelerations.
+//
+// Panics if there are no points, the number of periods and accelerations differ, or the periods
+// aren't strictly increasing or are negative.
+func MakeResponseSpectrum(periods, accelerations []float64) *ResponseSpectrum {
+	if len(periods) == 0 {
+		panic("A response spectrum needs at least one point")
+	}
+	if len(periods) != len(accelerations) {
+		panic(fmt.Sprintf(
+			"A response spectrum has %d periods but %d accelerations", len(periods), len(accelerations),
+		))
+	}
+	if periods[0] < 0 {
+		panic(fmt.Sprintf("The periods of a response spectrum can't be negative, got %f", periods[0]))
+	}
+
+	for i := 1; i < len(periods); i++ {
+		if periods[i] <= periods[i-1] {
+			panic(fmt.Sprintf(
+				"The periods of a response spectrum must be increasing, got %f after %f",
+				periods[i], periods[i-1],
+			))
+		}
+	}
+
+	return &ResponseSpectrum{periods: periods, accelerations: accelerations}
+}
+
+// PointsCount is the number of points defining the spectrum.
+func (s *ResponseSpectrum) PointsCount() int {
+	return len(s.periods)
+}
+
+// AccelerationAt returns the spectral acceleration for the given period, linearly interpolated
+// between the points around it.
+func (s *ResponseSpectrum) AccelerationAt(period float64) float64 {
+	last := len(s.periods) - 1
+
+	if period <= s.periods[0] {
+		return s.accelerations[0]
+	}
+	if period >= s.periods[last] {
+		return s.accelerations[last]
+	}
+
+	for i := 1; i <= last; i++ {
+		if period <= s.periods[i] {
+			var (
+				startPeriod, endPeriod = s.periods[i-1], s.periods[i]
+				startAccel, endAccel   = s.accelerations[i-1], s.accelerations[i]
+			)
+
+			return startAccel + (endAccel-startAccel)*(period-startPeriod)/(endPeriod-startPeriod)
+		}
+	}
+
+	return s.accelerations[last]
+}
@@ -0,0 +1,29 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseSpectrum(t *testing.T) {
+	spectrum := MakeResponseSpectrum([]float64{0.0, 0.1, 0.5, 2.0}, []float64{2.0, 5.0, 5.0, 1.25})
+
+	t.Run("accelerations at the points", func(t *testing.T) {
+		assert.InDelta(t, 2.0, spectrum.AccelerationAt(0.0), 1e-10)
+		assert.InDelta(t, 5.0, spectrum.AccelerationAt(0.5), 1e-10)
+	})
+
+	t.Run("accelerations are linearly interpolated", func(t *testing.T) {
+		assert.InDelta(t, 3.5, spectrum.AccelerationAt(0.05), 1e-10)
+		assert.InDelta(t, 3.125, spectrum.AccelerationAt(1.25), 1e-10)
+	})
+
+	t.Run("the closest point's acceleration outside the points", func(t *testing.T) {
+		assert.InDelta(t, 1.25, spectrum.AccelerationAt(4.0), 1e-10)
+	})
+
+	t.Run("periods must be increasing", func(t *testing.T) {
+		assert.Panics(t, func() { MakeResponseSpectrum([]float64{0.5, 0.1}, []float64{1.0, 2.0}) })
+	})
+}
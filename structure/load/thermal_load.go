@@ -0,0 +1,56 @@
+package load
+
+import "fmt"
+
+// ThermalTerm represents the available terms for which thermal loads can exist:
+// - Uniform temperature change
+// - Temperature gradient through the section's depth
+type ThermalTerm string
+
+const (
+	// UniformTemperature is a temperature change, ΔT, uniform in the whole section, which
+	// elongates (or shortens) the element.
+	UniformTemperature = ThermalTerm("dt")
+
+	// TemperatureGradient is the temperature difference between the top fiber (in the direction of
+	// the element's local y-axis) and the bottom fiber of the section, ΔT, which bends the element
+	// with a curvature proportional to ΔT/h, being h the section's depth.
+	TemperatureGradient = ThermalTerm("dtg")
+)
+
+// IsValidThermalTerm validates the thermal load term, to make sure it represents a known term.
+func IsValidThermalTerm(term ThermalTerm) bool {
+	return term == UniformTemperature || term == TemperatureGradient
+}
+
+// EnsureValidThermalTerm validates the thermal load term and panics if an unknown term happens.
+func EnsureValidThermalTerm(term ThermalTerm) {
+	if !IsValidThermalTerm(term) {
+		panic(fmt.Sprintf("Invalid thermal load term: '%s'", term))
+	}
+}
+
+// A ThermalLoad is a temperature change applied to the whole length of an element.
+// It belongs to a load case, which is the default one unless otherwise stated.
+type ThermalLoad struct {
+	Term     ThermalTerm
+	Value    float64
+	LoadCase string
+}
+
+// MakeThermal creates a thermal load for the given term (UniformTemperature or
+// TemperatureGradient) with the given temperature difference.
+func MakeThermal(term ThermalTerm, value float64) *ThermalLoad {
+	return &ThermalLoad{term, value, DefaultCase}
+}
+
+// InCase sets the load case the load belongs to and returns the load.
+func (load *ThermalLoad) InCase(loadCase string) *ThermalLoad {
+	load.LoadCase = loadCase
+	return load
+}
+
+// IsInDefaultCase returns true if the load belongs to the default load case.
+func (load *ThermalLoad) IsInDefaultCase() bool {
+	return load.LoadCase == DefaultCase
+}
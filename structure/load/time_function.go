@@ -0,0 +1,69 @@
+package load
+
+import "fmt"
+
+// A TimeFunction is a value that varies with time, like the factor of a dynamic load or the
+// ground acceleration of an earthquake record. It's defined by points, (time, value) pairs, and
+// linearly interpolated between them.
+//
+// The function is zero before the first point's time and after the last one.
+type TimeFunction struct {
+	times  []float64
+	values []float64
+}
+
+// MakeTimeFunction creates a time function with the given points' times and values.
+//
+// Panics if there are no points, the number of times and values differ, or the times aren't
+// strictly increasing.
+func MakeTimeFunction(times, values []float64) *TimeFunction {
+	if len(times) == 0 {
+		panic("A time function needs at least one point")
+	}
+	if len(times) != len(values) {
+		panic(fmt.Sprintf("A time function has %d times but %d values", len(times), len(values)))
+	}
+
+	for i := 1; i < len(times); i++ {
+		if times[i] <= times[i-1] {
+			panic(fmt.Sprintf(
+				"The times of a time function must be increasing, got %f after %f", times[i], times[i-1],
+			))
+		}
+	}
+
+	return &TimeFunction{times: times, values: values}
+}
+
+// PointsCount is the number of points defining the function.
+func (f *TimeFunction) PointsCount() int {
+	return len(f.times)
+}
+
+// Duration is the time of the last point of the function.
+func (f *TimeFunction) Duration() float64 {
+	return f.times[len(f.times)-1]
+}
+
+// ValueAt returns the value of the function at the given time, linearly interpolated between the
+// points around it.
+func (f *TimeFunction) ValueAt(time float64) float64 {
+	last := len(f.times) - 1
+
+	if time < f.times[0] || time > f.times[last] {
+		return 0.0
+	}
+
+	for i := 1; i <= last; i++ {
+		if time <= f.times[i] {
+			var (
+				startTime, endTime   = f.times[i-1], f.times[i]
+				startValue, endValue = f.values[i-1], f.values[i]
+			)
+
+			return startValue + (endValue-startValue)*(time-startTime)/(endTime-startTime)
+		}
+	}
+
+	return f.values[last]
+}
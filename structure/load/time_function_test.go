@@ -0,0 +1,36 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeFunction(t *testing.T) {
+	function := MakeTimeFunction([]float64{0.0, 0.5, 1.5}, []float64{0.0, 10.0, -10.0})
+
+	t.Run("duration is the last point's time", func(t *testing.T) {
+		assert.Equal(t, 1.5, function.Duration())
+		assert.Equal(t, 3, function.PointsCount())
+	})
+
+	t.Run("values at the points", func(t *testing.T) {
+		assert.InDelta(t, 0.0, function.ValueAt(0.0), 1e-10)
+		assert.InDelta(t, 10.0, function.ValueAt(0.5), 1e-10)
+		assert.InDelta(t, -10.0, function.ValueAt(1.5), 1e-10)
+	})
+
+	t.Run("values are linearly interpolated", func(t *testing.T) {
+		assert.InDelta(t, 5.0, function.ValueAt(0.25), 1e-10)
+		assert.InDelta(t, 0.0, function.ValueAt(1.0), 1e-10)
+	})
+
+	t.Run("zero outside the points", func(t *testing.T) {
+		assert.Equal(t, 0.0, function.ValueAt(-0.1))
+		assert.Equal(t, 0.0, function.ValueAt(2.0))
+	})
+
+	t.Run("times must be increasing", func(t *testing.T) {
+		assert.Panics(t, func() { MakeTimeFunction([]float64{0.0, 0.0}, []float64{1.0, 2.0}) })
+	})
+}
@@ -0,0 +1,48 @@
+package structure
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+)
+
+// LoadCombinationsSeq is the sequence of load combinations defined for a structure, in the
+// order they are defined.
+type LoadCombinationsSeq struct {
+	combinations []*load.Combination
+}
+
+// LoadCombinationsCount is the number of load combinations.
+func (lc *LoadCombinationsSeq) LoadCombinationsCount() int {
+	return len(lc.combinations)
+}
+
+// LoadCombinations returns the slice of load combinations.
+func (lc *LoadCombinationsSeq) LoadCombinations() []*load.Combination {
+	return lc.combinations
+}
+
+// SetLoadCombinations sets the load combinations.
+func (lc *LoadCombinationsSeq) SetLoadCombinations(combinations []*load.Combination) {
+	lc.combinations = combinations
+}
+
+// EnsureLoadCombinationsCases panics if any of the load combinations includes a load case
+// which isn't in the given ones.
+func (lc *LoadCombinationsSeq) EnsureLoadCombinationsCases(loadCases []string) {
+	existingCases := make(map[string]bool)
+	for _, loadCase := range loadCases {
+		existingCases[loadCase] = true
+	}
+
+	for _, combination := range lc.combinations {
+		for _, loadCase := range combination.LoadCases() {
+			if !existingCases[loadCase] {
+				panic(fmt.Sprintf(
+					"Load combination '%s' includes unknown load case '%s'",
+					combination.Name, loadCase,
+				))
+			}
+		}
+	}
+}
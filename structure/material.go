@@ -14,14 +14,16 @@ import (
 // - Poisson Ratio
 // - Yield Strength
 // - Ultimate Strength
+// - Thermal Expansion Coefficient
 type Material struct {
 	Name                             string
 	Density                          float64
 	YoungMod, ShearMod, PoissonRatio float64
 	YieldStrength, UltimateStrength  float64
+	ThermalExpansion                 float64
 }
 
-// MakeMaterial creates a material with the given properties.
+// MakeMaterial creates a material with the given properties and no thermal expansion.
 func MakeMaterial(name string, density, young, shear, poisson, yield, ultimate float64) *Material {
 	return &Material{
 		Name:             name,
@@ -36,13 +38,13 @@ func MakeMaterial(name string, density, young, shear, poisson, yield, ultimate f
 
 // MakeUnitMaterial creates a material with all properties set to 1.0.
 func MakeUnitMaterial() *Material {
-	return &Material{"unit_material", 1.0, 1.0, 1.0, 1.0, 1.0, 1.0}
+	return &Material{"unit_material", 1.0, 1.0, 1.0, 1.0, 1.0, 1.0, 1.0}
 }
 
 // String representation of the material.
 func (m *Material) String() string {
 	return fmt.Sprintf(
-		"'%s': %f %f %f %f %f %f",
+		"'%s': %f %f %f %f %f %f %f",
 		m.Name,
 		m.Density,
 		m.YoungMod,
@@ -50,6 +52,7 @@ func (m *Material) String() string {
 		m.PoissonRatio,
 		m.YieldStrength,
 		m.UltimateStrength,
+		m.ThermalExpansion,
 	)
 }
 
@@ -63,5 +66,6 @@ func (m *Material) Equals(other *Material) bool {
 		nums.FloatsEqual(m.ShearMod, other.ShearMod) &&
 		nums.FloatsEqual(m.PoissonRatio, other.PoissonRatio) &&
 		nums.FloatsEqual(m.YieldStrength, other.YieldStrength) &&
-		nums.FloatsEqual(m.UltimateStrength, other.UltimateStrength)
+		nums.FloatsEqual(m.UltimateStrength, other.UltimateStrength) &&
+		nums.FloatsEqual(m.ThermalExpansion, other.ThermalExpansion)
 }
@@ -0,0 +1,119 @@
+package structure
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+)
+
+// MovingLoadCasePrefix is the prefix of the names of the load cases with the loads of a train in
+// each of its positions, followed by the position's number.
+const MovingLoadCasePrefix = "train_"
+
+// A MovingLoadPosition is a position of a load train along a path of elements: the distance
+// from the path's start to the train's first axle. The train's loads in the position belong to
+// the load case.
+type MovingLoadPosition struct {
+	LoadCase string
+	Position float64
+}
+
+// AddMovingLoadCases moves the train along the path of elements, traversed from their start to
+// their end node, adding the train's loads in each position to their own load case. Returns the
+// positions, in order.
+//
+// The train enters the path with its first axle at the path's start, and advances in the given
+// steps until its last axle reaches the path's end. The axles out of the path aren't loaded, and
+// the positions without any axle in the path are skipped. An axle at the joint of two elements is
+// applied to the first of them.
+//
+// Panics if the path is empty, refers to elements that don't exist, or the step isn't positive.
+func (str *Structure) AddMovingLoadCases(
+	path []contracts.StrID,
+	train *load.LoadTrain,
+	step float64,
+) []MovingLoadPosition {
+	if len(path) == 0 {
+		panic("A load train needs a path of bars to move along")
+	}
+	if step <= 0 {
+		panic(fmt.Sprintf("The step of a load train must be positive, got %f", step))
+	}
+
+	var (
+		elements    = make([]*Element, len(path))
+		starts      = make([]float64, len(path))
+		pathLength  = 0.0
+		travel      float64
+		stepsCount  int
+		positions   []MovingLoadPosition
+		addPosition = func(position float64) {
+			loadCase := fmt.Sprintf("%s%d", MovingLoadCasePrefix, len(positions)+1)
+			if str.addTrainLoads(elements, starts, pathLength, train, position, loadCase) {
+				positions = append(positions, MovingLoadPosition{LoadCase: loadCase, Position: position})
+			}
+		}
+	)
+
+	for i, id := range path {
+		elements[i] = str.GetElementById(id)
+		starts[i] = pathLength
+		pathLength += elements[i].Length()
+	}
+
+	travel = pathLength + train.Length()
+	stepsCount = int(math.Floor(travel/step + 1e-9))
+
+	for i := 0; i <= stepsCount; i++ {
+		addPosition(float64(i) * step)
+	}
+	if !nums.FloatsEqual(float64(stepsCount)*step, travel) {
+		addPosition(travel)
+	}
+
+	return positions
+}
+
+// addTrainLoads adds the loads of the train's axles in the path, being the first axle at the
+// given position, to the load case. The elements are those of the path, each starting at the
+// given distance from the path's start. Returns false if no axle is in the path.
+func (str *Structure) addTrainLoads(
+	elements []*Element,
+	starts []float64,
+	pathLength float64,
+	train *load.LoadTrain,
+	position float64,
+	loadCase string,
+) bool {
+	var (
+		hasLoads  = false
+		tolerance = 1e-10 * pathLength
+	)
+
+	for i := 0; i < train.AxlesCount(); i++ {
+		distance := position - train.AxleOffset(i)
+		if distance < 0 || distance > pathLength+tolerance {
+			continue
+		}
+
+		for j, element := range elements {
+			if distance > starts[j]+element.Length()+tolerance && j < len(elements)-1 {
+				continue
+			}
+
+			var (
+				t    = nums.MakeTParam((distance - starts[j]) / element.Length())
+				axle = load.MakeConcentrated(load.FY, false, t, -train.AxleLoad(i)).InCase(loadCase)
+			)
+
+			element.ConcentratedLoads = append(element.ConcentratedLoads, axle)
+			hasLoads = true
+			break
+		}
+	}
+
+	return hasLoads
+}
@@ -0,0 +1,68 @@
+package structure
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddMovingLoadCases(t *testing.T) {
+	var (
+		nodeOne   = MakeNode("n1", g2d.MakePoint(0, 0), &DispConstraint)
+		nodeTwo   = MakeNode("n2", g2d.MakePoint(100, 0), &DispYConstraint)
+		nodeThree = MakeNode("n3", g2d.MakePoint(200, 0), &DispYConstraint)
+		spanOne   = MakeElementBuilder("b1").
+				WithStartNode(nodeOne, &FullConstraint).
+				WithEndNode(nodeTwo, &FullConstraint).
+				WithMaterial(material).
+				WithSection(section).
+				Build()
+		spanTwo = MakeElementBuilder("b2").
+			WithStartNode(nodeTwo, &FullConstraint).
+			WithEndNode(nodeThree, &FullConstraint).
+			WithMaterial(material).
+			WithSection(section).
+			Build()
+		str = Make(
+			StrMetadata{MajorVersion: 1, MinorVersion: 0},
+			map[contracts.StrID]*Node{"n1": nodeOne, "n2": nodeTwo, "n3": nodeThree},
+			[]*Element{spanOne, spanTwo},
+		)
+		train     = load.MakeLoadTrain([]float64{10.0, 20.0}, []float64{30.0})
+		positions = str.AddMovingLoadCases([]contracts.StrID{"b1", "b2"}, train, 40.0)
+	)
+
+	t.Run("the train moves until its last axle leaves the path", func(t *testing.T) {
+		var values []float64
+		for _, position := range positions {
+			values = append(values, position.Position)
+		}
+
+		assert.Equal(t, []float64{0, 40, 80, 120, 160, 200, 230}, values)
+		assert.Equal(t, "train_1", positions[0].LoadCase)
+		assert.Equal(t, "train_7", positions[6].LoadCase)
+	})
+
+	t.Run("each position has a load case with the axles in the path", func(t *testing.T) {
+		assert.Equal(t, 4, len(spanOne.LoadCases()))
+		assert.Equal(t, 4, len(spanTwo.LoadCases()))
+		assert.Equal(t, 6, len(spanOne.ConcentratedLoads))
+	})
+
+	t.Run("the axles act downwards", func(t *testing.T) {
+		first := spanOne.ConcentratedLoads[0]
+
+		assert.Equal(t, "train_1", first.LoadCase)
+		assert.Equal(t, load.FY, first.Term)
+		assert.False(t, first.IsInLocalCoords)
+		assert.Equal(t, 0.0, first.T.Value())
+		assert.Equal(t, -10.0, first.Value)
+	})
+
+	t.Run("the step must be positive", func(t *testing.T) {
+		assert.Panics(t, func() { str.AddMovingLoadCases([]contracts.StrID{"b1"}, train, 0) })
+	})
+}
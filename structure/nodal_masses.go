@@ -0,0 +1,56 @@
+package structure
+
+import (
+	"fmt"
+
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+)
+
+// A NodalMass is a mass concentrated in a node of the structure, like the equipment on a floor,
+// which adds to the mass of the bars in the dynamic analyses. The mass acts in both translational
+// degrees of freedom, and the rotational inertia in the rotation.
+type NodalMass struct {
+	NodeID            contracts.StrID
+	Mass              float64
+	RotationalInertia float64
+}
+
+// String returns the definition of the nodal mass, as in "n1 -> 250 10".
+func (m *NodalMass) String() string {
+	if m.RotationalInertia == 0 {
+		return fmt.Sprintf("%s -> %g", m.NodeID, m.Mass)
+	}
+
+	return fmt.Sprintf("%s -> %g %g", m.NodeID, m.Mass, m.RotationalInertia)
+}
+
+// NodalMassesSeq is the sequence of nodal masses defined for a structure, in the order they are
+// defined.
+type NodalMassesSeq struct {
+	masses []*NodalMass
+}
+
+// NodalMassesCount is the number of nodal masses.
+func (nm *NodalMassesSeq) NodalMassesCount() int {
+	return len(nm.masses)
+}
+
+// NodalMasses returns the slice of nodal masses.
+func (nm *NodalMassesSeq) NodalMasses() []*NodalMass {
+	return nm.masses
+}
+
+// SetNodalMasses sets the nodal masses.
+func (nm *NodalMassesSeq) SetNodalMasses(masses []*NodalMass) {
+	nm.masses = masses
+}
+
+// EnsureNodalMassesNodes panics if any of the nodal masses is in a node which isn't one of the
+// given nodes.
+func (nm *NodalMassesSeq) EnsureNodalMassesNodes(nodes map[contracts.StrID]*Node) {
+	for _, mass := range nm.masses {
+		if _, exists := nodes[mass.NodeID]; !exists {
+			panic(fmt.Sprintf("Nodal mass in unknown node '%s'", mass.NodeID))
+		}
+	}
+}
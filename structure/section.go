@@ -7,19 +7,29 @@ import (
 )
 
 // A Section of a resistant element.
+//
+// The depth of the section, in the direction of the strong axis bending, is only required for
+// the temperature gradient loads.
+//
+// The shear area, in the direction of the strong axis bending, is optional. When a section has a
+// shear area, the elements using it include the shear deformation (Timoshenko beam theory);
+// otherwise, it's neglected (Euler–Bernoulli beam theory).
 type Section struct {
 	Name           string
 	Area           float64
 	IStrong, IWeak float64 // Moments of Inertia
 	SStrong, SWeak float64 // Section Moduli
+	Depth          float64
+	ShearArea      float64
 }
 
-// MakeUnitSection creates a section with all properties set to 1.0.
+// MakeUnitSection creates a section with all properties set to 1.0, except for the shear area,
+// which is zero: the shear deformation is neglected.
 func MakeUnitSection() *Section {
-	return &Section{"unit_section", 1.0, 1.0, 1.0, 1.0, 1.0}
+	return &Section{"unit_section", 1.0, 1.0, 1.0, 1.0, 1.0, 1.0, 0.0}
 }
 
-// MakeSection creates a section with the given properties.
+// MakeSection creates a section with the given properties, no depth and no shear area.
 func MakeSection(name string, area, iStrong, iWeak, sStrong, sWeak float64) *Section {
 	return &Section{
 		Name:    name,
@@ -34,13 +44,15 @@ func MakeSection(name string, area, iStrong, iWeak, sStrong, sWeak float64) *Sec
 // String representation of the section.
 func (s *Section) String() string {
 	return fmt.Sprintf(
-		"'%s': %f %f %f %f %f",
+		"'%s': %f %f %f %f %f %f %f",
 		s.Name,
 		s.Area,
 		s.IStrong,
 		s.IWeak,
 		s.SStrong,
 		s.SWeak,
+		s.Depth,
+		s.ShearArea,
 	)
 }
 
@@ -53,5 +65,13 @@ func (s *Section) Equals(other *Section) bool {
 		nums.FloatsEqual(s.IStrong, other.IStrong) &&
 		nums.FloatsEqual(s.IWeak, other.IWeak) &&
 		nums.FloatsEqual(s.SStrong, other.SStrong) &&
-		nums.FloatsEqual(s.SWeak, other.SWeak)
+		nums.FloatsEqual(s.SWeak, other.SWeak) &&
+		nums.FloatsEqual(s.Depth, other.Depth) &&
+		nums.FloatsEqual(s.ShearArea, other.ShearArea)
+}
+
+// HasShearArea returns true if the section has a shear area, and thus the shear deformation of
+// the elements using it is to be included.
+func (s *Section) HasShearArea() bool {
+	return s.ShearArea > 0.0
 }
@@ -10,6 +10,8 @@ type Structure struct {
 	Metadata StrMetadata
 	NodesById
 	ElementsSeq
+	LoadCombinationsSeq
+	NodalMassesSeq
 }
 
 // Make creates a new structure model.
@@ -10,6 +10,7 @@ type SectionsByName = map[string]*Section
 type NodesByIdMap = map[contracts.StrID]*Node
 type ConcLoadsById = map[contracts.StrID][]*load.ConcentratedLoad
 type DistLoadsById = map[contracts.StrID][]*load.DistributedLoad
+type ThermLoadsById = map[contracts.StrID][]*load.ThermalLoad
 
 type StructureData struct {
 	Nodes             NodesByIdMap
@@ -17,4 +18,5 @@ type StructureData struct {
 	Sections          SectionsByName
 	ConcentratedLoads ConcLoadsById
 	DistributedLoads  DistLoadsById
+	ThermalLoads      ThermLoadsById
 }
@@ -24,3 +24,32 @@ func BenchmarkSolveStructure(b *testing.B) {
 		solution = solveStructure(str)
 	}
 }
+
+func BenchmarkSolveStructurePreconditioners(b *testing.B) {
+	build.Info = &build.BuildInfo{MajorVersion: 3, MinorVersion: 2}
+
+	var (
+		file = io.OpenFile("./retic_10x5.inkfem")
+		str  = iodef.Read(file)
+	)
+	defer file.Close()
+
+	preconditioners := []process.PreconditionerType{
+		process.JacobiPreconditioner,
+		process.IncompleteCholeskyPreconditioner,
+		process.SSORPreconditioner,
+	}
+
+	for _, preconditioner := range preconditioners {
+		b.Run(string(preconditioner), func(b *testing.B) {
+			options := process.SolveOptions{
+				MaxDisplacementsError: displError,
+				Preconditioner:        preconditioner,
+			}
+
+			for n := 0; n < b.N; n++ {
+				solution = solveStructureWithOptions(str, options)
+			}
+		})
+	}
+}
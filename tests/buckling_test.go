@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverColumnBuckling(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		force    = -1000.0
+		str      = makeCantileverColumnStructure(force)
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolveBuckling(pre, process.BucklingOptions{
+			SolveOptions: process.SolveOptions{
+				MaxDisplacementsError: displError,
+				Solver:                process.DirectSolver,
+			},
+			ModesCount: 2,
+		})
+		ei = material.YoungMod * section.IStrong
+	)
+
+	assert.Equal(t, load.DefaultCase, solution.LoadCase)
+	assert.Equal(t, 2, len(solution.Modes))
+
+	t.Run("first critical load is Euler's", func(t *testing.T) {
+		var (
+			criticalLoad = math.Pi * math.Pi * ei / (4.0 * length * length)
+			want         = criticalLoad / math.Abs(force)
+			got          = solution.Modes[0].LoadFactor
+		)
+
+		assert.True(t, nums.FloatsEqualEps(got, want, 1e-3*want), "want %f, got %f", want, got)
+	})
+
+	t.Run("second critical load", func(t *testing.T) {
+		var (
+			criticalLoad = 9.0 * math.Pi * math.Pi * ei / (4.0 * length * length)
+			want         = criticalLoad / math.Abs(force)
+			got          = solution.Modes[1].LoadFactor
+		)
+
+		assert.True(t, nums.FloatsEqualEps(got, want, 1e-2*want), "want %f, got %f", want, got)
+	})
+
+	t.Run("first mode shape is normalized at the free end", func(t *testing.T) {
+		var (
+			shape = solution.Modes[0].Elements[0]
+			last  = len(shape.GlobalXDispl) - 1
+		)
+
+		assert.True(t, nums.FloatsEqual(shape.GlobalXDispl[0].Value, 0.0))
+		assert.True(t, nums.FloatsEqual(shape.GlobalXDispl[last].Value, 1.0))
+	})
+}
+
+func makeCantileverColumnStructure(force float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("base", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("top", g2d.MakePoint(0, length), &structure.NilConstraint)
+		column  = structure.MakeElementBuilder(
+			"column",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddConcentratedLoads(
+			[]*load.ConcentratedLoad{load.MakeConcentrated(load.FY, false, nums.MaxT, force)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{column},
+	)
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverBeamLoadCombinations(t *testing.T) {
+	build.Info = &build.BuildInfo{MajorVersion: 3, MinorVersion: 2}
+
+	var (
+		deadLoad = load.MakeConcentrated(load.FY, true, nums.MaxT, -2000).InCase("dead")
+		liveLoad = load.MakeDistributed(load.FY, true, nums.MinT, -20, nums.MaxT, -20).InCase("live")
+		str      = makeCantileverBeamStructure(
+			[]*load.ConcentratedLoad{deadLoad},
+			[]*load.DistributedLoad{liveLoad},
+		)
+		uls1 = load.MakeCombination(
+			"ULS1",
+			load.CombinationTerm{Factor: 1.35, LoadCase: "dead"},
+			load.CombinationTerm{Factor: 1.5, LoadCase: "live"},
+		)
+		uls2 = load.MakeCombination(
+			"ULS2",
+			load.CombinationTerm{Factor: 0.8, LoadCase: "dead"},
+			load.CombinationTerm{Factor: -1.0, LoadCase: "live"},
+		)
+		solutions = solveStructureLoadCases(str, process.SolveOptions{
+			SafeChecks:            true,
+			MaxDisplacementsError: displError,
+			Solver:                process.DirectSolver,
+		})
+		dead, live   = solutions[1].Elements[0], solutions[2].Elements[0]
+		combinations = process.CombineSolutions(solutions, []*load.Combination{uls1, uls2})
+		envelopes    = process.MakeEnvelopes(combinations)
+	)
+
+	t.Run("there is a solution per combination", func(t *testing.T) {
+		assert.Equal(t, 2, len(combinations))
+		assert.Equal(t, "ULS1", combinations[0].LoadCase)
+		assert.Equal(t, uls1, combinations[0].Combination)
+	})
+
+	t.Run("the displacements are superposed", func(t *testing.T) {
+		var (
+			got  = combinations[0].Elements[0].GlobalYDispl
+			last = len(got) - 1
+			want = 1.35*dead.GlobalYDispl[last].Value + 1.5*live.GlobalYDispl[last].Value
+		)
+
+		assert.InDelta(t, want, got[last].Value, 1e-10)
+	})
+
+	t.Run("the bending moments are superposed", func(t *testing.T) {
+		var (
+			got  = combinations[0].Elements[0].BendingMoment
+			want = 1.35*dead.BendingMoment[0].Value + 1.5*live.BendingMoment[0].Value
+		)
+
+		assert.InDelta(t, want, got[0].Value, 1e-6)
+		assert.InDelta(t, 0.0, got[len(got)-1].Value, 1e-6)
+	})
+
+	t.Run("the reactions are superposed", func(t *testing.T) {
+		got := combinations[1].NodeReactions()["fixed-node"]
+
+		assert.InDelta(t, 0.8*2000-1.0*20*length, got.Fy(), 1e-6)
+	})
+
+	t.Run("the envelope has the governing combinations", func(t *testing.T) {
+		bending := envelopes[0].BendingMoment[0]
+
+		// At the fixed end: ULS1 = -(1.35·PL + 1.5·qL²/2) and ULS2 = -(0.8·PL - 1.0·qL²/2)
+		assert.Equal(t, 1, len(envelopes))
+		assert.True(t, bending.T.IsMin())
+		assert.InDelta(t, -60000.0, bending.Max, 1e-6)
+		assert.Equal(t, "ULS2", bending.MaxCombination)
+		assert.InDelta(t, -420000.0, bending.Min, 1e-6)
+		assert.Equal(t, "ULS1", bending.MinCombination)
+	})
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	gomath "math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplySupportedBeamDeflectedShape(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str  = makeContinuousBeam(1)
+		beam = str.GetElementById("span-1")
+		q    = -10.0
+		ei   = material.YoungMod * section.IStrong
+	)
+
+	beam.DistributedLoads = append(
+		beam.DistributedLoads,
+		load.MakeDistributed(load.FY, true, nums.MinT, q, nums.MaxT, q),
+	)
+
+	solution := solveStructureWithSolver(str, process.DirectSolver).Elements[0]
+
+	t.Run("the displacements between the nodes follow the closed form", func(t *testing.T) {
+		for _, tValue := range []float64{0.05, 0.33, 0.57, 0.86} {
+			var (
+				x         = tValue * length
+				wantDy    = q * x * (length*length*length - 2*length*x*x + x*x*x) / (24 * ei)
+				wantRz    = q * (length*length*length - 6*length*x*x + 4*x*x*x) / (24 * ei)
+				_, dy, rz = solution.LocalDisplacementsAt(nums.MakeTParam(tValue))
+			)
+
+			assert.InDelta(t, wantDy, dy, 1e-8)
+			assert.InDelta(t, wantRz, rz, 1e-10)
+		}
+	})
+
+	t.Run("the displacements at the nodes are the solution's", func(t *testing.T) {
+		for i, value := range solution.GlobalYDispl {
+			dx, dy, rz := solution.GlobalDisplacementsAt(value.T)
+
+			assert.InDelta(t, solution.GlobalXDispl[i].Value, dx, 1e-12)
+			assert.InDelta(t, value.Value, dy, 1e-12)
+			assert.InDelta(t, solution.GlobalZRot[i].Value, rz, 1e-12)
+		}
+	})
+
+	t.Run("the maximum deflection is at midspan", func(t *testing.T) {
+		maxDeflection := solution.MaxDeflection()
+
+		assert.InDelta(t, 0.5, maxDeflection.T.Value(), 1e-6)
+		assert.InDelta(t, 5*q*gomath.Pow(length, 4)/(384*ei), maxDeflection.Value, 1e-8)
+	})
+}
+
+func TestProppedCantileverMaxDeflectionBetweenNodes(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		q       = -10.0
+		ei      = material.YoungMod * section.IStrong
+		nodeOne = structure.MakeNode("fixed", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("roller", g2d.MakePoint(length, 0), &structure.DispYConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddDistributedLoads(
+			[]*load.DistributedLoad{load.MakeDistributed(load.FY, true, nums.MinT, q, nums.MaxT, q)},
+		).Build()
+		str = structure.Make(
+			structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+			map[contracts.StrID]*structure.Node{nodeOne.GetID(): nodeOne, nodeTwo.GetID(): nodeTwo},
+			[]*structure.Element{beam},
+		)
+		solution      = solveStructureWithSolver(str, process.DirectSolver).Elements[0]
+		maxDeflection = solution.MaxDeflection()
+
+		// v = qx²(3L² - 5Lx + 2x²)/(48EI), with x from the fixed end, is maximum at x = (15 - √33)L/16
+		wantT          = (15 - gomath.Sqrt(33)) / 16
+		x              = wantT * length
+		wantDeflection = q * x * x * (3*length*length - 5*length*x + 2*x*x) / (48 * ei)
+	)
+
+	assert.InDelta(t, wantT, maxDeflection.T.Value(), 1e-6)
+	assert.InDelta(t, wantDeflection, maxDeflection.Value, 1e-5*gomath.Abs(wantDeflection))
+
+	for _, value := range solution.LocalYDispl {
+		assert.Less(t, gomath.Abs(value.Value), gomath.Abs(maxDeflection.Value))
+	}
+}
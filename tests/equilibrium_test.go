@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverBeamEquilibrium(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		fyValue = -2000.0
+		qyValue = -50.0
+		str     = makeCantileverBeamStructure(
+			[]*load.ConcentratedLoad{load.MakeConcentrated(load.FY, true, nums.MakeTParam(0.3), fyValue)},
+			[]*load.DistributedLoad{load.MakeDistributed(load.FY, true, nums.MinT, qyValue, nums.MaxT, qyValue)},
+		)
+		check = solveStructureWithSolver(str, process.DirectSolver).Equilibrium
+	)
+
+	t.Run("the applied loads are the sum of the bar loads", func(t *testing.T) {
+		var (
+			wantFy = fyValue + qyValue*length
+			wantMz = fyValue*0.3*length + qyValue*length*length/2
+		)
+
+		assert.InDelta(t, 0.0, check.AppliedLoads.Fx(), 1e-10)
+		assert.InDelta(t, wantFy, check.AppliedLoads.Fy(), 1e-8)
+		assert.InDelta(t, wantMz, check.AppliedLoads.Mz(), 1e-6)
+	})
+
+	t.Run("the reactions balance the applied loads", func(t *testing.T) {
+		assert.InDelta(t, -check.AppliedLoads.Fy(), check.Reactions.Fy(), 1e-6)
+		assert.InDelta(t, -check.AppliedLoads.Mz(), check.Reactions.Mz(), 1e-4)
+		assert.Less(t, check.RelativeForceImbalance(), 1e-10)
+		assert.Less(t, check.RelativeMomentImbalance(), 1e-10)
+	})
+
+	t.Run("the residual and node imbalances are negligible", func(t *testing.T) {
+		assert.Less(t, check.RelativeResidual(), 1e-10)
+		assert.Less(t, check.RelativeMaxNodeImbalance(), 1e-10)
+		assert.Equal(t, process.DefaultEquilibriumTolerance, check.Tolerance)
+		assert.True(t, check.IsWithinTolerance())
+	})
+}
+
+func TestUnconvergedSolutionExceedsEquilibriumTolerance(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str = makeCantileverBeamStructure(
+			nil,
+			[]*load.DistributedLoad{load.MakeDistributed(load.FY, true, nums.MinT, -50.0, nums.MaxT, -50.0)},
+		)
+		// The PCG stops before the first iteration, with zero displacements
+		check = solveStructureWithOptions(str, process.SolveOptions{
+			MaxDisplacementsError: 1e12,
+			Solver:                process.PCGSolver,
+		}).Equilibrium
+	)
+
+	assert.InDelta(t, 1.0, check.RelativeResidual(), 1e-10)
+	assert.False(t, check.IsWithinTolerance())
+}
+
+func TestEquilibriumOfStructuresWithSupportsAndLinks(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		ei         = material.YoungMod * section.IStrong
+		structures = map[string]*structure.Structure{
+			"inclined roller":    makeInclinedRollerBeamStructure(30.0, -4000.0),
+			"spring support":     makeCantileverBeamWithSpringStructure(3.0*ei/(length*length*length), -4000.0),
+			"semi-rigid links":   makeFixedBeamWithSemiRigidLinksStructure(2.0*ei/length, -200.0),
+			"support settlement": makeFixedBeamWithEndSettlementStructure(-0.5),
+			"tension-only brace": makeXBracedFrame(1000.0, structure.TensionOnly, &structure.DispConstraint),
+			"thermal loads": makeThermalLoadsBeamStructure(&structure.FullConstraint, []*load.ThermalLoad{
+				load.MakeThermal(load.UniformTemperature, 30.0),
+				load.MakeThermal(load.TemperatureGradient, 20.0),
+			}),
+		}
+	)
+
+	for name, str := range structures {
+		t.Run(name, func(t *testing.T) {
+			check := solveStructureWithSolver(str, process.DirectSolver).Equilibrium
+
+			assert.True(
+				t,
+				check.IsWithinTolerance(),
+				"force %e, moment %e, residual %e, node %s %e",
+				check.RelativeForceImbalance(),
+				check.RelativeMomentImbalance(),
+				check.RelativeResidual(),
+				check.MaxNodeImbalanceID,
+				check.RelativeMaxNodeImbalance(),
+			)
+		})
+	}
+}
+
+// A statically determinate structure whose supports move doesn't strain: it has neither loads
+// nor reactions, and its forces are rounding errors.
+func TestEquilibriumOfStructuresMovingWithoutStrain(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		structures = map[string]func() *structure.Structure{
+			"settled cantilever": func() *structure.Structure {
+				return makeCantileverBeamWithSettledSupportStructure(-0.5, 0.01)
+			},
+			"cantilever rotated through a link": func() *structure.Structure {
+				return makeBeamWithPrescribedRotationThroughLinkStructure(0.01, 1e6, &structure.NilConstraint)
+			},
+		}
+		solvers = []process.SolverType{process.DirectSolver, process.PCGSolver}
+	)
+
+	for name, makeStructure := range structures {
+		for _, solver := range solvers {
+			t.Run(fmt.Sprintf("%s with the %s solver", name, solver), func(t *testing.T) {
+				check := solveStructureWithSolver(makeStructure(), solver).Equilibrium
+
+				assert.True(
+					t,
+					check.IsWithinTolerance(),
+					"force %e, moment %e, residual %e, node %s %e",
+					check.RelativeForceImbalance(),
+					check.RelativeMomentImbalance(),
+					check.RelativeResidual(),
+					check.MaxNodeImbalanceID,
+					check.RelativeMaxNodeImbalance(),
+				)
+			})
+		}
+	}
+}
+
+func makeCantileverBeamWithSettledSupportStructure(settlement, rotation float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode(
+			"settled-node",
+			g2d.MakePoint(0, 0),
+			structure.MakePrescribedConstraint(true, true, true, 0, settlement, rotation),
+		)
+		nodeTwo = structure.MakeNode("free-node", g2d.MakePoint(length, 0), &structure.NilConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
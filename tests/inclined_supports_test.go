@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplySupportedBeamWithInclinedRoller(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		angle     = 30.0
+		rads      = angle * math.Pi / 180.0
+		fyValue   = -4000.0
+		str       = makeInclinedRollerBeamStructure(angle, fyValue)
+		sol       = solveStructure(str)
+		reactions = sol.NodeReactions()
+		eps       = 1e-3
+	)
+
+	t.Run("pinned node reaction", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(reactions["pinned-node"].Fx(), -0.5*fyValue*math.Tan(rads), eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["pinned-node"].Fy(), -0.5*fyValue, eps))
+	})
+
+	t.Run("roller node global reaction", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(reactions["roller-node"].Fx(), 0.5*fyValue*math.Tan(rads), eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["roller-node"].Fy(), -0.5*fyValue, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["roller-node"].Mz(), 0.0, eps))
+	})
+
+	t.Run("roller node local reaction", func(t *testing.T) {
+		localReactions := sol.InclinedNodeReactions()
+
+		assert.Len(t, localReactions, 1)
+		assert.True(t, nums.FloatsEqualEps(localReactions["roller-node"].Fx(), 0.0, eps))
+		assert.True(t, nums.FloatsEqualEps(localReactions["roller-node"].Fy(), -0.5*fyValue/math.Cos(rads), eps))
+	})
+
+	t.Run("roller node moves along the slope", func(t *testing.T) {
+		var (
+			element = sol.Elements[0]
+			last    = len(element.GlobalXDispl) - 1
+			dx      = element.GlobalXDispl[last].Value
+			dy      = element.GlobalYDispl[last].Value
+		)
+
+		assert.True(t, nums.FloatsEqualEps(-dx*math.Sin(rads)+dy*math.Cos(rads), 0.0, displError))
+		assert.False(t, nums.FloatsEqualEps(dx, 0.0, displError))
+	})
+}
+
+func makeInclinedRollerBeamStructure(angle, fyValue float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("pinned-node", g2d.MakePoint(0, 0), &structure.DispConstraint)
+		nodeTwo = structure.MakeNode(
+			"roller-node",
+			g2d.MakePoint(length, 0),
+			structure.DispYConstraint.WithAngle(angle),
+		)
+		beam = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddConcentratedLoads(
+			[]*load.ConcentratedLoad{load.MakeConcentrated(load.FY, true, nums.HalfT, fyValue)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
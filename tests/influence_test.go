@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplySupportedBeamInfluenceLines(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str      = makeContinuousBeam(1)
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolveInfluenceLines(pre, process.InfluenceOptions{
+			Path: []contracts.StrID{"span-1"},
+			Quantities: []process.InfluenceQuantity{
+				{Kind: process.ReactionInfluence, NodeID: "n1", Component: 1},
+				{Kind: process.EndForceInfluence, ElementID: "span-1", Component: 1},
+				{Kind: process.MomentInfluence, ElementID: "span-1", T: nums.HalfT, Component: 2},
+			},
+		})
+	)
+
+	t.Run("the points are the sliced beam's nodes", func(t *testing.T) {
+		assert.Equal(t, pre.GetElementById("span-1").NodesCount(), len(solution.Points))
+		assert.Equal(t, 0.0, solution.Points[0].Position)
+		assert.InDelta(t, length, solution.Points[len(solution.Points)-1].Position, 1e-10)
+	})
+
+	t.Run("the reaction decreases linearly from one to zero", func(t *testing.T) {
+		for i, point := range solution.Points {
+			assert.InDelta(t, 1.0-point.Position/length, solution.Lines[0].Values[i], 1e-8)
+		}
+	})
+
+	t.Run("the shear at the start end is the reaction", func(t *testing.T) {
+		for i := range solution.Points {
+			if i == 0 {
+				continue
+			}
+
+			assert.InDelta(t, solution.Lines[0].Values[i], solution.Lines[1].Values[i], 1e-8)
+		}
+	})
+
+	t.Run("the midspan moment is triangular", func(t *testing.T) {
+		for i, point := range solution.Points {
+			var (
+				x        = point.Position
+				expected = 0.5 * x
+			)
+
+			if x > 0.5*length {
+				expected = 0.5 * (length - x)
+			}
+
+			assert.InDelta(t, expected, solution.Lines[2].Values[i], 1e-6)
+		}
+	})
+}
+
+func TestContinuousBeamMiddleReactionInfluenceLine(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str      = makeContinuousBeam(2)
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolveInfluenceLines(pre, process.InfluenceOptions{
+			Path: []contracts.StrID{"span-1", "span-2"},
+			Quantities: []process.InfluenceQuantity{
+				{Kind: process.ReactionInfluence, NodeID: "n2", Component: 1},
+			},
+		})
+		nodesCount = pre.GetElementById("span-1").NodesCount()
+	)
+
+	t.Run("the shared node is included once", func(t *testing.T) {
+		assert.Equal(t, 2*nodesCount-1, len(solution.Points))
+		assert.InDelta(t, 2*length, solution.Points[len(solution.Points)-1].Position, 1e-10)
+	})
+
+	t.Run("the ordinates are those of a two span beam", func(t *testing.T) {
+		for i, point := range solution.Points {
+			a := point.Position
+			if a > length {
+				a = 2*length - a
+			}
+
+			expected := 0.5 * a / length * (3.0 - a*a/(length*length))
+			assert.InDelta(t, expected, solution.Lines[0].Values[i], 1e-6)
+		}
+	})
+}
+
+func TestParseInfluenceQuantity(t *testing.T) {
+	t.Run("parses the quantities", func(t *testing.T) {
+		for spec, expected := range map[string]process.InfluenceQuantity{
+			"reaction:n1:fy":   {Kind: process.ReactionInfluence, NodeID: "n1", Component: 1},
+			"end:b1:end:mz":    {Kind: process.EndForceInfluence, ElementID: "b1", AtEnd: true, Component: 2},
+			"moment:b2:0.25":   {Kind: process.MomentInfluence, ElementID: "b2", T: nums.MakeTParam(0.25), Component: 2},
+			"end:b3:start:fx":  {Kind: process.EndForceInfluence, ElementID: "b3", Component: 0},
+			"reaction:n10:mz":  {Kind: process.ReactionInfluence, NodeID: "n10", Component: 2},
+			"moment:b1:1":      {Kind: process.MomentInfluence, ElementID: "b1", T: nums.MaxT, Component: 2},
+			"reaction:n2:fx":   {Kind: process.ReactionInfluence, NodeID: "n2", Component: 0},
+			"end:b4:start:fy":  {Kind: process.EndForceInfluence, ElementID: "b4", Component: 1},
+			"moment:b1:0.5000": {Kind: process.MomentInfluence, ElementID: "b1", T: nums.HalfT, Component: 2},
+		} {
+			quantity, err := process.ParseInfluenceQuantity(spec)
+
+			assert.Nil(t, err, spec)
+			assert.Equal(t, expected, quantity, spec)
+		}
+	})
+
+	t.Run("formats the quantity as its specification", func(t *testing.T) {
+		quantity, _ := process.ParseInfluenceQuantity("end:b1:end:mz")
+		assert.Equal(t, "end:b1:end:mz", quantity.String())
+	})
+
+	t.Run("fails with malformed quantities", func(t *testing.T) {
+		for _, spec := range []string{"reaction:n1", "reaction:n1:dx", "end:b1:middle:fx", "moment:b1:1.5", "shear:b1:0.5"} {
+			_, err := process.ParseInfluenceQuantity(spec)
+			assert.NotNil(t, err, spec)
+		}
+	})
+}
+
+// makeContinuousBeam creates a horizontal beam with the given number of spans, all of the same
+// length, pinned in its first node and supported in the Y direction in the rest.
+func makeContinuousBeam(spans int) *structure.Structure {
+	var (
+		nodes    = make(map[contracts.StrID]*structure.Node, spans+1)
+		elements = make([]*structure.Element, spans)
+		previous = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.DispConstraint)
+	)
+
+	nodes[previous.GetID()] = previous
+
+	for i := 1; i <= spans; i++ {
+		var (
+			id   = contracts.StrID(fmt.Sprintf("n%d", i+1))
+			node = structure.MakeNode(id, g2d.MakePoint(float64(i)*length, 0), &structure.DispYConstraint)
+		)
+
+		nodes[id] = node
+		elements[i-1] = structure.MakeElementBuilder(
+			contracts.StrID(fmt.Sprintf("span-%d", i)),
+		).WithStartNode(
+			previous, &structure.FullConstraint,
+		).WithEndNode(
+			node, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).Build()
+
+		previous = node
+	}
+
+	return structure.Make(structure.StrMetadata{MajorVersion: 1, MinorVersion: 0}, nodes, elements)
+}
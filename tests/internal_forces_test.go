@@ -0,0 +1,148 @@
+package tests
+
+import (
+	gomath "math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriangularLoadMaxBendingMomentBetweenNodes(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str  = makeContinuousBeam(1)
+		beam = str.GetElementById("span-1")
+	)
+
+	beam.DistributedLoads = append(
+		beam.DistributedLoads,
+		load.MakeDistributed(load.FY, true, nums.MinT, 0, nums.MaxT, -10),
+	)
+
+	var (
+		solution     = solveStructureWithSolver(str, process.DirectSolver).Elements[0]
+		minimum, max = solution.BendingMomentExtrema()
+
+		// The shear force is zero at L/√3, where M = wL²/(9√3)
+		wantT   = 1.0 / gomath.Sqrt(3.0)
+		wantMax = 10.0 * length * length / (9.0 * gomath.Sqrt(3.0))
+	)
+
+	t.Run("the maximum is where the shear force is zero", func(t *testing.T) {
+		assert.InDelta(t, wantT, max.T.Value(), 1e-6)
+		assert.InDelta(t, wantMax, max.Value, 1e-4)
+		assert.InDelta(t, 0.0, solution.ShearForceAt(max.T), 1e-4)
+	})
+
+	t.Run("the maximum is larger than the nodes' values", func(t *testing.T) {
+		for _, value := range solution.BendingMoment {
+			assert.Less(t, value.Value, max.Value)
+		}
+	})
+
+	t.Run("the minimum is at the supports", func(t *testing.T) {
+		assert.InDelta(t, 0.0, minimum.Value, 1e-4)
+	})
+
+	t.Run("the bending moment follows the closed form", func(t *testing.T) {
+		for _, tValue := range []float64{0.05, 0.33, 0.5, 0.71, 0.98} {
+			var (
+				x    = tValue * length
+				want = 10.0 * x * (length*length - x*x) / (6.0 * length)
+			)
+
+			assert.InDelta(t, want, solution.BendingMomentAt(nums.MakeTParam(tValue)), 1e-4)
+		}
+	})
+}
+
+func TestInternalForcesMatchTheNodesValues(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str = makeCantileverBeamStructure(
+			[]*load.ConcentratedLoad{
+				load.MakeConcentrated(load.FY, true, nums.MakeTParam(0.3), -500),
+				load.MakeConcentrated(load.MZ, true, nums.MakeTParam(0.6), 2000),
+				load.MakeConcentrated(load.FX, false, nums.MakeTParam(0.8), 300),
+			},
+			[]*load.DistributedLoad{
+				load.MakeDistributed(load.FY, true, nums.MakeTParam(0.2), -10, nums.MakeTParam(0.7), -30),
+				load.MakeDistributed(load.FX, true, nums.MinT, 5, nums.MaxT, 5),
+			},
+		)
+		solution = solveStructureWithSolver(str, process.DirectSolver).Elements[0]
+	)
+
+	// Where there are two values at the same position, the first one is before a discontinuity.
+	assertMatches := func(t *testing.T, values []process.PointSolutionValue, at func(t nums.TParam) float64) {
+		for i, value := range values {
+			tValue := value.T
+			if i+1 < len(values) && values[i+1].T.Equals(value.T) {
+				tValue = nums.MakeTParam(value.T.Value() - 1e-9)
+			}
+
+			assert.InDelta(t, value.Value, at(tValue), 1e-3, "at t = %f", value.T.Value())
+		}
+	}
+
+	t.Run("the axial stresses", func(t *testing.T) {
+		assertMatches(t, solution.AxialStress, solution.AxialStressAt)
+	})
+
+	t.Run("the shear forces", func(t *testing.T) {
+		assertMatches(t, solution.ShearForce, solution.ShearForceAt)
+	})
+
+	t.Run("the bending moments", func(t *testing.T) {
+		assertMatches(t, solution.BendingMoment, solution.BendingMomentAt)
+	})
+
+	t.Run("the extrema include the concentrated moment's sides", func(t *testing.T) {
+		minimum, _ := solution.BendingMomentExtrema()
+
+		assert.True(t, minimum.T.IsMin())
+		assert.InDelta(t, solution.BendingMoment[0].Value, minimum.Value, 1e-3)
+	})
+}
+
+func TestCombinationInternalForces(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str = makeCantileverBeamStructure(
+			[]*load.ConcentratedLoad{
+				load.MakeConcentrated(load.FY, true, nums.MakeTParam(0.45), -2000).InCase("dead"),
+			},
+			[]*load.DistributedLoad{
+				load.MakeDistributed(load.FY, true, nums.MinT, -20, nums.MaxT, 0).InCase("live"),
+			},
+		)
+		solutions = solveStructureLoadCases(str, process.SolveOptions{
+			MaxDisplacementsError: displError,
+			Solver:                process.DirectSolver,
+		})
+		combination = process.CombineSolutions(solutions, []*load.Combination{
+			load.MakeCombination(
+				"ULS",
+				load.CombinationTerm{Factor: 1.35, LoadCase: "dead"},
+				load.CombinationTerm{Factor: 1.5, LoadCase: "live"},
+			),
+		})[0].Elements[0]
+		dead, live = solutions[1].Elements[0], solutions[2].Elements[0]
+	)
+
+	for _, tValue := range []float64{0.1, 0.45, 0.57, 0.9} {
+		var (
+			at   = nums.MakeTParam(tValue)
+			want = 1.35*dead.BendingMomentAt(at) + 1.5*live.BendingMomentAt(at)
+		)
+
+		assert.InDelta(t, want, combination.BendingMomentAt(at), 1e-6)
+	}
+}
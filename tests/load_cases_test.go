@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverBeamLoadCases(t *testing.T) {
+	build.Info = &build.BuildInfo{MajorVersion: 3, MinorVersion: 2}
+
+	var (
+		deadLoad = load.MakeConcentrated(load.FY, true, nums.MaxT, -2000)
+		liveLoad = load.MakeDistributed(load.FY, true, nums.MinT, -20, nums.MaxT, -20).InCase("live")
+		str      = makeCantileverBeamStructure(
+			[]*load.ConcentratedLoad{deadLoad},
+			[]*load.DistributedLoad{liveLoad},
+		)
+		ei        = material.YoungMod * section.IStrong
+		solutions = solveStructureLoadCases(str, process.SolveOptions{
+			SafeChecks:            true,
+			MaxDisplacementsError: displError,
+			Solver:                process.DirectSolver,
+		})
+	)
+
+	t.Run("there is a solution per load case", func(t *testing.T) {
+		assert.Equal(t, 2, len(solutions))
+		assert.Equal(t, load.DefaultCase, solutions[0].LoadCase)
+		assert.Equal(t, "live", solutions[1].LoadCase)
+	})
+
+	t.Run("the default case includes only the concentrated load", func(t *testing.T) {
+		var (
+			yDispl   = solutions[0].Elements[0].GlobalYDispl
+			want     = -2000 * length * length * length / (3 * ei) // PL³ / 3EI
+			reaction = solutions[0].NodeReactions()["fixed-node"]
+		)
+
+		assert.InDelta(t, want, yDispl[len(yDispl)-1].Value, 1e-10)
+		assert.InDelta(t, 2000, reaction.Fy(), 1e-6)
+	})
+
+	t.Run("the live case includes only the distributed load", func(t *testing.T) {
+		var (
+			yDispl   = solutions[1].Elements[0].GlobalYDispl
+			want     = -20 * length * length * length * length / (8 * ei) // qL⁴ / 8EI
+			reaction = solutions[1].NodeReactions()["fixed-node"]
+		)
+
+		assert.InDelta(t, want, yDispl[len(yDispl)-1].Value, 1e-10)
+		assert.InDelta(t, 20*length, reaction.Fy(), 1e-6)
+	})
+}
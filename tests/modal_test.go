@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverColumnNaturalFrequencies(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		density     = 7.85e-3
+		str         = makeMassiveCantileverColumnStructure(density)
+		pre         = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		ei          = material.YoungMod * section.IStrong
+		massPerLen  = density * section.Area
+		firstOmega  = 1.875104 * 1.875104 * math.Sqrt(ei/(massPerLen*math.Pow(length, 4)))
+		secondOmega = 4.694091 * 4.694091 * math.Sqrt(ei/(massPerLen*math.Pow(length, 4)))
+	)
+
+	t.Run("consistent mass matrix", func(t *testing.T) {
+		solution := process.SolveModal(pre, process.ModalOptions{ModesCount: 2})
+
+		assert.Equal(t, 2, len(solution.Modes))
+		// The mass lumped at the fixed base doesn't vibrate
+		assert.Less(t, solution.TotalMassX, massPerLen*length)
+		assert.Greater(t, solution.TotalMassX, 0.85*massPerLen*length)
+
+		var (
+			first  = solution.Modes[0]
+			second = solution.Modes[1]
+		)
+
+		assert.True(
+			t,
+			nums.FloatsEqualEps(first.AngularFrequency, firstOmega, 1e-3*firstOmega),
+			"want %f, got %f", firstOmega, first.AngularFrequency,
+		)
+		assert.True(
+			t,
+			nums.FloatsEqualEps(second.AngularFrequency, secondOmega, 1e-2*secondOmega),
+			"want %f, got %f", secondOmega, second.AngularFrequency,
+		)
+		assert.True(t, nums.FloatsEqual(first.Frequency, first.AngularFrequency/(2.0*math.Pi)))
+		assert.True(t, nums.FloatsEqual(first.Period*first.Frequency, 1.0))
+	})
+
+	t.Run("lumped mass matrix", func(t *testing.T) {
+		var (
+			solution = process.SolveModal(pre, process.ModalOptions{ModesCount: 1, Lumped: true})
+			got      = solution.Modes[0].AngularFrequency
+		)
+
+		assert.True(t, nums.FloatsEqualEps(got, firstOmega, 2e-2*firstOmega), "want %f, got %f", firstOmega, got)
+	})
+
+	t.Run("gravity converts the density into mass", func(t *testing.T) {
+		var (
+			gravity  = 9.81
+			solution = process.SolveModal(pre, process.ModalOptions{ModesCount: 1, Gravity: gravity})
+			want     = firstOmega * math.Sqrt(gravity)
+			got      = solution.Modes[0].AngularFrequency
+		)
+
+		assert.True(t, nums.FloatsEqualEps(got, want, 1e-3*want), "want %f, got %f", want, got)
+	})
+
+	t.Run("modal participation mass ratios", func(t *testing.T) {
+		var (
+			solution = process.SolveModal(pre, process.ModalOptions{ModesCount: 2})
+			first    = solution.Modes[0]
+		)
+
+		// The first bending mode of a cantilever mobilizes about 61% of its mass, a bit more of the
+		// mass which isn't at the fixed base
+		assert.True(t, first.MassRatioX > 0.613 && first.MassRatioX < 0.7, "got %f", first.MassRatioX)
+		assert.True(t, nums.FloatsEqualEps(first.MassRatioY, 0.0, 1e-6), "got %f", first.MassRatioY)
+		assert.LessOrEqual(t, first.MassRatioX+solution.Modes[1].MassRatioX, 1.0)
+	})
+}
+
+func TestNodalMassOnMasslessCantilever(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		mass      = 2.5
+		str       = makeMassiveCantileverColumnStructure(0.0)
+		ei        = material.YoungMod * section.IStrong
+		stiffX    = 3.0 * ei / (length * length * length)
+		wantOmega = math.Sqrt(stiffX / mass)
+	)
+
+	str.SetNodalMasses([]*structure.NodalMass{{NodeID: "top", Mass: mass}})
+
+	var (
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolveModal(pre, process.ModalOptions{ModesCount: 1})
+		got      = solution.Modes[0]
+	)
+
+	assert.True(t, nums.FloatsEqualEps(got.AngularFrequency, wantOmega, 1e-4*wantOmega), "want %f, got %f", wantOmega, got.AngularFrequency)
+	assert.True(t, nums.FloatsEqualEps(got.MassRatioX, 1.0, 1e-6))
+	assert.True(t, nums.FloatsEqualEps(got.ParticipationFactorX, 1.0, 1e-6))
+}
+
+func makeMassiveCantileverColumnStructure(
+	density float64,
+	loads ...*load.ConcentratedLoad,
+) *structure.Structure {
+	var (
+		massiveMaterial = *material
+		nodeOne         = structure.MakeNode("base", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo         = structure.MakeNode("top", g2d.MakePoint(0, length), &structure.NilConstraint)
+	)
+
+	massiveMaterial.Density = density
+
+	column := structure.MakeElementBuilder(
+		"column",
+	).WithStartNode(
+		nodeOne, &structure.FullConstraint,
+	).WithEndNode(
+		nodeTwo, &structure.FullConstraint,
+	).WithMaterial(
+		&massiveMaterial,
+	).WithSection(
+		section,
+	).AddConcentratedLoads(
+		loads,
+	).Build()
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{column},
+	)
+}
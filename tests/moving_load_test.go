@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovingLoadOnSimplySupportedBeam(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		axleLoad  = 10.0
+		str       = makeContinuousBeam(1)
+		path      = []contracts.StrID{"span-1"}
+		train     = load.MakeLoadTrain([]float64{axleLoad}, []float64{})
+		positions = str.AddMovingLoadCases(path, train, 0.1*length)
+		pre       = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution  = process.SolveMovingLoad(pre, path, train, positions, directSolveOptions)
+	)
+
+	t.Run("a position every step", func(t *testing.T) {
+		assert.Equal(t, 11, len(solution.Positions))
+	})
+
+	t.Run("the largest reaction is with the axle over the support", func(t *testing.T) {
+		reaction := solution.Reactions[0]
+
+		assert.Equal(t, contracts.StrID("n1"), reaction.NodeID)
+		assert.InDelta(t, axleLoad, reaction.Max[1], 1e-6)
+		assert.Equal(t, "train_1", reaction.MaxCase[1])
+		assert.InDelta(t, 0.0, reaction.Min[1], 1e-6)
+		assert.Equal(t, "train_11", reaction.MinCase[1])
+	})
+
+	t.Run("the largest midspan moment is with the axle at midspan", func(t *testing.T) {
+		found := false
+
+		for _, value := range solution.Envelopes[0].BendingMoment {
+			if value.T.Equals(nums.HalfT) {
+				found = true
+				assert.InDelta(t, axleLoad*length/4, value.Max, 1e-6)
+				assert.Equal(t, "train_6", value.MaxCombination)
+				assert.InDelta(t, 0.0, value.Min, 1e-6)
+			}
+		}
+
+		assert.True(t, found)
+	})
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+// A cantilever with a moment at its free end bends into a circular arc of radius EI / M, whose
+// tip rotation is θ = M·L / EI.
+func TestCantileverWithEndMomentLargeRotation(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		ei       = material.YoungMod * section.IStrong
+		rotation = math.Pi / 2.0
+		moment   = rotation * ei / length
+		radius   = ei / moment
+		str      = makeCantileverWithEndMoment(moment)
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolveNonlinear(pre, process.NonlinearOptions{
+			SolveOptions: process.SolveOptions{
+				MaxDisplacementsError: displError,
+				Solver:                process.DirectSolver,
+			},
+			LoadSteps: 10,
+			MaxError:  1e-8,
+			PathNodes: []contracts.StrID{"tip"},
+		})
+		tip = solution.Path[len(solution.Path)-1].Displacements["tip"]
+	)
+
+	t.Run("load path", func(t *testing.T) {
+		assert.Equal(t, 10, len(solution.Path))
+		assert.True(t, nums.FloatsEqual(solution.Path[0].LoadFactor, 0.1))
+		assert.True(t, nums.FloatsEqual(solution.Path[9].LoadFactor, 1.0))
+	})
+
+	t.Run("tip rotation", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(tip[2], rotation, 1e-3), "want %f, got %f", rotation, tip[2])
+	})
+
+	t.Run("tip displacements follow the circular arc", func(t *testing.T) {
+		var (
+			wantDx = radius*math.Sin(rotation) - length
+			wantDy = radius * (1.0 - math.Cos(rotation))
+		)
+
+		assert.True(t, nums.FloatsEqualEps(tip[0], wantDx, 1e-2*length), "want %f, got %f", wantDx, tip[0])
+		assert.True(t, nums.FloatsEqualEps(tip[1], wantDy, 1e-2*length), "want %f, got %f", wantDy, tip[1])
+	})
+
+	t.Run("bending moment is constant", func(t *testing.T) {
+		for _, bending := range solution.Elements[0].BendingMoment {
+			assert.True(
+				t,
+				nums.FloatsEqualEps(math.Abs(bending.Value), moment, 1e-6*moment),
+				"want %f, got %f", moment, bending.Value,
+			)
+		}
+	})
+}
+
+func makeCantileverWithEndMoment(moment float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("base", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("tip", g2d.MakePoint(length, 0), &structure.NilConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddConcentratedLoads(
+			[]*load.ConcentratedLoad{load.MakeConcentrated(load.MZ, false, nums.MaxT, moment)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
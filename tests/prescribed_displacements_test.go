@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedBeamWithEndSettlement(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		settlement = -0.5
+		str        = makeFixedBeamWithEndSettlementStructure(settlement)
+		sol        = solveStructure(str)
+		reactions  = sol.NodeReactions()
+		ei         = material.YoungMod * section.IStrong
+		eps        = 1e-3
+	)
+
+	t.Run("settled node displacement", func(t *testing.T) {
+		yDispl := sol.Elements[0].GlobalYDispl
+
+		assert.True(t, nums.FloatsEqualEps(yDispl[0].Value, 0.0, displError))
+		assert.True(t, nums.FloatsEqualEps(yDispl[len(yDispl)-1].Value, settlement, displError))
+	})
+
+	t.Run("Fy reactions", func(t *testing.T) {
+		want := 12.0 * ei * settlement / (length * length * length)
+
+		assert.True(t, nums.FloatsEqualEps(reactions["fixed-node"].Fy(), -want, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["settled-node"].Fy(), want, eps))
+	})
+
+	t.Run("Mz reactions", func(t *testing.T) {
+		want := -6.0 * ei * settlement / (length * length)
+
+		assert.True(t, nums.FloatsEqualEps(reactions["fixed-node"].Mz(), want, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["settled-node"].Mz(), want, eps))
+	})
+}
+
+func makeFixedBeamWithEndSettlementStructure(settlement float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("fixed-node", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode(
+			"settled-node",
+			g2d.MakePoint(length, 0),
+			structure.MakePrescribedConstraint(true, true, true, 0, settlement, 0),
+		)
+		beam = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
+
+func TestPrescribedRotationThroughSemiRigidLink(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		ei        = material.YoungMod * section.IStrong
+		rotation  = 0.01
+		stiffness = 1e6
+	)
+
+	t.Run("the cantilever turns as a rigid body", func(t *testing.T) {
+		var (
+			str       = makeBeamWithPrescribedRotationThroughLinkStructure(rotation, stiffness, &structure.NilConstraint)
+			sol       = solveStructure(str)
+			rotations = sol.Elements[0].GlobalZRot
+			yDispl    = sol.Elements[0].GlobalYDispl
+		)
+
+		assert.True(t, nums.FloatsEqualEps(rotations[0].Value, rotation, displError))
+		assert.True(t, nums.FloatsEqualEps(rotations[len(rotations)-1].Value, rotation, displError))
+		assert.True(t, nums.FloatsEqualEps(yDispl[len(yDispl)-1].Value, rotation*length, displError))
+	})
+
+	t.Run("the link's spring transmits the rotation to the fixed beam", func(t *testing.T) {
+		var (
+			str       = makeBeamWithPrescribedRotationThroughLinkStructure(rotation, stiffness, &structure.FullConstraint)
+			sol       = solveStructure(str)
+			reactions = sol.NodeReactions()
+			// The spring and the beam, whose stiffness at its start is 4EI/L, work in series
+			beamStiffness = 4.0 * ei / length
+			wantRotation  = rotation * stiffness / (stiffness + beamStiffness)
+			wantMoment    = beamStiffness * wantRotation
+		)
+
+		assert.True(t, nums.FloatsEqualEps(sol.Elements[0].GlobalZRot[0].Value, wantRotation, displError))
+		assert.True(t, nums.FloatsEqualEps(reactions["rotated-node"].Mz(), wantMoment, 1e-2))
+	})
+}
+
+func makeBeamWithPrescribedRotationThroughLinkStructure(
+	rotation, linkStiffness float64,
+	endConstraint *structure.Constraint,
+) *structure.Structure {
+	var (
+		link    = structure.MakeConstraint(true, true, false).WithSprings(0, 0, linkStiffness)
+		nodeOne = structure.MakeNode(
+			"rotated-node",
+			g2d.MakePoint(0, 0),
+			structure.MakePrescribedConstraint(true, true, true, 0, 0, rotation),
+		)
+		nodeTwo = structure.MakeNode("end-node", g2d.MakePoint(length, 0), endConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, link,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	yieldingMaterial = structure.MakeMaterial("steel", 0, 20e6, 0, 1, 25000, 40000)
+	plasticMoment    = yieldingMaterial.YieldStrength * section.SStrong
+)
+
+func TestFixedBeamPlasticCollapse(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		left  = structure.MakeNode("left", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		right = structure.MakeNode("right", g2d.MakePoint(length, 0), &structure.FullConstraint)
+		beam  = structure.MakeElementBuilder("beam").
+			WithStartNode(left, &structure.FullConstraint).
+			WithEndNode(right, &structure.FullConstraint).
+			WithMaterial(yieldingMaterial).
+			WithSection(section).
+			AddDistributedLoads([]*load.DistributedLoad{
+				load.MakeDistributed(load.FY, true, nums.MinT, -1.0, nums.MaxT, -1.0),
+			}).
+			Build()
+		str = structure.Make(
+			structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+			map[contracts.StrID]*structure.Node{left.GetID(): left, right.GetID(): right},
+			[]*structure.Element{beam},
+		)
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolvePushover(pre, process.PushoverOptions{PathNodes: []contracts.StrID{"left"}})
+	)
+
+	t.Run("the collapse load is 16·Mp/L²", func(t *testing.T) {
+		want := 16.0 * plasticMoment / (length * length)
+		assert.InDelta(t, want, solution.CollapseFactor, 1e-6*want)
+	})
+
+	t.Run("the hinges form at the ends, then at midspan", func(t *testing.T) {
+		firstFactor := 12.0 * plasticMoment / (length * length)
+
+		assert.Equal(t, 3, len(solution.Hinges))
+		assert.ElementsMatch(
+			t,
+			[]float64{0.0, 1.0},
+			[]float64{solution.Hinges[0].T.Value(), solution.Hinges[1].T.Value()},
+		)
+		assert.InDelta(t, firstFactor, solution.Hinges[0].LoadFactor, 1e-6*firstFactor)
+		assert.InDelta(t, firstFactor, solution.Hinges[1].LoadFactor, 1e-6*firstFactor)
+		assert.InDelta(t, 0.5, solution.Hinges[2].T.Value(), 1e-10)
+	})
+
+	t.Run("the hinges' moment is the plastic moment", func(t *testing.T) {
+		for _, hinge := range solution.Hinges {
+			assert.InDelta(t, plasticMoment, math.Abs(hinge.Moment), 1e-6*plasticMoment)
+		}
+
+		// The midspan bends the opposite way than the ends
+		assert.Less(t, solution.Hinges[0].Moment*solution.Hinges[2].Moment, 0.0)
+	})
+
+	t.Run("the fixed nodes don't move", func(t *testing.T) {
+		assert.Equal(t, [3]float64{}, solution.Hinges[2].Displacements["left"])
+	})
+}
+
+func TestProppedCantileverPlasticCollapse(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		fixed   = structure.MakeNode("fixed", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		propped = structure.MakeNode("propped", g2d.MakePoint(length, 0), &structure.DispConstraint)
+		beam    = structure.MakeElementBuilder("beam").
+			WithStartNode(fixed, &structure.FullConstraint).
+			WithEndNode(propped, &structure.FullConstraint).
+			WithMaterial(yieldingMaterial).
+			WithSection(section).
+			AddConcentratedLoads([]*load.ConcentratedLoad{
+				load.MakeConcentrated(load.FY, true, nums.MakeTParam(0.5), -1.0),
+			}).
+			Build()
+		str = structure.Make(
+			structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+			map[contracts.StrID]*structure.Node{fixed.GetID(): fixed, propped.GetID(): propped},
+			[]*structure.Element{beam},
+		)
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolvePushover(pre, process.PushoverOptions{})
+	)
+
+	assert.Equal(t, 2, len(solution.Hinges))
+
+	t.Run("the first hinge forms at the fixed end", func(t *testing.T) {
+		want := 16.0 * plasticMoment / (3.0 * length)
+
+		assert.Equal(t, contracts.StrID("beam"), solution.Hinges[0].ElementID)
+		assert.InDelta(t, 0.0, solution.Hinges[0].T.Value(), 1e-10)
+		assert.InDelta(t, want, solution.Hinges[0].LoadFactor, 1e-6*want)
+	})
+
+	t.Run("the collapse load is 6·Mp/L", func(t *testing.T) {
+		want := 6.0 * plasticMoment / length
+
+		assert.InDelta(t, 0.5, solution.Hinges[1].T.Value(), 1e-10)
+		assert.InDelta(t, want, solution.CollapseFactor, 1e-6*want)
+	})
+
+	t.Run("a shape factor scales the collapse load", func(t *testing.T) {
+		var (
+			shaped = process.SolvePushover(pre, process.PushoverOptions{ShapeFactor: 1.5})
+			want   = 1.5 * solution.CollapseFactor
+		)
+
+		assert.InDelta(t, want, shaped.CollapseFactor, 1e-6*want)
+	})
+}
+
+func TestPortalFrameSwayMechanism(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		height    = 100.0
+		leftBase  = structure.MakeNode("left-base", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		leftTop   = structure.MakeNode("left-top", g2d.MakePoint(0, height), &structure.NilConstraint)
+		rightTop  = structure.MakeNode("right-top", g2d.MakePoint(length, height), &structure.NilConstraint)
+		rightBase = structure.MakeNode("right-base", g2d.MakePoint(length, 0), &structure.FullConstraint)
+
+		leftColumn = structure.MakeElementBuilder("left-column").
+				WithStartNode(leftBase, &structure.FullConstraint).
+				WithEndNode(leftTop, &structure.FullConstraint).
+				WithMaterial(yieldingMaterial).
+				WithSection(section).
+				AddConcentratedLoads([]*load.ConcentratedLoad{
+				load.MakeConcentrated(load.FX, false, nums.MaxT, 1.0),
+			}).
+			Build()
+		beam = structure.MakeElementBuilder("beam").
+			WithStartNode(leftTop, &structure.FullConstraint).
+			WithEndNode(rightTop, &structure.FullConstraint).
+			WithMaterial(yieldingMaterial).
+			WithSection(section).
+			Build()
+		rightColumn = structure.MakeElementBuilder("right-column").
+				WithStartNode(rightBase, &structure.FullConstraint).
+				WithEndNode(rightTop, &structure.FullConstraint).
+				WithMaterial(yieldingMaterial).
+				WithSection(section).
+				Build()
+
+		str = structure.Make(
+			structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+			map[contracts.StrID]*structure.Node{
+				leftBase.GetID():  leftBase,
+				leftTop.GetID():   leftTop,
+				rightTop.GetID():  rightTop,
+				rightBase.GetID(): rightBase,
+			},
+			[]*structure.Element{leftColumn, beam, rightColumn},
+		)
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolvePushover(pre, process.PushoverOptions{
+			PathNodes: []contracts.StrID{"left-top"},
+		})
+		want = 4.0 * plasticMoment / height
+	)
+
+	assert.Equal(t, 4, len(solution.Hinges))
+	assert.InDelta(t, want, solution.CollapseFactor, 1e-6*want)
+
+	t.Run("the frame sways as the hinges form", func(t *testing.T) {
+		var previous float64
+		for _, hinge := range solution.Hinges {
+			sway := hinge.Displacements["left-top"][0]
+			assert.GreaterOrEqual(t, sway, previous)
+			previous = sway
+		}
+	})
+}
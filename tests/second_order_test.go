@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverColumnSecondOrder(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		axialForce = -50000.0
+		horizForce = 100.0
+		str        = makeSwayColumnStructure(axialForce, horizForce)
+		options    = process.SolveOptions{
+			MaxDisplacementsError: displError,
+			Solver:                process.DirectSolver,
+		}
+		firstOrder = process.Solve(preprocess.StructureModel(str, &preprocess.PreprocessOptions{}), options)[0]
+	)
+
+	options.SecondOrder = true
+	options.SecondOrderMaxError = 1e-10
+
+	var (
+		secondOrder = process.Solve(preprocess.StructureModel(str, &preprocess.PreprocessOptions{}), options)[0]
+		kL          = length * math.Sqrt(math.Abs(axialForce)/(material.YoungMod*section.IStrong))
+	)
+
+	t.Run("base moment is amplified", func(t *testing.T) {
+		var (
+			want = math.Abs(horizForce) * length * math.Tan(kL) / kL
+			got  = math.Abs(secondOrder.Elements[0].BendingMoment[0].Value)
+		)
+
+		assert.True(t, nums.FloatsEqualEps(got, want, 1e-3*want), "want %f, got %f", want, got)
+	})
+
+	t.Run("moment amplification factor", func(t *testing.T) {
+		var (
+			amplifications = process.MomentAmplifications(firstOrder, secondOrder)
+			want           = math.Tan(kL) / kL
+			got            = amplifications[0].Factor()
+		)
+
+		assert.Equal(t, 1, len(amplifications))
+		assert.Equal(t, contracts.StrID("column"), amplifications[0].ElementID)
+		assert.True(t, nums.FloatsEqualEps(got, want, 1e-3*want), "want %f, got %f", want, got)
+	})
+}
+
+func makeSwayColumnStructure(axialForce, horizForce float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("base", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("top", g2d.MakePoint(0, length), &structure.NilConstraint)
+		column  = structure.MakeElementBuilder(
+			"column",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddConcentratedLoads(
+			[]*load.ConcentratedLoad{
+				load.MakeConcentrated(load.FY, false, nums.MaxT, axialForce),
+				load.MakeConcentrated(load.FX, false, nums.MaxT, horizForce),
+			},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{column},
+	)
+}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedBeamWithSemiRigidLinks(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		ei        = material.YoungMod * section.IStrong
+		stiffness = 2.0 * ei / length
+		qyValue   = -200.0
+		str       = makeFixedBeamWithSemiRigidLinksStructure(stiffness, qyValue)
+		sol       = solveStructure(str)
+		reactions = sol.NodeReactions()
+		eps       = 1e-2
+	)
+
+	// The end moments of a fixed beam with semi-rigid links are the fixed end moments, qL²/12,
+	// reduced by the factor 1 / (1 + 2EI / kL).
+	var (
+		fixedEndMoment = -qyValue * length * length / 12.0
+		endMoment      = fixedEndMoment / (1.0 + 2.0*ei/(stiffness*length))
+	)
+
+	t.Run("Fy reactions", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(reactions["n1"].Fy(), -0.5*qyValue*length, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["n2"].Fy(), -0.5*qyValue*length, eps))
+	})
+
+	t.Run("Mz reactions", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(reactions["n1"].Mz(), endMoment, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["n2"].Mz(), -endMoment, eps))
+	})
+
+	t.Run("element end rotations differ from the nodes'", func(t *testing.T) {
+		var (
+			rotations = sol.Elements[0].GlobalZRot
+			want      = endMoment / stiffness
+		)
+
+		assert.True(t, nums.FloatsEqualEps(rotations[0].Value, -want, displError))
+		assert.True(t, nums.FloatsEqualEps(rotations[len(rotations)-1].Value, want, displError))
+	})
+}
+
+func makeFixedBeamWithSemiRigidLinksStructure(stiffness, qyValue float64) *structure.Structure {
+	var (
+		link    = structure.MakeConstraint(true, true, false).WithSprings(0, 0, stiffness)
+		nodeOne = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("n2", g2d.MakePoint(length, 0), &structure.FullConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, link,
+		).WithEndNode(
+			nodeTwo, link,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddDistributedLoads(
+			[]*load.DistributedLoad{load.MakeDistributed(load.FY, true, nums.MinT, qyValue, nums.MaxT, qyValue)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
+
+// The semi-rigid link and the rotational spring of the support act in series between the bar and
+// the ground. The beam's far end is fixed.
+func TestBeamWithSemiRigidLinkOnRotationalSpringSupport(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		ei              = material.YoungMod * section.IStrong
+		linkStiffness   = 2.0 * ei / length
+		springStiffness = 2.0 * ei / length
+		qyValue         = -200.0
+		str             = makeBeamWithSemiRigidLinkOnSpringSupportStructure(linkStiffness, springStiffness, qyValue)
+		sol             = solveStructure(str)
+		reactions       = sol.NodeReactions()
+		eps             = 1e-2
+	)
+
+	// The fixed end moment, qL²/12, is shared between the bar's end, 4EI / L, and the springs in
+	// series.
+	var (
+		stiffness = linkStiffness * springStiffness / (linkStiffness + springStiffness)
+		endMoment = -qyValue * length * length / 12.0 * stiffness / (stiffness + 4.0*ei/length)
+	)
+
+	t.Run("the spring's reaction is the moment through the link", func(t *testing.T) {
+		assert.InDelta(t, endMoment, reactions["spring-node"].Mz(), eps)
+	})
+
+	t.Run("the nodes are in equilibrium", func(t *testing.T) {
+		assert.InDelta(t, 0.0, sol.Equilibrium.MaxNodeImbalance.Mz(), eps)
+		assert.True(t, sol.Equilibrium.IsWithinTolerance())
+	})
+}
+
+func makeBeamWithSemiRigidLinkOnSpringSupportStructure(
+	linkStiffness, springStiffness, qyValue float64,
+) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode(
+			"spring-node",
+			g2d.MakePoint(0, 0),
+			structure.MakeConstraint(true, true, false).WithSprings(0, 0, springStiffness),
+		)
+		nodeTwo = structure.MakeNode("fixed-node", g2d.MakePoint(length, 0), &structure.FullConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, structure.MakeConstraint(true, true, false).WithSprings(0, 0, linkStiffness),
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddDistributedLoads(
+			[]*load.DistributedLoad{load.MakeDistributed(load.FY, true, nums.MinT, qyValue, nums.MaxT, qyValue)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
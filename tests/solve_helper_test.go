@@ -35,12 +35,26 @@ var (
 )
 
 func solveStructure(str *structure.Structure) *process.Solution {
+	return solveStructureWithSolver(str, process.PCGSolver)
+}
+
+func solveStructureWithSolver(str *structure.Structure, solver process.SolverType) *process.Solution {
+	return solveStructureWithOptions(str, process.SolveOptions{
+		OutputPath:            "",
+		SafeChecks:            true,
+		MaxDisplacementsError: displError,
+		Solver:                solver,
+	})
+}
+
+func solveStructureWithOptions(str *structure.Structure, solveOptions process.SolveOptions) *process.Solution {
+	return solveStructureLoadCases(str, solveOptions)[0]
+}
+
+// solveStructureLoadCases solves the structure for all of its load cases, returning the
+// solutions with the default case first.
+func solveStructureLoadCases(str *structure.Structure, solveOptions process.SolveOptions) []*process.Solution {
 	var (
-		solveOptions = process.SolveOptions{
-			OutputPath:            "",
-			SafeChecks:            true,
-			MaxDisplacementsError: displError,
-		}
 		preOptions = &preprocess.PreprocessOptions{
 			IncludeOwnWeight: false,
 		}
@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectSolverCantileverBeam(t *testing.T) {
+	build.Info = &build.BuildInfo{MajorVersion: 3, MinorVersion: 2}
+
+	var (
+		l               = load.MakeConcentrated(load.FY, true, nums.MaxT, -2000)
+		str             = makeCantileverBeamStructure([]*load.ConcentratedLoad{l}, noDistLoads)
+		sol             = solveStructureWithSolver(str, process.DirectSolver)
+		solutionElement = sol.Elements[0]
+		maxYDispl       = -200.0 / 1908.0 // PL³ / 3EI
+		maxZRot         = -1.0 / 636.0    // -PL² / 2EI
+		nOfValues       = len(solutionElement.GlobalYDispl)
+	)
+
+	t.Run("global Y displacement at the free end", func(t *testing.T) {
+		if got := solutionElement.GlobalYDispl[nOfValues-1].Value; !nums.FloatsEqual(got, maxYDispl) {
+			t.Errorf("expected max Y displacement of %f, but got %f", maxYDispl, got)
+		}
+	})
+
+	t.Run("global Z rotation at the free end", func(t *testing.T) {
+		if got := solutionElement.GlobalZRot[nOfValues-1].Value; !nums.FloatsEqual(got, maxZRot) {
+			t.Errorf("expected max Z rotation of %f, but got %f", maxZRot, got)
+		}
+	})
+}
+
+func TestPreconditionersCantileverBeam(t *testing.T) {
+	build.Info = &build.BuildInfo{MajorVersion: 3, MinorVersion: 2}
+
+	var (
+		l         = load.MakeConcentrated(load.FY, true, nums.MaxT, -2000)
+		str       = makeCantileverBeamStructure([]*load.ConcentratedLoad{l}, noDistLoads)
+		maxYDispl = -200.0 / 1908.0 // PL³ / 3EI
+	)
+
+	preconditioners := []process.PreconditionerType{
+		process.JacobiPreconditioner,
+		process.IncompleteCholeskyPreconditioner,
+		process.SSORPreconditioner,
+	}
+
+	for _, preconditioner := range preconditioners {
+		t.Run(string(preconditioner), func(t *testing.T) {
+			var (
+				sol = solveStructureWithOptions(str, process.SolveOptions{
+					SafeChecks:            true,
+					MaxDisplacementsError: displError,
+					Preconditioner:        preconditioner,
+				})
+				yDispl = sol.Elements[0].GlobalYDispl
+			)
+
+			if got := yDispl[len(yDispl)-1].Value; !nums.FloatsEqualEps(got, maxYDispl, displError) {
+				t.Errorf("expected max Y displacement of %f, but got %f", maxYDispl, got)
+			}
+		})
+	}
+}
+
+// All the bars of the truss are pinned, so nothing resists the rotation of its nodes.
+func TestSolversPinnedTruss(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		fyValue = -1000.0
+		solvers = []process.SolverType{process.DirectSolver, process.PCGSolver}
+		// The inclined bars are at 45°
+		inclinedStress = 0.5 * fyValue / math.Sin(math.Pi/4) / section.Area
+		bottomStress   = -0.5 * fyValue / section.Area
+	)
+
+	for _, solver := range solvers {
+		t.Run(string(solver), func(t *testing.T) {
+			var (
+				sol       = solveStructureWithSolver(makePinnedTrussStructure(fyValue), solver)
+				reactions = sol.NodeReactions()
+			)
+
+			assert.InDelta(t, -0.5*fyValue, reactions["left"].Fy(), 1e-6)
+			assert.InDelta(t, -0.5*fyValue, reactions["right"].Fy(), 1e-6)
+
+			for _, element := range sol.Elements {
+				want := inclinedStress
+				if element.GetID() == "bottom" {
+					want = bottomStress
+				}
+
+				for _, axial := range element.AxialStress {
+					assert.InDelta(t, want, axial.Value, 1e-6, "bar %s", element.GetID())
+				}
+			}
+
+			assert.True(t, sol.Equilibrium.IsWithinTolerance())
+		})
+	}
+}
+
+func makePinnedTrussStructure(fyValue float64) *structure.Structure {
+	var (
+		leftNode  = structure.MakeNode("left", g2d.MakePoint(0, 0), &structure.DispConstraint)
+		rightNode = structure.MakeNode("right", g2d.MakePoint(2*length, 0), &structure.DispYConstraint)
+		topNode   = structure.MakeNode("top", g2d.MakePoint(length, length), &structure.NilConstraint)
+		makeBar   = func(
+			id contracts.StrID,
+			startNode, endNode *structure.Node,
+			loads ...*load.ConcentratedLoad,
+		) *structure.Element {
+			return structure.MakeElementBuilder(
+				id,
+			).WithStartNode(
+				startNode, &structure.DispConstraint,
+			).WithEndNode(
+				endNode, &structure.DispConstraint,
+			).WithMaterial(
+				material,
+			).WithSection(
+				section,
+			).AddConcentratedLoads(
+				loads,
+			).Build()
+		}
+		leftBar   = makeBar("left", leftNode, topNode, load.MakeConcentrated(load.FY, false, nums.MaxT, fyValue))
+		rightBar  = makeBar("right", topNode, rightNode)
+		bottomBar = makeBar("bottom", leftNode, rightNode)
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			leftNode.GetID():  leftNode,
+			rightNode.GetID(): rightNode,
+			topNode.GetID():   topNode,
+		},
+		[]*structure.Element{leftBar, rightBar, bottomBar},
+	)
+}
@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverOscillatorResponseSpectrum(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		mass      = 2.5
+		accel     = 300.0
+		stiffness = 3.0 * material.YoungMod * section.IStrong / (length * length * length)
+		str       = makeMassiveCantileverColumnStructure(0.0)
+	)
+
+	str.SetNodalMasses([]*structure.NodalMass{{NodeID: "top", Mass: mass}})
+
+	var (
+		pre      = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		solution = process.SolveSpectrum(pre, process.SpectrumOptions{
+			ModalOptions: process.ModalOptions{ModesCount: 2},
+			Spectrum:     load.MakeResponseSpectrum([]float64{0.0, 10.0}, []float64{1.0, 1.0}),
+			Direction:    process.SpectrumX,
+			// The spectrum is given in fractions of the acceleration
+			AccelerationScale: accel,
+		})
+		first = solution.Modes[0]
+	)
+
+	t.Run("first mode peak displacement", func(t *testing.T) {
+		assert.InDelta(t, accel, first.SpectralAcceleration, 1e-10)
+		assert.InDelta(t, mass*accel/stiffness, first.DisplacementFactor, 1e-4*mass*accel/stiffness)
+		assert.InDelta(t, 1.0, solution.MassRatio(), 1e-6)
+	})
+
+	for _, combination := range solution.Combinations {
+		t.Run(string(combination.Rule)+" base reactions", func(t *testing.T) {
+			var (
+				reaction = combination.Reactions["base"]
+				shear    = mass * accel
+			)
+
+			assert.InDelta(t, shear, reaction[0], 1e-4*shear)
+			assert.InDelta(t, 0.0, reaction[1], 1e-6*shear)
+			assert.InDelta(t, shear*length, reaction[2], 1e-4*shear*length)
+		})
+
+		t.Run(string(combination.Rule)+" bending moment envelope", func(t *testing.T) {
+			var (
+				envelope = combination.Envelopes[0].BendingMoment
+				base     = envelope[0]
+				top      = envelope[len(envelope)-1]
+				moment   = mass * accel * length
+			)
+
+			assert.InDelta(t, moment, base.Max, 1e-4*moment)
+			assert.InDelta(t, -moment, base.Min, 1e-4*moment)
+			assert.InDelta(t, 0.0, top.Max, 1e-4*moment)
+		})
+	}
+}
+
+func TestCQCCorrelation(t *testing.T) {
+	t.Run("a mode is fully correlated with itself", func(t *testing.T) {
+		assert.InDelta(t, 1.0, process.CQCCorrelation(0.05, 10.0, 10.0), 1e-12)
+	})
+
+	t.Run("modes with close frequencies are correlated", func(t *testing.T) {
+		assert.InDelta(t, 0.8, process.CQCCorrelation(0.05, 10.0, 10.5), 0.05)
+	})
+
+	t.Run("modes with separated frequencies aren't correlated", func(t *testing.T) {
+		assert.Less(t, process.CQCCorrelation(0.05, 10.0, 30.0), 0.01)
+	})
+
+	t.Run("it's symmetric", func(t *testing.T) {
+		assert.InDelta(
+			t,
+			process.CQCCorrelation(0.05, 10.0, 12.0),
+			process.CQCCorrelation(0.05, 12.0, 10.0),
+			1e-12,
+		)
+	})
+}
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCantileverBeamWithSpringAtEnd(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		ei        = material.YoungMod * section.IStrong
+		stiffness = 3.0 * ei / (length * length * length)
+		fyValue   = -4000.0
+		str       = makeCantileverBeamWithSpringStructure(stiffness, fyValue)
+		sol       = solveStructure(str)
+		reactions = sol.NodeReactions()
+		eps       = 1e-3
+	)
+
+	t.Run("spring node displacement", func(t *testing.T) {
+		var (
+			want   = fyValue / (stiffness + 3.0*ei/(length*length*length))
+			yDispl = sol.Elements[0].GlobalYDispl
+		)
+
+		assert.True(t, nums.FloatsEqualEps(yDispl[len(yDispl)-1].Value, want, displError))
+	})
+
+	t.Run("spring reaction", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(reactions["spring-node"].Fx(), 0.0, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["spring-node"].Fy(), -0.5*fyValue, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["spring-node"].Mz(), 0.0, eps))
+	})
+
+	t.Run("fixed node reaction", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(reactions["fixed-node"].Fy(), -0.5*fyValue, eps))
+		assert.True(t, nums.FloatsEqualEps(reactions["fixed-node"].Mz(), -0.5*fyValue*length, eps))
+	})
+}
+
+func makeCantileverBeamWithSpringStructure(stiffness, fyValue float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("fixed-node", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode(
+			"spring-node",
+			g2d.MakePoint(length, 0),
+			structure.NilConstraint.WithSprings(0, stiffness, 0),
+		)
+		beam = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddConcentratedLoads(
+			[]*load.ConcentratedLoad{load.MakeConcentrated(load.FY, true, nums.MaxT, fyValue)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
+
+// The pinned link releases the bar's rotation from the node's, hence the rotational spring of the
+// support has nothing to resist.
+func TestPinnedBarOnRotationalSpringSupport(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		qyValue   = -20.0
+		str       = makePinnedBarOnRotationalSpringStructure(1e6, qyValue)
+		sol       = solveStructure(str)
+		reactions = sol.NodeReactions()
+		eps       = 1e-3
+	)
+
+	t.Run("spring node reaction", func(t *testing.T) {
+		assert.InDelta(t, 0.0, reactions["spring-node"].Fx(), eps)
+		assert.InDelta(t, -0.5*qyValue*length, reactions["spring-node"].Fy(), eps)
+		assert.InDelta(t, 0.0, reactions["spring-node"].Mz(), eps)
+	})
+
+	t.Run("the nodes are in equilibrium", func(t *testing.T) {
+		imbalance := sol.Equilibrium.MaxNodeImbalance
+
+		assert.InDelta(t, 0.0, imbalance.Fx(), eps)
+		assert.InDelta(t, 0.0, imbalance.Fy(), eps)
+		assert.InDelta(t, 0.0, imbalance.Mz(), eps)
+		assert.True(t, sol.Equilibrium.IsWithinTolerance())
+	})
+}
+
+func makePinnedBarOnRotationalSpringStructure(stiffness, qyValue float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode(
+			"spring-node",
+			g2d.MakePoint(0, 0),
+			structure.MakeConstraint(true, true, false).WithSprings(0, 0, stiffness),
+		)
+		nodeTwo = structure.MakeNode("pinned-node", g2d.MakePoint(length, 0), &structure.DispConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.DispConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			material,
+		).WithSection(
+			section,
+		).AddDistributedLoads(
+			[]*load.DistributedLoad{load.MakeDistributed(load.FY, true, nums.MinT, qyValue, nums.MaxT, qyValue)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
@@ -0,0 +1,158 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+var directSolveOptions = process.SolveOptions{
+	SafeChecks:            true,
+	MaxDisplacementsError: displError,
+	Solver:                process.DirectSolver,
+}
+
+func TestTensionOnlyBracing(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		force    = 1000.0
+		str      = makeXBracedFrame(force, structure.TensionOnly, &structure.DispConstraint)
+		solution = solveStructureWithOptions(str, directSolveOptions)
+	)
+
+	t.Run("the compressed brace is inactive", func(t *testing.T) {
+		assert.Equal(t, []contracts.StrID{"brace-2"}, solution.InactiveElements)
+	})
+
+	t.Run("the inactive brace has no stresses", func(t *testing.T) {
+		brace := elementSolutionById(solution, "brace-2")
+
+		for _, values := range [][]process.PointSolutionValue{brace.AxialStress, brace.ShearForce, brace.BendingMoment} {
+			for _, value := range values {
+				assert.Equal(t, 0.0, value.Value)
+			}
+		}
+	})
+
+	t.Run("the stretched brace is in tension", func(t *testing.T) {
+		brace := elementSolutionById(solution, "brace-1")
+
+		for _, axial := range brace.AxialStress {
+			assert.Greater(t, axial.Value, 0.0)
+		}
+	})
+
+	t.Run("the reactions balance the lateral load", func(t *testing.T) {
+		reactions := solution.NodeReactions()
+		assert.InDelta(t, -force, reactions["n1"].Fx()+reactions["n2"].Fx(), 1e-6*force)
+		assert.InDelta(t, 0.0, reactions["n1"].Fy()+reactions["n2"].Fy(), 1e-6*force)
+	})
+}
+
+func TestCompressionOnlyBracing(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str      = makeXBracedFrame(1000.0, structure.CompressionOnly, &structure.DispConstraint)
+		solution = solveStructureWithOptions(str, directSolveOptions)
+	)
+
+	assert.Equal(t, []contracts.StrID{"brace-1"}, solution.InactiveElements)
+
+	for _, axial := range elementSolutionById(solution, "brace-2").AxialStress {
+		assert.Less(t, axial.Value, 0.0)
+	}
+}
+
+func TestBracingWithoutAxialBehaviorLimits(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		str      = makeXBracedFrame(1000.0, structure.TensionAndCompression, &structure.DispConstraint)
+		solution = solveStructureWithOptions(str, directSolveOptions)
+	)
+
+	assert.Empty(t, solution.InactiveElements)
+	assert.Greater(t, elementSolutionById(solution, "brace-1").AxialStress[0].Value, 0.0)
+	assert.Less(t, elementSolutionById(solution, "brace-2").AxialStress[0].Value, 0.0)
+}
+
+func TestTensionOnlyElementMustBeAxialMember(t *testing.T) {
+	build.ReadBuildInfo()
+
+	str := makeXBracedFrame(1000.0, structure.TensionOnly, &structure.FullConstraint)
+
+	assert.Panics(t, func() {
+		solveStructureWithOptions(str, directSolveOptions)
+	})
+}
+
+// makeXBracedFrame creates a square frame of side length, pinned to the ground, with two
+// crossing braces with the given axial behavior, linked to the nodes with the given constraint.
+// The lateral force, applied at the top left node, stretches the brace from the bottom left to
+// the top right node, "brace-1", and compresses the other one, "brace-2".
+func makeXBracedFrame(
+	force float64,
+	behavior structure.AxialBehavior,
+	braceLink *structure.Constraint,
+) *structure.Structure {
+	var (
+		n1 = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.DispConstraint)
+		n2 = structure.MakeNode("n2", g2d.MakePoint(length, 0), &structure.DispConstraint)
+		n3 = structure.MakeNode("n3", g2d.MakePoint(0, length), &structure.NilConstraint)
+		n4 = structure.MakeNode("n4", g2d.MakePoint(length, length), &structure.NilConstraint)
+
+		makeBar = func(id contracts.StrID, start, end *structure.Node) *structure.ElementBuilder {
+			return structure.MakeElementBuilder(id).
+				WithStartNode(start, &structure.FullConstraint).
+				WithEndNode(end, &structure.FullConstraint).
+				WithMaterial(material).
+				WithSection(section)
+		}
+		makeBrace = func(id contracts.StrID, start, end *structure.Node) *structure.Element {
+			return structure.MakeElementBuilder(id).
+				WithStartNode(start, braceLink).
+				WithEndNode(end, braceLink).
+				WithMaterial(material).
+				WithSection(section).
+				WithAxialBehavior(behavior).
+				Build()
+		}
+
+		leftColumn  = makeBar("left-column", n1, n3).Build()
+		rightColumn = makeBar("right-column", n2, n4).Build()
+		beam        = makeBar("beam", n3, n4).
+				AddConcentratedLoad(load.MakeConcentrated(load.FX, false, nums.MinT, force)).
+				Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{"n1": n1, "n2": n2, "n3": n3, "n4": n4},
+		[]*structure.Element{
+			leftColumn,
+			rightColumn,
+			beam,
+			makeBrace("brace-1", n1, n4),
+			makeBrace("brace-2", n2, n3),
+		},
+	)
+}
+
+func elementSolutionById(solution *process.Solution, id contracts.StrID) *process.ElementSolution {
+	for _, element := range solution.Elements {
+		if element.GetID() == id {
+			return element
+		}
+	}
+
+	panic("No element with id " + string(id))
+}
@@ -0,0 +1,212 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	thermalMaterial = &structure.Material{
+		Name:             "steel",
+		YoungMod:         20e6,
+		PoissonRatio:     1,
+		ThermalExpansion: 1.2e-5,
+	}
+	thermalSection = &structure.Section{
+		Name:    "IPE 120",
+		Area:    14,
+		IStrong: 318,
+		IWeak:   28,
+		SStrong: 53,
+		SWeak:   9,
+		Depth:   12,
+	}
+	// The stresses come from the derivatives of the PCG solver's displacements, whose error is
+	// amplified by the Young modulus: noise around 1e-5, negligible against E·α·ΔT ≈ 7200.
+	thermalStressError = 1e-2
+)
+
+func TestCantileverBeamWithThermalLoads(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		alpha       = thermalMaterial.ThermalExpansion
+		uniformTemp = 30.0
+		gradient    = 20.0
+		str         = makeThermalLoadsBeamStructure(&structure.NilConstraint, []*load.ThermalLoad{
+			load.MakeThermal(load.UniformTemperature, uniformTemp),
+			load.MakeThermal(load.TemperatureGradient, gradient),
+		})
+		sol       = solveStructure(str)
+		element   = sol.Elements[0]
+		last      = len(element.GlobalXDispl) - 1
+		curvature = -alpha * gradient / thermalSection.Depth
+	)
+
+	t.Run("free end displacements", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(element.GlobalXDispl[last].Value, alpha*uniformTemp*length, displError))
+		assert.True(t, nums.FloatsEqualEps(element.GlobalYDispl[last].Value, 0.5*curvature*length*length, displError))
+		assert.True(t, nums.FloatsEqualEps(element.GlobalZRot[last].Value, curvature*length, displError))
+	})
+
+	t.Run("no stresses", func(t *testing.T) {
+		for _, axial := range element.AxialStress {
+			assert.True(t, nums.FloatsEqualEps(axial.Value, 0.0, thermalStressError))
+		}
+		for _, bending := range element.BendingMoment {
+			assert.True(t, nums.FloatsEqualEps(bending.Value, 0.0, thermalStressError))
+		}
+	})
+}
+
+func TestFixedBeamWithThermalLoads(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		alpha       = thermalMaterial.ThermalExpansion
+		youngMod    = thermalMaterial.YoungMod
+		uniformTemp = 30.0
+		gradient    = 20.0
+		str         = makeThermalLoadsBeamStructure(&structure.FullConstraint, []*load.ThermalLoad{
+			load.MakeThermal(load.UniformTemperature, uniformTemp),
+			load.MakeThermal(load.TemperatureGradient, gradient),
+		})
+		sol       = solveStructure(str)
+		element   = sol.Elements[0]
+		reactions = sol.NodeReactions()
+	)
+
+	t.Run("no displacements", func(t *testing.T) {
+		for i := range element.GlobalXDispl {
+			assert.True(t, nums.FloatsEqualEps(element.GlobalXDispl[i].Value, 0.0, displError))
+			assert.True(t, nums.FloatsEqualEps(element.GlobalYDispl[i].Value, 0.0, displError))
+		}
+	})
+
+	t.Run("compression axial stress", func(t *testing.T) {
+		want := -youngMod * alpha * uniformTemp
+
+		for _, axial := range element.AxialStress {
+			assert.True(t, nums.FloatsEqualEps(axial.Value, want, thermalStressError))
+		}
+	})
+
+	t.Run("constant bending moment", func(t *testing.T) {
+		want := youngMod * thermalSection.IStrong * alpha * gradient / thermalSection.Depth
+
+		for _, bending := range element.BendingMoment {
+			assert.True(t, nums.FloatsEqualEps(bending.Value, want, thermalStressError))
+		}
+	})
+
+	t.Run("reactions push the bar's ends inwards", func(t *testing.T) {
+		want := youngMod * thermalSection.Area * alpha * uniformTemp
+
+		assert.True(t, nums.FloatsEqualEps(reactions["n1"].Fx(), want, 1.0))
+		assert.True(t, nums.FloatsEqualEps(reactions["n2"].Fx(), -want, 1.0))
+	})
+}
+
+// The bar is pinned at both ends, simply supported, hence the gradient bends it with a constant
+// curvature and no stresses. The supports fix the nodes' rotations, which the bar's links release.
+func TestPinnedBarWithTemperatureGradient(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		alpha     = thermalMaterial.ThermalExpansion
+		gradient  = 20.0
+		str       = makeThermalLoadsPinnedBarStructure(load.MakeThermal(load.TemperatureGradient, gradient))
+		sol       = solveStructure(str)
+		element   = sol.Elements[0]
+		curvature = -alpha * gradient / thermalSection.Depth
+	)
+
+	t.Run("the bar is sliced", func(t *testing.T) {
+		assert.Greater(t, len(element.GlobalYDispl), 2)
+	})
+
+	t.Run("deflection", func(t *testing.T) {
+		for _, displ := range element.GlobalYDispl {
+			var (
+				x    = displ.T.Value() * length
+				want = 0.5 * curvature * x * (x - length)
+			)
+
+			assert.True(t, nums.FloatsEqualEps(displ.Value, want, displError), "at x = %f", x)
+		}
+	})
+
+	t.Run("no bending moment", func(t *testing.T) {
+		for _, bending := range element.BendingMoment {
+			assert.True(t, nums.FloatsEqualEps(bending.Value, 0.0, thermalStressError))
+		}
+	})
+}
+
+func makeThermalLoadsPinnedBarStructure(thermalLoad *load.ThermalLoad) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("n2", g2d.MakePoint(length, 0), structure.MakeConstraint(false, true, true))
+		bar     = structure.MakeElementBuilder(
+			"bar",
+		).WithStartNode(
+			nodeOne, &structure.DispConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.DispConstraint,
+		).WithMaterial(
+			thermalMaterial,
+		).WithSection(
+			thermalSection,
+		).AddThermalLoads(
+			[]*load.ThermalLoad{thermalLoad},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{bar},
+	)
+}
+
+func makeThermalLoadsBeamStructure(
+	endConstraint *structure.Constraint,
+	thermalLoads []*load.ThermalLoad,
+) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("n1", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("n2", g2d.MakePoint(length, 0), endConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			thermalMaterial,
+		).WithSection(
+			thermalSection,
+		).AddThermalLoads(
+			thermalLoads,
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}
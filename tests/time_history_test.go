@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/preprocess"
+	"github.com/angelsolaorbaiceta/inkfem/process"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+// The massless cantilever column with a mass at its top behaves as a single degree of freedom
+// oscillator in the horizontal direction, with the column's lateral stiffness.
+func TestCantileverOscillatorTimeHistory(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		mass      = 2.5
+		force     = 1000.0
+		stiffness = 3.0 * material.YoungMod * section.IStrong / (length * length * length)
+		omega     = math.Sqrt(stiffness / mass)
+		period    = 2.0 * math.Pi / omega
+		staticDx  = force / stiffness
+		str       = makeMassiveCantileverColumnStructure(
+			0.0, load.MakeConcentrated(load.FX, false, nums.MaxT, force),
+		)
+	)
+
+	str.SetNodalMasses([]*structure.NodalMass{{NodeID: "top", Mass: mass}})
+
+	var (
+		pre     = preprocess.StructureModel(str, &preprocess.PreprocessOptions{})
+		options = process.TimeHistoryOptions{
+			TimeStep: period / 200.0,
+			Duration: period,
+			Nodes:    []contracts.StrID{"top", "base"},
+			Bars:     []contracts.StrID{"column"},
+		}
+	)
+
+	t.Run("suddenly applied load", func(t *testing.T) {
+		options.LoadFunction = load.MakeTimeFunction([]float64{0.0, 2.0 * period}, []float64{1.0, 1.0})
+		solution := process.SolveTimeHistory(pre, options)
+
+		assert.Equal(t, 201, len(solution.Steps))
+		assert.Equal(t, []contracts.StrID{"base"}, solution.ReactionNodes)
+
+		for _, step := range solution.Steps {
+			var (
+				want = staticDx * (1.0 - math.Cos(omega*step.Time))
+				got  = step.Displacements["top"][0]
+			)
+
+			assert.InDelta(t, want, got, 1e-3*2.0*staticDx, "at t = %f", step.Time)
+		}
+
+		half := solution.Steps[100]
+		assert.InDelta(t, 2.0*staticDx, half.Displacements["top"][0], 1e-3*staticDx)
+
+		// The base reaction balances the elastic force of the column
+		assert.InDelta(t, -2.0*force, half.Reactions["base"][0], 1e-2*force)
+		assert.InDelta(t, 2.0*force*length, math.Abs(half.Forces["column"][2]), 1e-2*force*length)
+	})
+
+	t.Run("ground acceleration", func(t *testing.T) {
+		var (
+			accel     = 9.81
+			options   = options
+			wantPeak  = 2.0 * mass * accel / stiffness
+			gotPeak   = 0.0
+			noDamping float64
+		)
+
+		options.LoadFunction = nil
+		options.GroundAccelerationX = load.MakeTimeFunction([]float64{0.0, 2.0 * period}, []float64{accel, accel})
+
+		for _, step := range process.SolveTimeHistory(pre, options).Steps {
+			gotPeak = math.Max(gotPeak, math.Abs(step.Displacements["top"][0]))
+		}
+		noDamping = gotPeak
+
+		assert.InDelta(t, wantPeak, gotPeak, 1e-3*wantPeak)
+
+		t.Run("damping reduces the peak", func(t *testing.T) {
+			options.DampingRatio = 0.05
+			options.DampingFrequencies = [2]float64{omega, omega}
+			gotPeak = 0.0
+
+			for _, step := range process.SolveTimeHistory(pre, options).Steps {
+				gotPeak = math.Max(gotPeak, math.Abs(step.Displacements["top"][0]))
+			}
+
+			// Peak of the damped step response: 1 + exp(-ζπ/sqrt(1 - ζ²)) times the static value
+			var (
+				ratio = 0.05
+				want  = 0.5 * wantPeak * (1.0 + math.Exp(-ratio*math.Pi/math.Sqrt(1.0-ratio*ratio)))
+			)
+
+			assert.Less(t, gotPeak, noDamping)
+			assert.InDelta(t, want, gotPeak, 5e-3*want)
+		})
+	})
+
+	t.Run("HHT-α dissipates the response", func(t *testing.T) {
+		options.LoadFunction = load.MakeTimeFunction([]float64{0.0, 2.0 * period}, []float64{1.0, 1.0})
+		options.Alpha = -0.1
+		solution := process.SolveTimeHistory(pre, options)
+
+		assert.InDelta(t, 2.0*staticDx, solution.Steps[100].Displacements["top"][0], 1e-2*staticDx)
+	})
+}
+
+func TestRayleighCoefficients(t *testing.T) {
+	var (
+		ratio                 = 0.05
+		omegaI, omegaJ        = 10.0, 40.0
+		massCoeff, stiffCoeff = process.RayleighCoefficients(ratio, omegaI, omegaJ)
+	)
+
+	for _, omega := range []float64{omegaI, omegaJ} {
+		got := 0.5 * (massCoeff/omega + stiffCoeff*omega)
+		assert.InDelta(t, ratio, got, 1e-12)
+	}
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angelsolaorbaiceta/inkfem/build"
+	"github.com/angelsolaorbaiceta/inkfem/contracts"
+	"github.com/angelsolaorbaiceta/inkfem/structure"
+	"github.com/angelsolaorbaiceta/inkfem/structure/load"
+	"github.com/angelsolaorbaiceta/inkgeom/g2d"
+	"github.com/angelsolaorbaiceta/inkgeom/nums"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	shearMaterial = &structure.Material{
+		Name:         "steel",
+		YoungMod:     20e6,
+		ShearMod:     8e6,
+		PoissonRatio: 0.25,
+	}
+	shearSection = &structure.Section{
+		Name:      "IPE 120",
+		Area:      14,
+		IStrong:   318,
+		IWeak:     28,
+		SStrong:   53,
+		SWeak:     9,
+		ShearArea: 6,
+	}
+)
+
+func TestTimoshenkoCantileverBeamWithConcentratedLoadAtEnd(t *testing.T) {
+	build.ReadBuildInfo()
+
+	var (
+		force     = -2000.0
+		ei        = shearMaterial.YoungMod * shearSection.IStrong
+		gas       = shearMaterial.ShearMod * shearSection.ShearArea
+		str       = makeTimoshenkoCantileverBeamStructure(force)
+		sol       = solveStructure(str)
+		element   = sol.Elements[0]
+		last      = len(element.GlobalYDispl) - 1
+		bendDispl = force * length * length * length / (3.0 * ei)
+		shearDisp = force * length / gas
+	)
+
+	t.Run("free end displacement includes the shear deformation", func(t *testing.T) {
+		assert.True(t, nums.FloatsEqualEps(element.GlobalYDispl[last].Value, bendDispl+shearDisp, displError))
+	})
+
+	t.Run("free end rotation isn't affected by the shear deformation", func(t *testing.T) {
+		want := force * length * length / (2.0 * ei)
+		assert.True(t, nums.FloatsEqualEps(element.GlobalZRot[last].Value, want, displError))
+	})
+
+	t.Run("shear force and bending moment", func(t *testing.T) {
+		for _, shear := range element.ShearForce {
+			assert.True(t, nums.FloatsEqualEps(math.Abs(shear.Value), math.Abs(force), 1e-2))
+		}
+
+		fixedEndMoment := element.BendingMoment[0].Value
+		assert.True(t, nums.FloatsEqualEps(math.Abs(fixedEndMoment), math.Abs(force*length), 1.0))
+	})
+}
+
+func makeTimoshenkoCantileverBeamStructure(force float64) *structure.Structure {
+	var (
+		nodeOne = structure.MakeNode("fixed-node", g2d.MakePoint(0, 0), &structure.FullConstraint)
+		nodeTwo = structure.MakeNode("free-node", g2d.MakePoint(length, 0), &structure.NilConstraint)
+		beam    = structure.MakeElementBuilder(
+			"beam",
+		).WithStartNode(
+			nodeOne, &structure.FullConstraint,
+		).WithEndNode(
+			nodeTwo, &structure.FullConstraint,
+		).WithMaterial(
+			shearMaterial,
+		).WithSection(
+			shearSection,
+		).AddConcentratedLoads(
+			[]*load.ConcentratedLoad{load.MakeConcentrated(load.FY, true, nums.MaxT, force)},
+		).Build()
+	)
+
+	return structure.Make(
+		structure.StrMetadata{MajorVersion: 1, MinorVersion: 0},
+		map[contracts.StrID]*structure.Node{
+			nodeOne.GetID(): nodeOne,
+			nodeTwo.GetID(): nodeTwo,
+		},
+		[]*structure.Element{beam},
+	)
+}